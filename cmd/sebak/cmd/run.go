@@ -42,6 +42,7 @@ var (
 	flagBlockTime                  string = common.GetENVValue("SEBAK_BLOCK_TIME", "5s")
 	flagBlockTimeDelta             string = common.GetENVValue("SEBAK_BLOCK_TIME_DELTA", "1s")
 	flagDebugPProf                 bool   = common.GetENVValue("SEBAK_DEBUG_PPROF", "0") == "1"
+	flagDebugFaults                bool   = common.GetENVValue("SEBAK_DEBUG_FAULTS", "0") == "1"
 	flagKPSecretSeed               string = common.GetENVValue("SEBAK_SECRET_SEED", "")
 	flagLog                        string = common.GetENVValue("SEBAK_LOG", "")
 	flagHTTPLog                    string = common.GetENVValue("SEBAK_HTTP_LOG", "")
@@ -82,8 +83,17 @@ var (
 
 	flagWatcherMode   bool   = common.GetENVValue("SEBAK_WATCHER_MODE", "0") == "1"
 	flagWatchInterval string = common.GetENVValue("SEBAK_WATCH_INTERVAL", "5s")
+	flagLightMode     bool   = common.GetENVValue("SEBAK_LIGHT_MODE", "0") == "1"
 
 	flagDiscovery cmdcommon.ListFlags // "SEBAK_DISCOVERY"
+
+	flagProposerSelector string = common.GetENVValue("SEBAK_PROPOSER_SELECTOR", common.ProposerSelectorSequentialName)
+	flagValidatorWeights string = common.GetENVValue("SEBAK_VALIDATOR_WEIGHTS", "")
+
+	flagTxFeeValidatorShare string = common.GetENVValue("SEBAK_TX_FEE_VALIDATOR_SHARE", "0")
+	flagTxFeeDistribution   string = common.GetENVValue("SEBAK_TX_FEE_DISTRIBUTION", common.TxFeeDistributionProposer)
+
+	flagInflationToDelegators bool = common.GetENVValue("SEBAK_INFLATION_TO_DELEGATORS", "0") == "1"
 )
 
 var (
@@ -119,6 +129,8 @@ var (
 	jsonrpcbindEndpoint     *common.Endpoint
 	watchInterval           time.Duration
 	discoveryEndpoints      []*common.Endpoint
+	validatorWeights        map[string]uint64
+	txFeeValidatorShare     uint64
 
 	logLevel logging.Lvl
 	log      logging.Logger = logging.New("module", "main")
@@ -215,6 +227,7 @@ func init() {
 	)
 
 	nodeCmd.Flags().BoolVar(&flagDebugPProf, "debug-pprof", flagDebugPProf, "set debug pprof")
+	nodeCmd.Flags().BoolVar(&flagDebugFaults, "debug-faults", flagDebugFaults, "enable fault injection of outgoing messages by debug api")
 
 	nodeCmd.Flags().StringVar(&flagSyncPoolSize, "sync-pool-size", flagSyncPoolSize, "sync pool size")
 	nodeCmd.Flags().StringVar(&flagSyncFetchTimeout, "sync-fetch-timeout", flagSyncFetchTimeout, "sync fetch timeout")
@@ -229,7 +242,13 @@ func init() {
 	nodeCmd.Flags().StringVar(&flagCongressAddress, "set-congress-address", flagCongressAddress, "set congress address")
 	nodeCmd.Flags().BoolVar(&flagWatcherMode, "watcher-mode", flagWatcherMode, "watcher mode")
 	nodeCmd.Flags().StringVar(&flagWatchInterval, "watch-interval", flagWatchInterval, "watch interval")
+	nodeCmd.Flags().BoolVar(&flagLightMode, "light", flagLightMode, "light mode; follows only the blocks and commit certificates verified by --validators")
 	nodeCmd.Flags().Var(&flagDiscovery, "discovery", "initial endpoint for discovery")
+	nodeCmd.Flags().StringVar(&flagProposerSelector, "proposer-selector", flagProposerSelector, "proposer selector: 'sequential', 'hash', 'weighted' or 'skip-missed'; must be same in all validators")
+	nodeCmd.Flags().StringVar(&flagValidatorWeights, "validator-weights", flagValidatorWeights, "weights of validators for 'weighted' proposer selector: '<address>=<weight> ...'")
+	nodeCmd.Flags().StringVar(&flagTxFeeValidatorShare, "tx-fee-validator-share", flagTxFeeValidatorShare, "percentage of collected transaction fee for validators, 0 to 100; must be same in all validators")
+	nodeCmd.Flags().StringVar(&flagTxFeeDistribution, "tx-fee-distribution", flagTxFeeDistribution, "receivers of validator share of transaction fee: 'proposer' or 'signers'; must be same in all validators")
+	nodeCmd.Flags().BoolVar(&flagInflationToDelegators, "inflation-to-delegators", flagInflationToDelegators, "route inflation to delegators pro rata to delegated stake; must be same in all validators")
 
 	rootCmd.AddCommand(nodeCmd)
 }
@@ -419,6 +438,8 @@ func parseFlagsNode() {
 
 	if validators, err = parseFlagValidators(flagValidators); err != nil {
 		cmdcommon.PrintFlagsError(nodeCmd, "--validators", err)
+	} else if flagLightMode && len(validators) < 1 {
+		cmdcommon.PrintFlagsError(nodeCmd, "--validators", errors.New("--validators must be given in light mode"))
 	}
 
 	if storageConfig, err = storage.NewConfigFromString(flagStorageConfigString); err != nil {
@@ -512,6 +533,21 @@ func parseFlagsNode() {
 		}
 	}
 
+	if ok := common.ProposerSelectorNames[flagProposerSelector]; !ok {
+		cmdcommon.PrintFlagsError(nodeCmd, "--proposer-selector", fmt.Errorf("unknown proposer selector: '%s'", flagProposerSelector))
+	}
+	if validatorWeights, err = parseFlagValidatorWeights(flagValidatorWeights); err != nil {
+		cmdcommon.PrintFlagsError(nodeCmd, "--validator-weights", err)
+	}
+	if txFeeValidatorShare, err = strconv.ParseUint(flagTxFeeValidatorShare, 10, 64); err != nil {
+		cmdcommon.PrintFlagsError(nodeCmd, "--tx-fee-validator-share", err)
+	} else if txFeeValidatorShare > 100 {
+		cmdcommon.PrintFlagsError(nodeCmd, "--tx-fee-validator-share", fmt.Errorf("must be between 0 and 100: %d", txFeeValidatorShare))
+	}
+	if ok := common.TxFeeDistributionNames[flagTxFeeDistribution]; !ok {
+		cmdcommon.PrintFlagsError(nodeCmd, "--tx-fee-distribution", fmt.Errorf("unknown transaction fee distribution: '%s'", flagTxFeeDistribution))
+	}
+
 	if logLevel, err = logging.LvlFromString(flagLogLevel); err != nil {
 		cmdcommon.PrintFlagsError(nodeCmd, "--log-level", err)
 	}
@@ -585,6 +621,10 @@ func parseFlagsNode() {
 		}
 	}
 
+	if flagLightMode && flagWatcherMode {
+		cmdcommon.PrintFlagsError(nodeCmd, "--light", errors.New("--light can not be used with --watcher-mode"))
+	}
+
 	if len(flagRateLimitAPI) < 1 {
 		re := strings.Fields(common.GetENVValue("SEBAK_RATE_LIMIT_API", ""))
 		for _, r := range re {
@@ -639,6 +679,12 @@ func parseFlagsNode() {
 	parsedFlags = append(parsedFlags, "\n\thttp-cache-pool-size", httpCachePoolSize)
 	parsedFlags = append(parsedFlags, "\n\tdiscovery", discoveryEndpoints)
 	parsedFlags = append(parsedFlags, "\n\twatcher-mode", flagWatcherMode)
+	parsedFlags = append(parsedFlags, "\n\tlight", flagLightMode)
+	parsedFlags = append(parsedFlags, "\n\tproposer-selector", flagProposerSelector)
+	parsedFlags = append(parsedFlags, "\n\tvalidator-weights", validatorWeights)
+	parsedFlags = append(parsedFlags, "\n\ttx-fee-validator-share", txFeeValidatorShare)
+	parsedFlags = append(parsedFlags, "\n\ttx-fee-distribution", flagTxFeeDistribution)
+	parsedFlags = append(parsedFlags, "\n\tinflation-to-delegators", flagInflationToDelegators)
 
 	// create current Node
 	localNode, err = node.NewLocalNode(kp, bindEndpoint, "")
@@ -682,6 +728,25 @@ func parseHTTPCacheRedisAddrs(s string) (map[string]string, error) {
 	return addrs, nil
 }
 
+func parseFlagValidatorWeights(s string) (map[string]uint64, error) {
+	weights := map[string]uint64{}
+	for _, w := range strings.Fields(s) {
+		pair := strings.Split(w, "=")
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("weight has wrong format: '%s'", w)
+		}
+		if _, err := keypair.Parse(pair[0]); err != nil {
+			return nil, fmt.Errorf("invalid validator address: '%s'", pair[0])
+		}
+		weight, err := strconv.ParseUint(pair[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight: '%s'", w)
+		}
+		weights[pair[0]] = weight
+	}
+	return weights, nil
+}
+
 func getTimeDuration(str string, defaultValue time.Duration, errMessage string) time.Duration {
 	if strings.TrimSpace(str) == "" {
 		return defaultValue
@@ -704,7 +769,10 @@ func runNode() error {
 		return err
 	}
 
-	nt := network.NewHTTP2Network(networkConfig)
+	var nt network.Network = network.NewHTTP2Network(networkConfig)
+	if flagDebugFaults {
+		nt = network.NewFaultNetwork(nt, network.NewFaultInjector(time.Now().UnixNano()))
+	}
 
 	policy, err := consensus.NewDefaultVotingThresholdPolicy(int(threshold))
 	if err != nil {
@@ -747,9 +815,20 @@ func runNode() error {
 		TxPoolClientLimit:      int(txPoolClientLimit),
 		TxPoolNodeLimit:        int(txPoolNodeLimit),
 		JSONRPCEndpoint:        jsonrpcbindEndpoint,
-		WatcherMode:            flagWatcherMode,
+		WatcherMode:            flagWatcherMode || flagLightMode,
+		LightMode:              flagLightMode,
 		DiscoveryEndpoints:     discoveryEndpoints,
+		ProposerSelector:       flagProposerSelector,
+		ValidatorWeights:       validatorWeights,
+		TxFeeValidatorShare:    txFeeValidatorShare,
+		TxFeeDistribution:      flagTxFeeDistribution,
+		InflationToDelegators:  flagInflationToDelegators,
+	}
+	// the consensus WAL is kept under the storage directory
+	if !conf.WatcherMode && storageConfig.Scheme == "file" {
+		conf.ConsensusWALPath = filepath.Join(storageConfig.Path, "consensus.wal")
 	}
+
 	connectionManager := network.NewValidatorConnectionManager(localNode, nt, policy, conf)
 
 	tp := transaction.NewPool(conf)
@@ -765,6 +844,7 @@ func runNode() error {
 	c.CheckBlockHeightInterval = syncCheckInterval
 	c.CheckPrevBlockInterval = syncCheckPrevBlock
 	c.WatchInterval = watchInterval
+	c.ThresholdPolicy = policy
 
 	syncer := c.NewSyncer()
 
@@ -874,7 +954,11 @@ func runNode() error {
 			log.Crit(err.Error())
 			return err
 		}
+	}
 
+	// In light mode, the blocks are fetched from `--validators` and verified
+	// by them
+	if conf.WatcherMode {
 		watcher := c.NewWatcher(syncer)
 		g.Add(func() error {
 			return watcher.Start()
@@ -29,6 +29,8 @@ var (
 	flagDry           bool
 	flagFreeze        bool
 	flagVerbose       bool
+	flagMemo          string
+	flagMemoType      string = string(transaction.MemoText)
 )
 
 func init() {
@@ -75,6 +77,15 @@ func init() {
 				cmdcommon.PrintFlagsError(c, "--endpoint", err)
 			}
 
+			var memo *transaction.Memo
+			if len(flagMemo) > 0 {
+				if m, err := transaction.NewMemo(transaction.MemoType(flagMemoType), flagMemo); err != nil {
+					cmdcommon.PrintFlagsError(c, "--memo", err)
+				} else {
+					memo = &m
+				}
+			}
+
 			// TODO: Validate input transaction (does the sender have enough money?)
 
 			// At the moment this is a rather crude implementation: There is no support for pooling of transaction,
@@ -122,6 +133,7 @@ func init() {
 				tx = MakeTransactionPayment(sender, receiver, amount, senderAccount.SequenceID)
 			}
 
+			tx.B.Memo = memo
 			tx.Sign(sender, []byte(flagNetworkID))
 
 			// Send request
@@ -151,6 +163,8 @@ func init() {
 	PaymentCmd.Flags().BoolVar(&flagFreeze, "freeze", flagFreeze, "When present, the payment is a frozen account creation. Imply --create.")
 	PaymentCmd.Flags().BoolVar(&flagDry, "dry-run", flagDry, "Print the transaction instead of sending it")
 	PaymentCmd.Flags().BoolVar(&flagVerbose, "verbose", flagVerbose, "Print extra data (transaction sent, before/after balance...)")
+	PaymentCmd.Flags().StringVar(&flagMemo, "memo", flagMemo, "memo of transaction")
+	PaymentCmd.Flags().StringVar(&flagMemoType, "memo-type", flagMemoType, "type of memo: text, id or hash")
 }
 
 ///
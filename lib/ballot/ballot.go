@@ -179,6 +179,24 @@ func (b Ballot) ProposerConfirmed() string {
 	return b.B.Proposed.Confirmed
 }
 
+// SetProposerConfirmed sets the time, at which the proposer proposes; if it
+// is not set, `SignByProposer` sets the current time. The proposer sets it
+// before selecting the transactions, so the transactions are checked with
+// the same time by the proposer and the other validators.
+func (b *Ballot) SetProposerConfirmed(confirmed string) {
+	b.B.Proposed.Confirmed = confirmed
+}
+
+func (b Ballot) StateRoot() string {
+	return b.B.Proposed.StateRoot
+}
+
+// SetStateRoot sets the state root, which is calculated by proposer; it should
+// be set before signing.
+func (b *Ballot) SetStateRoot(root string) {
+	b.B.Proposed.StateRoot = root
+}
+
 func (b Ballot) Vote() voting.Hole {
 	return b.B.Vote
 }
@@ -205,7 +223,9 @@ func (b *Ballot) SignByProposer(kp keypair.KP, networkID []byte) {
 	ptx.Sign(kp, networkID)
 	b.SetProposerTransaction(ptx)
 
-	b.B.Proposed.Confirmed = common.NowISO8601()
+	if len(b.B.Proposed.Confirmed) < 1 {
+		b.B.Proposed.Confirmed = common.NowISO8601()
+	}
 	hash := common.MustMakeObjectHash(b.B.Proposed)
 	signature, _ := keypair.MakeSignature(kp, networkID, string(hash))
 	b.H.ProposerSignature = base58.Encode(signature)
@@ -225,6 +245,17 @@ func (b *Ballot) Sign(kp keypair.KP, networkID []byte) {
 	return
 }
 
+// SignBlock signs the hash of the block, which will be created by this ballot.
+// The signature is collected into the commit certificate of the block.
+func (b *Ballot) SignBlock(kp keypair.KP, networkID []byte, blockHash string) {
+	signature, _ := keypair.MakeSignature(kp, networkID, blockHash)
+	b.H.BlockSignature = base58.Encode(signature)
+}
+
+func (b Ballot) BlockSignature() string {
+	return b.H.BlockSignature
+}
+
 func (b Ballot) VerifyProposer(networkID []byte) (err error) {
 	var kp keypair.KP
 	if kp, err = keypair.Parse(b.B.Proposed.Proposer); err != nil {
@@ -271,10 +302,11 @@ func (b *Ballot) SetProposerTransaction(ptx ProposerTransaction) {
 }
 
 type BallotHeader struct {
-	Version           string `json:"version"`            // version of `BallotBody`
-	Hash              string `json:"hash"`               // hash of `BallotBody`
-	Signature         string `json:"signature"`          // signed by source node of <networkID> + `Hash`
-	ProposerSignature string `json:"proposer_signature"` // signed by proposer of <networkID> + `Hash` of `BallotBodyProposed`
+	Version           string `json:"version"`                   // version of `BallotBody`
+	Hash              string `json:"hash"`                      // hash of `BallotBody`
+	Signature         string `json:"signature"`                 // signed by source node of <networkID> + `Hash`
+	ProposerSignature string `json:"proposer_signature"`        // signed by proposer of <networkID> + `Hash` of `BallotBodyProposed`
+	BlockSignature    string `json:"block_signature,omitempty"` // signed by source node of <networkID> + hash of new block; only in ACCEPT ballot
 }
 
 type BallotBodyProposed struct {
@@ -283,6 +315,7 @@ type BallotBodyProposed struct {
 	VotingBasis         voting.Basis        `json:"voting_basis"`
 	Transactions        []string            `json:"transactions"`
 	ProposerTransaction ProposerTransaction `json:"proposer_transaction"`
+	StateRoot           string              `json:"state_root"` // state root after the proposed transactions are applied
 }
 
 type BallotBody struct {
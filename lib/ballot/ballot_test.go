@@ -211,6 +211,28 @@ func TestNewBallot(t *testing.T) {
 	require.Equal(t, p.Address(), b.Proposer())
 }
 
+func TestBallotSetProposerConfirmed(t *testing.T) {
+	conf := common.NewTestConfig()
+	kp := keypair.Random()
+	basis := voting.Basis{Round: 0, Height: 1, BlockHash: "hahaha", TotalTxs: 1}
+
+	confirmed := common.FormatISO8601(time.Now().Add(-time.Second))
+
+	b := NewBallot(kp.Address(), kp.Address(), basis, []string{})
+	b.SetProposerConfirmed(confirmed)
+	b.Sign(kp, conf.NetworkID)
+
+	// the confirmed time, which is set before signing, is kept
+	require.Equal(t, confirmed, b.ProposerConfirmed())
+
+	{ // without it, the current time is set
+		b := NewBallot(kp.Address(), kp.Address(), basis, []string{})
+		b.Sign(kp, conf.NetworkID)
+		require.NotEmpty(t, b.ProposerConfirmed())
+		require.NotEqual(t, confirmed, b.ProposerConfirmed())
+	}
+}
+
 // In this test, we can check that the normal ballot(not expired) should be signed by proposer.
 func TestIsBallotWellFormed(t *testing.T) {
 	conf := common.NewTestConfig()
@@ -2,6 +2,7 @@ package ballot
 
 import (
 	"encoding/json"
+	"sort"
 
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/errors"
@@ -10,8 +11,17 @@ import (
 )
 
 var TypesProposerTransaction map[operation.OperationType]struct{} = map[operation.OperationType]struct{}{
-	operation.TypeCollectTxFee: struct{}{},
-	operation.TypeInflation:    struct{}{},
+	operation.TypeCollectTxFee:     struct{}{},
+	operation.TypeInflation:        struct{}{},
+	operation.TypeDistributeTxFee:  struct{}{},
+	operation.TypeDelegationReward: struct{}{},
+}
+
+// optionalTypesProposerTransaction is the operation types, which may not be
+// in `ProposerTransaction`.
+var optionalTypesProposerTransaction map[operation.OperationType]struct{} = map[operation.OperationType]struct{}{
+	operation.TypeDistributeTxFee:  struct{}{},
+	operation.TypeDelegationReward: struct{}{},
 }
 
 type ProposerTransaction struct {
@@ -71,7 +81,57 @@ func NewInflationFromBallot(blt Ballot, commonAccount string, initialBalance com
 	return
 }
 
-func NewProposerTransactionFromBallot(blt Ballot, opc operation.CollectTxFee, opi operation.Inflation) (ptx ProposerTransaction, err error) {
+// SplitTxFee splits the collected transaction fee by the validator share,
+// percentage of fee; the validator share is divided equally to the
+// recipients and the remainder, `rest` goes to the common account.
+func SplitTxFee(fee common.Amount, share uint64, recipients int) (each, rest common.Amount) {
+	if share > 100 {
+		share = 100
+	}
+	if recipients < 1 || share < 1 {
+		return 0, fee
+	}
+
+	validatorShare := uint64(fee)/100*share + uint64(fee)%100*share/100
+	each = common.Amount(validatorShare / uint64(recipients))
+	rest = fee - each*common.Amount(recipients)
+
+	return
+}
+
+// NewDistributeTxFeeFromBallot makes `operation.DistributeTxFee` from the
+// collected transaction fee of `opc`; the amount of `opc` is reduced to the
+// rest. If nothing to distribute, it returns false.
+func NewDistributeTxFeeFromBallot(blt Ballot, opc operation.CollectTxFee, share uint64, recipients []operation.TxFeeRecipient) (operation.CollectTxFee, operation.DistributeTxFee, bool) {
+	each, rest := SplitTxFee(opc.Amount, share, len(recipients))
+	if each < 1 {
+		return opc, operation.DistributeTxFee{}, false
+	}
+
+	sorted := make([]operation.TxFeeRecipient, len(recipients))
+	for i, r := range recipients {
+		r.Amount = each
+		sorted[i] = r
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Address < sorted[j].Address })
+
+	rd := blt.VotingBasis()
+	opc.Amount = rest
+
+	return opc, operation.NewDistributeTxFee(sorted, rd.Height, rd.BlockHash, rd.TotalTxs), true
+}
+
+// NewDelegationRewardFromBallot makes `operation.DelegationReward`, which
+// routes the inflation to the delegators by `rewards`.
+func NewDelegationRewardFromBallot(blt Ballot, rewards []operation.DelegatorReward) operation.DelegationReward {
+	rd := blt.VotingBasis()
+
+	return operation.NewDelegationReward(rewards, rd.Height, rd.BlockHash, rd.TotalTxs)
+}
+
+// NewProposerTransactionFromBallot makes `ProposerTransaction`; `optionals`
+// are the optional operations like `operation.DistributeTxFee`.
+func NewProposerTransactionFromBallot(blt Ballot, opc operation.CollectTxFee, opi operation.Inflation, optionals ...operation.Body) (ptx ProposerTransaction, err error) {
 	var ops []operation.Operation
 
 	var op operation.Operation
@@ -89,6 +149,13 @@ func NewProposerTransactionFromBallot(blt Ballot, opc operation.CollectTxFee, op
 		ops = append(ops, op)
 	}
 
+	for _, opb := range optionals {
+		if op, err = operation.NewOperation(opb); err != nil {
+			return
+		}
+		ops = append(ops, op)
+	}
+
 	ptx, err = NewProposerTransaction(blt.Proposer(), ops...)
 
 	return
@@ -184,6 +251,43 @@ func (p ProposerTransaction) IsWellFormedWithBallot(blt Ballot, conf common.Conf
 		}
 	}
 
+	// check OperationDelegationReward
+	if opb, found := blt.ProposerTransaction().DelegationReward(); found {
+		if opb.Height != rd.Height {
+			err = errors.InvalidOperation
+			return
+		}
+		if opb.BlockHash != rd.BlockHash {
+			err = errors.InvalidOperation
+			return
+		}
+		if opb.TotalTxs != rd.TotalTxs {
+			err = errors.InvalidOperation
+			return
+		}
+	}
+
+	// check OperationDistributeTxFee
+	if opb, found := blt.ProposerTransaction().DistributeTxFee(); found {
+		if len(blt.Transactions()) < 1 {
+			err = errors.InvalidOperation
+			return
+		}
+
+		if opb.Height != rd.Height {
+			err = errors.InvalidOperation
+			return
+		}
+		if opb.BlockHash != rd.BlockHash {
+			err = errors.InvalidOperation
+			return
+		}
+		if opb.TotalTxs != rd.TotalTxs {
+			err = errors.InvalidOperation
+			return
+		}
+	}
+
 	return
 }
 
@@ -229,6 +333,30 @@ func (p ProposerTransaction) Inflation() (opb operation.Inflation, err error) {
 	return
 }
 
+// DelegationReward returns the `operation.DelegationReward`; it is optional,
+// so returns false if not found.
+func (p ProposerTransaction) DelegationReward() (opb operation.DelegationReward, found bool) {
+	for _, op := range p.B.Operations {
+		if opb, found = op.B.(operation.DelegationReward); found {
+			return
+		}
+	}
+
+	return
+}
+
+// DistributeTxFee returns the `operation.DistributeTxFee`; it is optional,
+// so returns false if not found.
+func (p ProposerTransaction) DistributeTxFee() (opb operation.DistributeTxFee, found bool) {
+	for _, op := range p.B.Operations {
+		if opb, found = op.B.(operation.DistributeTxFee); found {
+			return
+		}
+	}
+
+	return
+}
+
 func (p *ProposerTransaction) UnmarshalJSON(b []byte) error {
 	var t transaction.Transaction
 	if err := json.Unmarshal(b, &t); err != nil {
@@ -254,7 +382,14 @@ func CheckProposerTransactionFee(c common.Checker, args ...interface{}) (err err
 func CheckProposerTransactionOperationTypes(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*transaction.Checker)
 
-	if len(checker.Transaction.B.Operations) != 2 {
+	expected := len(TypesProposerTransaction) - len(optionalTypesProposerTransaction)
+	for _, op := range checker.Transaction.B.Operations {
+		if _, found := optionalTypesProposerTransaction[op.H.Type]; found {
+			expected++
+		}
+	}
+
+	if len(checker.Transaction.B.Operations) != expected {
 		err = errors.InvalidProposerTransaction
 		return
 	}
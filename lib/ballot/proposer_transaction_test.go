@@ -0,0 +1,73 @@
+package ballot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/transaction/operation"
+	"boscoin.io/sebak/lib/voting"
+)
+
+func TestSplitTxFee(t *testing.T) {
+	cases := []struct {
+		fee        common.Amount
+		share      uint64
+		recipients int
+		each       common.Amount
+		rest       common.Amount
+	}{
+		{fee: 10000, share: 0, recipients: 3, each: 0, rest: 10000},
+		{fee: 10000, share: 30, recipients: 0, each: 0, rest: 10000},
+		{fee: 10000, share: 30, recipients: 1, each: 3000, rest: 7000},
+		{fee: 10000, share: 100, recipients: 3, each: 3333, rest: 1},
+		{fee: 10000, share: 200, recipients: 2, each: 5000, rest: 0},
+		{fee: 99, share: 50, recipients: 1, each: 49, rest: 50},
+		{fee: 1, share: 50, recipients: 1, each: 0, rest: 1},
+		{fee: common.MaximumBalance, share: 100, recipients: 1, each: common.MaximumBalance, rest: 0},
+	}
+
+	for _, c := range cases {
+		each, rest := SplitTxFee(c.fee, c.share, c.recipients)
+		require.Equal(t, c.each, each, "%v", c)
+		require.Equal(t, c.rest, rest, "%v", c)
+	}
+}
+
+func TestNewDistributeTxFeeFromBallot(t *testing.T) {
+	kp := keypair.Random()
+	basis := voting.Basis{Height: 3, BlockHash: "block-hash", TotalTxs: 4}
+	blt := NewBallot(kp.Address(), kp.Address(), basis, []string{"tx0", "tx1"})
+
+	opc := operation.NewCollectTxFee(kp.Address(), common.Amount(10000), 2, basis.Height, basis.BlockHash, basis.TotalTxs)
+
+	recipients := []operation.TxFeeRecipient{
+		{Address: keypair.Random().Address()},
+		{Address: keypair.Random().Address()},
+	}
+
+	{ // nothing to distribute
+		returned, _, found := NewDistributeTxFeeFromBallot(*blt, opc, 0, recipients)
+		require.False(t, found)
+		require.Equal(t, opc, returned)
+	}
+
+	returned, opd, found := NewDistributeTxFeeFromBallot(*blt, opc, 25, recipients)
+	require.True(t, found)
+	require.Equal(t, common.Amount(7500), returned.Amount)
+	require.Equal(t, common.Amount(2500), opd.GetAmount())
+	require.Equal(t, basis.Height, opd.Height)
+	require.Equal(t, basis.BlockHash, opd.BlockHash)
+	require.Equal(t, basis.TotalTxs, opd.TotalTxs)
+	require.NoError(t, opd.IsWellFormed(common.NewTestConfig()))
+
+	ptx, err := NewProposerTransactionFromBallot(*blt, returned, operation.Inflation{}, opd)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(ptx.B.Operations))
+
+	distributed, found := ptx.DistributeTxFee()
+	require.True(t, found)
+	require.Equal(t, opd, distributed)
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction/operation"
 )
 
 // BlockAccount is account model in block. the storage should support,
@@ -26,6 +28,16 @@ type BlockAccount struct {
 	Linked   string      `json:"linked"`
 	CodeHash []byte      `json:"code_hash"`
 	RootHash common.Hash `json:"root_hash"`
+	// Signers is set by `operation.SetSigners`; if nil, only the key of
+	// `Address` can sign for this account.
+	Signers *AccountSigners `json:"signers,omitempty"`
+}
+
+// AccountSigners is the multi-signature setting of `BlockAccount`.
+type AccountSigners struct {
+	MasterWeight uint8                `json:"master_weight"`
+	Signers      []operation.Signer   `json:"signers"`
+	Thresholds   operation.Thresholds `json:"thresholds"`
 }
 
 func NewBlockAccount(address string, balance common.Amount) *BlockAccount {
@@ -45,6 +57,55 @@ func (b *BlockAccount) IsFrozen() bool {
 	return b.Linked != ""
 }
 
+func (b *BlockAccount) IsMultisig() bool {
+	return b.Signers != nil
+}
+
+// SetSigners replaces the signers of account by `operation.SetSigners`.
+func (b *BlockAccount) SetSigners(opb operation.SetSigners) {
+	b.Signers = &AccountSigners{
+		MasterWeight: opb.MasterWeight,
+		Signers:      opb.Signers,
+		Thresholds:   opb.Thresholds,
+	}
+}
+
+// SignerWeight returns the weight of signer; the key of account itself has
+// weight 1 when the signers are not set.
+func (b *BlockAccount) SignerWeight(address string) uint8 {
+	if !b.IsMultisig() {
+		if address == b.Address {
+			return 1
+		}
+		return 0
+	}
+
+	if address == b.Address {
+		return b.Signers.MasterWeight
+	}
+	for _, s := range b.Signers.Signers {
+		if s.Address == address {
+			return s.Weight
+		}
+	}
+
+	return 0
+}
+
+// Threshold returns the minimum sum of signer weights for the given
+// `operation.ThresholdLevel`. At least one signature is always needed.
+func (b *BlockAccount) Threshold(level operation.ThresholdLevel) uint8 {
+	if !b.IsMultisig() {
+		return 1
+	}
+
+	if t := b.Signers.Thresholds.Get(level); t > 0 {
+		return t
+	}
+
+	return 1
+}
+
 func (b *BlockAccount) IncreaseSequenceID() {
 	b.SequenceID += 1
 }
@@ -78,11 +139,73 @@ func (b *BlockAccount) Save(st *storage.LevelDBBackend) (err error) {
 		Address:    b.Address,
 		Balance:    b.GetBalance(),
 	}
-	err = bac.Save(st)
+	if err = bac.Save(st); err != nil {
+		return
+	}
+
+	// only the frozen account can delegate
+	if b.IsFrozen() {
+		if err = updateDelegationAmount(st, b.Address, b.Balance); err != nil {
+			return
+		}
+	}
+
+	// the block is saved before it's transactions are finished, so the
+	// latest block is the block, which changes the account.
+	history := BlockAccountHistory{Account: *b}
+	if history.Height, err = getLatestBlockHeight(st); err != nil {
+		return
+	}
+	err = history.Save(st)
 
 	return
 }
 
+// Remove deletes the account and it's `BlockAccountSequenceID` records and
+// writes the tombstone of `BlockAccountHistory` at the latest block height.
+// The records must be already stored, not in the current batch.
+func (b *BlockAccount) Remove(st *storage.LevelDBBackend) (err error) {
+	var keys []string
+	iterFunc, closeFunc := st.GetIterator(GetBlockAccountSequenceIDByAddressKeyPrefix(b.Address), nil)
+	for {
+		item, hasNext := iterFunc()
+		if !hasNext {
+			break
+		}
+
+		var key string
+		common.MustUnmarshalJSON(item.Value, &key)
+		keys = append(keys, key, string(item.Key))
+	}
+	closeFunc()
+
+	for _, key := range keys {
+		if err = st.Remove(key); err != nil && err != errors.StorageRecordDoesNotExist {
+			return
+		}
+	}
+
+	history := BlockAccountHistory{Account: *b, Removed: true}
+	if history.Height, err = getLatestBlockHeight(st); err != nil {
+		return
+	}
+	if err = history.Save(st); err != nil {
+		return
+	}
+
+	return st.Remove(GetBlockAccountKey(b.Address))
+}
+
+// IsMergedBlockAccount checks the account was removed by
+// `operation.AccountMerge`.
+func IsMergedBlockAccount(st *storage.LevelDBBackend, address string) bool {
+	iterFunc, closeFunc := GetBlockOperationsBySourceAndType(st, address, operation.TypeAccountMerge, nil)
+	_, found, _ := iterFunc()
+	closeFunc()
+
+	return found
+}
+
 func GetBlockAccountKey(address string) string {
 	return fmt.Sprintf("%s%s", common.BlockAccountPrefixAddress, address)
 }
@@ -131,8 +254,13 @@ func GetBlockAccountsByCreated(st *storage.LevelDBBackend, options storage.ListO
 
 			ba, err := GetBlockAccount(st, address)
 
-			// TODO if err != nil, stopping iteration is right? how about just
-			// ignoring the missing one?
+			// the account removed by `operation.AccountMerge` is skipped
+			for err == errors.StorageRecordDoesNotExist {
+				if address, hasNext, cursor = iterFunc(); !hasNext {
+					return nil, false, cursor
+				}
+				ba, err = GetBlockAccount(st, address)
+			}
 			if err != nil {
 				return nil, false, cursor
 			}
@@ -0,0 +1,88 @@
+package block
+
+import (
+	"fmt"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+)
+
+// BlockAccountHistory is the snapshot of `BlockAccount` at the block height.
+// The snapshot is written by `BlockAccount.Save`, so the last snapshot of the
+// block height is the state of account after the block. It is stored by the
+// key, 'bah-<BlockAccount.Address>-<Height>'. `BlockAccount.Remove` writes the
+// tombstone, which has `Removed` and the last state of account.
+type BlockAccountHistory struct {
+	Height  uint64       `json:"height"`
+	Account BlockAccount `json:"account"`
+	Removed bool         `json:"removed,omitempty"`
+}
+
+func GetBlockAccountHistoryKeyPrefix(address string) string {
+	return fmt.Sprintf("%s%s-", common.BlockAccountPrefixHistory, address)
+}
+
+func GetBlockAccountHistoryKey(address string, height uint64) string {
+	return fmt.Sprintf("%s%020d", GetBlockAccountHistoryKeyPrefix(address), height)
+}
+
+func (b *BlockAccountHistory) String() string {
+	return string(common.MustMarshalJSON(b))
+}
+
+func (b *BlockAccountHistory) Save(st *storage.LevelDBBackend) (err error) {
+	key := GetBlockAccountHistoryKey(b.Account.Address, b.Height)
+
+	var exists bool
+	if exists, err = st.Has(key); err != nil {
+		return
+	}
+
+	if exists {
+		err = st.Set(key, b)
+	} else {
+		err = st.New(key, b)
+	}
+
+	return
+}
+
+// GetBlockAccountAtHeight returns the state of account after the block of
+// `height`; if the account was removed at or before `height`, it returns
+// `errors.BlockAccountDoesNotExists`.
+func GetBlockAccountAtHeight(st *storage.LevelDBBackend, address string, height uint64) (b *BlockAccount, err error) {
+	options := storage.NewDefaultListOptions(
+		true,
+		[]byte(GetBlockAccountHistoryKey(address, height+1)),
+		1,
+	)
+	iterFunc, closeFunc := GetBlockAccountHistories(st, address, options)
+	history, hasNext, _ := iterFunc()
+	closeFunc()
+
+	if !hasNext || history.Removed {
+		err = errors.BlockAccountDoesNotExists
+		return
+	}
+
+	b = &history.Account
+	return
+}
+
+func GetBlockAccountHistories(st *storage.LevelDBBackend, address string, options storage.ListOptions) (func() (BlockAccountHistory, bool, []byte), func()) {
+	iterFunc, closeFunc := st.GetIterator(GetBlockAccountHistoryKeyPrefix(address), options)
+
+	return (func() (BlockAccountHistory, bool, []byte) {
+			item, hasNext := iterFunc()
+			if !hasNext {
+				return BlockAccountHistory{}, false, item.Key
+			}
+
+			var history BlockAccountHistory
+			common.MustUnmarshalJSON(item.Value, &history)
+			return history, hasNext, item.Key
+		}), (func() {
+			closeFunc()
+		})
+}
@@ -0,0 +1,106 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+)
+
+func TestBlockAccountHistory(t *testing.T) {
+	st := InitTestBlockchain()
+	defer st.Close()
+
+	genesis := GetLatestBlock(st)
+
+	// before the next block, the account is saved at the genesis height
+	ba := TestMakeBlockAccount()
+	ba.MustSave(st)
+
+	// block 2 deposits to account twice
+	blk := TestMakeNewBlockWithPrevBlock(genesis, []string{})
+	blk.MustSave(st)
+
+	require.NoError(t, ba.Deposit(common.Amount(100)))
+	ba.MustSave(st)
+	require.NoError(t, ba.Deposit(common.Amount(100)))
+	ba.MustSave(st)
+
+	// block 3 does not change account
+	nextBlk := TestMakeNewBlockWithPrevBlock(blk, []string{})
+	nextBlk.MustSave(st)
+
+	{
+		fetched, err := GetBlockAccountAtHeight(st, ba.Address, genesis.Height)
+		require.NoError(t, err)
+		require.Equal(t, common.Amount(common.BaseReserve), fetched.Balance)
+	}
+
+	for _, height := range []uint64{blk.Height, nextBlk.Height} {
+		fetched, err := GetBlockAccountAtHeight(st, ba.Address, height)
+		require.NoError(t, err)
+		require.Equal(t, ba.Balance, fetched.Balance)
+	}
+
+	{
+		_, err := GetBlockAccountAtHeight(st, TestMakeBlockAccount().Address, nextBlk.Height)
+		require.Error(t, err)
+	}
+
+	var histories []BlockAccountHistory
+	iterFunc, closeFunc := GetBlockAccountHistories(st, ba.Address, storage.NewDefaultListOptions(false, nil, 10))
+	for {
+		h, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+		histories = append(histories, h)
+	}
+	closeFunc()
+
+	require.Equal(t, 2, len(histories))
+	require.Equal(t, genesis.Height, histories[0].Height)
+	require.Equal(t, blk.Height, histories[1].Height)
+	require.Equal(t, ba.Balance, histories[1].Account.Balance)
+}
+
+func TestBlockAccountHistoryRemoved(t *testing.T) {
+	st := InitTestBlockchain()
+	defer st.Close()
+
+	genesis := GetLatestBlock(st)
+
+	ba := TestMakeBlockAccount()
+	ba.MustSave(st)
+
+	// block 2 removes account
+	blk := TestMakeNewBlockWithPrevBlock(genesis, []string{})
+	blk.MustSave(st)
+	require.NoError(t, ba.Remove(st))
+
+	nextBlk := TestMakeNewBlockWithPrevBlock(blk, []string{})
+	nextBlk.MustSave(st)
+
+	{
+		fetched, err := GetBlockAccountAtHeight(st, ba.Address, genesis.Height)
+		require.NoError(t, err)
+		require.Equal(t, ba.Balance, fetched.Balance)
+	}
+
+	for _, height := range []uint64{blk.Height, nextBlk.Height} {
+		_, err := GetBlockAccountAtHeight(st, ba.Address, height)
+		require.Equal(t, errors.BlockAccountDoesNotExists, err)
+	}
+
+	// block 4 creates the account again
+	lastBlk := TestMakeNewBlockWithPrevBlock(nextBlk, []string{})
+	lastBlk.MustSave(st)
+	ba.MustSave(st)
+
+	fetched, err := GetBlockAccountAtHeight(st, ba.Address, lastBlk.Height)
+	require.NoError(t, err)
+	require.Equal(t, ba.Balance, fetched.Balance)
+}
@@ -43,22 +43,54 @@ func (bck Block) IsEmpty() bool {
 }
 
 // NewBlock creates new block; `ptx` represents the
-// `ProposerTransaction.GetHash()`.
-func NewBlock(proposer string, basis voting.Basis, ptx string, transactions []string, proposedTime string) *Block {
+// `ProposerTransaction.GetHash()` and `stateRoot` is the root of account
+// state trie after the transactions of block are applied. The minimum fee of
+// block is `common.BaseFee`.
+func NewBlock(proposer string, basis voting.Basis, ptx string, transactions []string, stateRoot string, proposedTime string) *Block {
+	return NewBlockWithFee(proposer, basis, ptx, transactions, stateRoot, proposedTime, common.BaseFee, 0)
+}
+
+// NewBlockWithFee creates new block like `NewBlock`; `minFee` is the minimum
+// fee per operation of the transactions and `ops` is the number of their
+// operations.
+func NewBlockWithFee(proposer string, basis voting.Basis, ptx string, transactions []string, stateRoot string, proposedTime string, minFee common.Amount, ops uint64) *Block {
 	b := &Block{
-		Header:              *NewBlockHeader(basis, getTransactionRoot(append([]string{ptx}, transactions...)), proposedTime),
+		Header:              *NewBlockHeader(basis, getTransactionRoot(append([]string{ptx}, transactions...)), stateRoot, proposedTime, minFee, ops),
 		Transactions:        transactions,
 		ProposerTransaction: ptx,
 		Proposer:            proposer,
 		Round:               basis.Round,
 	}
 
-	b.Hash = base58.Encode(common.MustMakeObjectHash(b))
+	b.Hash = b.MakeHash()
 	return b
 }
 
+// MakeHash returns the hash of block; `Hash` and `Confirmed` are not the part
+// of hash.
+func (bck Block) MakeHash() string {
+	bck.Hash = ""
+	bck.Confirmed = ""
+	return base58.Encode(common.MustMakeObjectHash(&bck))
+}
+
+// getTransactionRoot returns the merkle root of the proposer transaction and
+// transactions.
 func getTransactionRoot(txs []string) string {
-	return common.MustMakeObjectHashString(txs) // TODO make root
+	return common.MakeMerkleRoot(txs)
+}
+
+// TransactionProof returns the merkle proof of transaction, which can be
+// verified by `Header.TransactionsRoot`.
+func (bck Block) TransactionProof(hash string) (proof common.MerkleProof, found bool) {
+	txs := append([]string{bck.ProposerTransaction}, bck.Transactions...)
+	for i, tx := range txs {
+		if tx == hash {
+			return common.MakeMerkleProof(txs, i)
+		}
+	}
+
+	return
 }
 
 func getBlockKey(hash string) string {
@@ -108,6 +140,38 @@ func (b *Block) Save(st *storage.LevelDBBackend) (err error) {
 		return
 	}
 
+	if err = saveLatestBlockHeight(st, b.Height); err != nil {
+		return
+	}
+
+	return
+}
+
+func getLatestBlockHeightKey() string {
+	return fmt.Sprintf("%s-latest-block-height", common.InternalPrefix)
+}
+
+// saveLatestBlockHeight keeps the height of the last saved block. Unlike
+// `GetLatestBlock`, it can be read in the batch before commit.
+func saveLatestBlockHeight(st *storage.LevelDBBackend, height uint64) (err error) {
+	var exists bool
+	if exists, err = st.Has(getLatestBlockHeightKey()); err != nil {
+		return
+	}
+
+	if exists {
+		return st.Set(getLatestBlockHeightKey(), height)
+	}
+	return st.New(getLatestBlockHeightKey(), height)
+}
+
+// getLatestBlockHeight returns the height of the last saved block; before the
+// genesis block is saved, it is `common.GenesisBlockHeight`.
+func getLatestBlockHeight(st *storage.LevelDBBackend) (height uint64, err error) {
+	if err = st.Get(getLatestBlockHeightKey(), &height); err == errors.StorageRecordDoesNotExist {
+		return common.GenesisBlockHeight, nil
+	}
+
 	return
 }
 
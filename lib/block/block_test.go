@@ -287,3 +287,17 @@ func TestMakeGenesisBlockFindCommonAccount(t *testing.T) {
 		require.Equal(t, commonAccount.SequenceID, ac.SequenceID)
 	}
 }
+
+func TestBlockTransactionProof(t *testing.T) {
+	txs := []string{"tx0", "tx1", "tx2"}
+	bk := TestMakeNewBlock(txs)
+
+	for _, hash := range append([]string{bk.ProposerTransaction}, txs...) {
+		proof, found := bk.TransactionProof(hash)
+		require.True(t, found)
+		require.True(t, proof.Verify(hash, bk.TransactionsRoot, uint64(len(txs)+1)))
+	}
+
+	_, found := bk.TransactionProof("unknown")
+	require.False(t, found)
+}
@@ -0,0 +1,111 @@
+package block
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+)
+
+// CommitSignature is the signature of validator over <networkID> + block
+// hash, which is delivered by the ACCEPT ballot.
+type CommitSignature struct {
+	Validator string `json:"validator"`
+	Signature string `json:"signature"`
+}
+
+func (s CommitSignature) Verify(networkID []byte, blockHash string) (err error) {
+	var kp keypair.KP
+	if kp, err = keypair.Parse(s.Validator); err != nil {
+		return
+	}
+
+	return kp.Verify(append(networkID, []byte(blockHash)...), base58.Decode(s.Signature))
+}
+
+// CommitCertificate is the proof that the validators agreed to the block;
+// it keeps the signatures of validators, which voted YES in ACCEPT state.
+type CommitCertificate struct {
+	Height     uint64            `json:"height"`
+	Round      uint64            `json:"round"`
+	BlockHash  string            `json:"block_hash"`
+	Signatures []CommitSignature `json:"signatures"`
+}
+
+func NewCommitCertificate(blk Block, signatures []CommitSignature) CommitCertificate {
+	return CommitCertificate{
+		Height:     blk.Height,
+		Round:      blk.Round,
+		BlockHash:  blk.Hash,
+		Signatures: signatures,
+	}
+}
+
+func getCommitCertificateKey(height uint64) string {
+	return fmt.Sprintf("%s%020d", common.BlockPrefixCommitCertificate, height)
+}
+
+func (c CommitCertificate) String() string {
+	return string(common.MustMarshalJSON(c))
+}
+
+func (c CommitCertificate) Save(st *storage.LevelDBBackend) (err error) {
+	key := getCommitCertificateKey(c.Height)
+
+	var exists bool
+	if exists, err = st.Has(key); err != nil {
+		return
+	} else if exists {
+		return st.Set(key, c)
+	}
+
+	return st.New(key, c)
+}
+
+// Verify checks the signatures of certificate; the number of valid
+// signatures from `validators` must reach `threshold`. The signatures of
+// unknown validators are not counted.
+func (c CommitCertificate) Verify(networkID []byte, blk Block, validators map[string]bool, threshold int) (err error) {
+	if c.Height != blk.Height || c.BlockHash != blk.Hash {
+		return errors.InvalidCommitCertificate
+	}
+
+	signed := map[string]bool{}
+	for _, s := range c.Signatures {
+		if _, found := signed[s.Validator]; found {
+			return errors.InvalidCommitCertificate
+		}
+		if err = s.Verify(networkID, c.BlockHash); err != nil {
+			return errors.InvalidCommitCertificate.Clone().SetData("validator", s.Validator)
+		}
+		signed[s.Validator] = validators[s.Validator]
+	}
+
+	var count int
+	for _, known := range signed {
+		if known {
+			count++
+		}
+	}
+
+	if threshold < 1 || count < threshold {
+		return errors.InvalidCommitCertificate.Clone().SetData("signatures", count).SetData("threshold", threshold)
+	}
+
+	return nil
+}
+
+func GetCommitCertificate(st *storage.LevelDBBackend, height uint64) (c CommitCertificate, err error) {
+	if err = st.Get(getCommitCertificateKey(height), &c); err == errors.StorageRecordDoesNotExist {
+		err = errors.CommitCertificateNotFound
+	}
+	return
+}
+
+func ExistsCommitCertificate(st *storage.LevelDBBackend, height uint64) (bool, error) {
+	return st.Has(getCommitCertificateKey(height))
+}
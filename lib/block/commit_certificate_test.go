@@ -0,0 +1,105 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+)
+
+func makeCommitSignature(kp *keypair.Full, networkID []byte, blockHash string) CommitSignature {
+	signature, _ := keypair.MakeSignature(kp, networkID, blockHash)
+	return CommitSignature{Validator: kp.Address(), Signature: base58.Encode(signature)}
+}
+
+func TestCommitCertificateSave(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	networkID := common.NewTestConfig().NetworkID
+	blk := TestMakeNewBlock(nil)
+
+	_, err := GetCommitCertificate(st, blk.Height)
+	require.Equal(t, errors.CommitCertificateNotFound, err)
+
+	kp := keypair.Random()
+	c := NewCommitCertificate(blk, []CommitSignature{makeCommitSignature(kp, networkID, blk.Hash)})
+	require.NoError(t, c.Save(st))
+
+	exists, err := ExistsCommitCertificate(st, blk.Height)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	fetched, err := GetCommitCertificate(st, blk.Height)
+	require.NoError(t, err)
+	require.Equal(t, c, fetched)
+
+	// overwritten
+	c.Signatures = append(c.Signatures, makeCommitSignature(keypair.Random(), networkID, blk.Hash))
+	require.NoError(t, c.Save(st))
+	fetched, err = GetCommitCertificate(st, blk.Height)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(fetched.Signatures))
+}
+
+func TestCommitCertificateVerify(t *testing.T) {
+	networkID := common.NewTestConfig().NetworkID
+	blk := TestMakeNewBlock(nil)
+
+	kps := []*keypair.Full{keypair.Random(), keypair.Random(), keypair.Random()}
+	validators := map[string]bool{}
+	var signatures []CommitSignature
+	for _, kp := range kps {
+		validators[kp.Address()] = true
+		signatures = append(signatures, makeCommitSignature(kp, networkID, blk.Hash))
+	}
+
+	c := NewCommitCertificate(blk, signatures)
+	require.NoError(t, c.Verify(networkID, blk, validators, 3))
+
+	{ // not enough signatures
+		c := NewCommitCertificate(blk, signatures[:2])
+		err := c.Verify(networkID, blk, validators, 3)
+		require.Equal(t, errors.InvalidCommitCertificate.Code, err.(*errors.Error).Code)
+	}
+
+	{ // unknown validator is not counted
+		c := NewCommitCertificate(blk, append(
+			signatures[:2:2],
+			makeCommitSignature(keypair.Random(), networkID, blk.Hash),
+		))
+		err := c.Verify(networkID, blk, validators, 3)
+		require.Equal(t, errors.InvalidCommitCertificate.Code, err.(*errors.Error).Code)
+	}
+
+	{ // duplicated signature
+		c := NewCommitCertificate(blk, append(signatures[:2:2], signatures[0]))
+		err := c.Verify(networkID, blk, validators, 2)
+		require.Equal(t, errors.InvalidCommitCertificate.Code, err.(*errors.Error).Code)
+	}
+
+	{ // signed the other block
+		c := NewCommitCertificate(blk, append(
+			signatures[:2:2],
+			makeCommitSignature(kps[2], networkID, "showme"),
+		))
+		err := c.Verify(networkID, blk, validators, 2)
+		require.Equal(t, errors.InvalidCommitCertificate.Code, err.(*errors.Error).Code)
+	}
+
+	{ // different network
+		err := c.Verify([]byte("showme"), blk, validators, 1)
+		require.Equal(t, errors.InvalidCommitCertificate.Code, err.(*errors.Error).Code)
+	}
+
+	{ // different block
+		other := TestMakeNewBlock(nil)
+		err := c.Verify(networkID, other, validators, 1)
+		require.Equal(t, errors.InvalidCommitCertificate.Code, err.(*errors.Error).Code)
+	}
+}
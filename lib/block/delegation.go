@@ -0,0 +1,212 @@
+package block
+
+import (
+	"fmt"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+)
+
+// Delegation is the stake of frozen account, which is delegated to the
+// validator by `operation.Delegate`. `Amount` is the balance of frozen
+// account; it follows the balance whenever the account is saved, see
+// `BlockAccount.Save`. When the account requests unfreezing, the delegation is
+// removed.
+type Delegation struct {
+	Account   string        `json:"account"`
+	Validator string        `json:"validator"`
+	Amount    common.Amount `json:"amount"`
+}
+
+// DelegatedStake is the total of delegated stake to the validator.
+type DelegatedStake struct {
+	Validator  string        `json:"validator"`
+	Amount     common.Amount `json:"amount"`
+	Delegators uint64        `json:"delegators"`
+}
+
+func NewDelegation(account, validator string, amount common.Amount) Delegation {
+	return Delegation{
+		Account:   account,
+		Validator: validator,
+		Amount:    amount,
+	}
+}
+
+func getDelegationKey(account string) string {
+	return fmt.Sprintf("%s%s", common.DelegationPrefixAccount, account)
+}
+
+func GetDelegationKeyPrefixValidator(validator string) string {
+	return fmt.Sprintf("%s%s-", common.DelegationPrefixValidator, validator)
+}
+
+func getDelegationValidatorKey(validator, account string) string {
+	return fmt.Sprintf("%s%s", GetDelegationKeyPrefixValidator(validator), account)
+}
+
+func getDelegatedStakeKey(validator string) string {
+	return fmt.Sprintf("%s%s", common.DelegatedStakePrefix, validator)
+}
+
+func (d Delegation) String() string {
+	return string(common.MustMarshalJSON(d))
+}
+
+// Save stores the delegation and updates the `DelegatedStake` of validator;
+// if the account already delegated to the other validator, the previous
+// delegation is replaced.
+func (d Delegation) Save(st *storage.LevelDBBackend) (err error) {
+	if err = RemoveDelegation(st, d.Account); err != nil {
+		return
+	}
+
+	if err = st.New(getDelegationKey(d.Account), d); err != nil {
+		return
+	}
+	if err = st.New(getDelegationValidatorKey(d.Validator, d.Account), d); err != nil {
+		return
+	}
+
+	var stake DelegatedStake
+	if stake, err = GetDelegatedStake(st, d.Validator); err != nil {
+		return
+	}
+	if stake.Amount, err = stake.Amount.Add(d.Amount); err != nil {
+		return
+	}
+	stake.Delegators++
+
+	return saveDelegatedStake(st, stake)
+}
+
+// RemoveDelegation removes the delegation of account; if not delegated,
+// nothing happens.
+func RemoveDelegation(st *storage.LevelDBBackend, account string) (err error) {
+	var d Delegation
+	if d, err = GetDelegation(st, account); err != nil {
+		if err == errors.StorageRecordDoesNotExist {
+			err = nil
+		}
+		return
+	}
+
+	if err = st.Remove(getDelegationKey(account)); err != nil {
+		return
+	}
+	if err = st.Remove(getDelegationValidatorKey(d.Validator, account)); err != nil {
+		return
+	}
+
+	var stake DelegatedStake
+	if stake, err = GetDelegatedStake(st, d.Validator); err != nil {
+		return
+	}
+	if stake.Amount, err = stake.Amount.Sub(d.Amount); err != nil {
+		return
+	}
+	stake.Delegators--
+
+	return saveDelegatedStake(st, stake)
+}
+
+// updateDelegationAmount sets the `Amount` of the delegation of account and
+// the `DelegatedStake` of it's validator by the current balance; if not
+// delegated, nothing happens.
+func updateDelegationAmount(st *storage.LevelDBBackend, account string, amount common.Amount) (err error) {
+	var d Delegation
+	if d, err = GetDelegation(st, account); err != nil {
+		if err == errors.StorageRecordDoesNotExist {
+			err = nil
+		}
+		return
+	}
+	if d.Amount == amount {
+		return
+	}
+
+	var stake DelegatedStake
+	if stake, err = GetDelegatedStake(st, d.Validator); err != nil {
+		return
+	}
+	if stake.Amount, err = stake.Amount.Sub(d.Amount); err != nil {
+		return
+	}
+	if stake.Amount, err = stake.Amount.Add(amount); err != nil {
+		return
+	}
+
+	d.Amount = amount
+	if err = st.Set(getDelegationKey(account), d); err != nil {
+		return
+	}
+	if err = st.Set(getDelegationValidatorKey(d.Validator, account), d); err != nil {
+		return
+	}
+
+	return saveDelegatedStake(st, stake)
+}
+
+func saveDelegatedStake(st *storage.LevelDBBackend, stake DelegatedStake) (err error) {
+	key := getDelegatedStakeKey(stake.Validator)
+
+	var exists bool
+	if exists, err = st.Has(key); err != nil {
+		return
+	}
+
+	if stake.Delegators < 1 {
+		if exists {
+			err = st.Remove(key)
+		}
+		return
+	}
+
+	if exists {
+		return st.Set(key, stake)
+	}
+
+	return st.New(key, stake)
+}
+
+func GetDelegation(st *storage.LevelDBBackend, account string) (d Delegation, err error) {
+	err = st.Get(getDelegationKey(account), &d)
+	return
+}
+
+// GetDelegatedStake returns the total of delegated stake to the validator;
+// if nothing delegated, the empty `DelegatedStake` is returned.
+func GetDelegatedStake(st *storage.LevelDBBackend, validator string) (stake DelegatedStake, err error) {
+	if err = st.Get(getDelegatedStakeKey(validator), &stake); err == errors.StorageRecordDoesNotExist {
+		err = nil
+		stake = DelegatedStake{Validator: validator}
+	}
+	return
+}
+
+func loadDelegationsInsideIterator(iterFunc func() (storage.IterItem, bool), closeFunc func()) (func() (Delegation, bool, []byte), func()) {
+	return (func() (Delegation, bool, []byte) {
+			item, hasNext := iterFunc()
+			if !hasNext {
+				return Delegation{}, false, item.Key
+			}
+
+			var d Delegation
+			common.MustUnmarshalJSON(item.Value, &d)
+			return d, hasNext, item.Key
+		}), (func() {
+			closeFunc()
+		})
+}
+
+// GetDelegations returns all the delegations in order of account.
+func GetDelegations(st *storage.LevelDBBackend, options storage.ListOptions) (func() (Delegation, bool, []byte), func()) {
+	return loadDelegationsInsideIterator(st.GetIterator(common.DelegationPrefixAccount, options))
+}
+
+// GetDelegationsByValidator returns the delegations to the validator in order
+// of account.
+func GetDelegationsByValidator(st *storage.LevelDBBackend, validator string, options storage.ListOptions) (func() (Delegation, bool, []byte), func()) {
+	return loadDelegationsInsideIterator(st.GetIterator(GetDelegationKeyPrefixValidator(validator), options))
+}
@@ -0,0 +1,116 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+)
+
+func getDelegationsByValidator(st *storage.LevelDBBackend, validator string) (delegations []Delegation) {
+	iterFunc, closeFunc := GetDelegationsByValidator(st, validator, nil)
+	defer closeFunc()
+	for {
+		d, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+		delegations = append(delegations, d)
+	}
+
+	return
+}
+
+func TestDelegation(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	validatorA := keypair.Random().Address()
+	validatorB := keypair.Random().Address()
+	account0 := keypair.Random().Address()
+	account1 := keypair.Random().Address()
+
+	d0 := NewDelegation(account0, validatorA, common.Amount(100))
+	d1 := NewDelegation(account1, validatorA, common.Amount(200))
+	require.NoError(t, d0.Save(st))
+	require.NoError(t, d1.Save(st))
+
+	stake, err := GetDelegatedStake(st, validatorA)
+	require.NoError(t, err)
+	require.Equal(t, DelegatedStake{Validator: validatorA, Amount: common.Amount(300), Delegators: 2}, stake)
+	require.Equal(t, 2, len(getDelegationsByValidator(st, validatorA)))
+
+	{ // delegate to the other validator
+		d0 = NewDelegation(account0, validatorB, common.Amount(100))
+		require.NoError(t, d0.Save(st))
+
+		stake, err := GetDelegatedStake(st, validatorA)
+		require.NoError(t, err)
+		require.Equal(t, DelegatedStake{Validator: validatorA, Amount: common.Amount(200), Delegators: 1}, stake)
+		require.Equal(t, []Delegation{d1}, getDelegationsByValidator(st, validatorA))
+
+		stake, err = GetDelegatedStake(st, validatorB)
+		require.NoError(t, err)
+		require.Equal(t, DelegatedStake{Validator: validatorB, Amount: common.Amount(100), Delegators: 1}, stake)
+		require.Equal(t, []Delegation{d0}, getDelegationsByValidator(st, validatorB))
+
+		d, err := GetDelegation(st, account0)
+		require.NoError(t, err)
+		require.Equal(t, d0, d)
+	}
+
+	{ // remove
+		require.NoError(t, RemoveDelegation(st, account0))
+		require.NoError(t, RemoveDelegation(st, account0))
+
+		_, err := GetDelegation(st, account0)
+		require.Equal(t, errors.StorageRecordDoesNotExist, err)
+
+		stake, err := GetDelegatedStake(st, validatorB)
+		require.NoError(t, err)
+		require.Equal(t, DelegatedStake{Validator: validatorB}, stake)
+		require.Equal(t, 0, len(getDelegationsByValidator(st, validatorB)))
+	}
+
+	{ // all delegations
+		iterFunc, closeFunc := GetDelegations(st, nil)
+		d, hasNext, _ := iterFunc()
+		require.True(t, hasNext)
+		require.Equal(t, d1, d)
+		_, hasNext, _ = iterFunc()
+		require.False(t, hasNext)
+		closeFunc()
+	}
+}
+
+// TestDelegationFollowsBalance checks the delegation of frozen account follows
+// it's balance when the account is saved.
+func TestDelegationFollowsBalance(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	validator := keypair.Random().Address()
+
+	frozen := NewBlockAccountLinked(keypair.Random().Address(), common.Amount(10000), keypair.Random().Address())
+	frozen.MustSave(st)
+	require.NoError(t, NewDelegation(frozen.Address, validator, frozen.Balance).Save(st))
+
+	other := NewDelegation(keypair.Random().Address(), validator, common.Amount(100))
+	require.NoError(t, other.Save(st))
+
+	frozen.Balance = common.Amount(9000)
+	frozen.MustSave(st)
+
+	d, err := GetDelegation(st, frozen.Address)
+	require.NoError(t, err)
+	require.Equal(t, NewDelegation(frozen.Address, validator, common.Amount(9000)), d)
+	require.Contains(t, getDelegationsByValidator(st, validator), d)
+
+	stake, err := GetDelegatedStake(st, validator)
+	require.NoError(t, err)
+	require.Equal(t, DelegatedStake{Validator: validator, Amount: common.Amount(9100), Delegators: 2}, stake)
+}
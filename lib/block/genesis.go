@@ -111,6 +111,7 @@ func MakeGenesisBlock(st *storage.LevelDBBackend, genesisAccount BlockAccount, c
 		},
 		"",
 		[]string{tx.GetHash()},
+		"",
 		common.GenesisBlockConfirmedTime,
 	)
 	if err = blk.Save(st); err != nil {
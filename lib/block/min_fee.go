@@ -0,0 +1,63 @@
+package block
+
+import (
+	"math/big"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/storage"
+)
+
+// NextMinFee returns the minimum fee per operation of the next block of `h`.
+// The target of block is the half of `opsInBallotLimit`; if the operations of
+// `h` are over the target, the minimum fee goes up, if under, goes down, by
+// `|ops - target| / target / common.MinFeeChangeDenominator` of the current
+// one. It never goes under `common.BaseFee`.
+func NextMinFee(h Header, opsInBallotLimit int) common.Amount {
+	minFee := h.MinFee
+	if minFee < common.BaseFee {
+		minFee = common.BaseFee
+	}
+
+	target := uint64(opsInBallotLimit / 2)
+	if target < 1 || h.Ops == target {
+		return minFee
+	}
+
+	var diff uint64
+	if h.Ops > target {
+		diff = h.Ops - target
+	} else {
+		diff = target - h.Ops
+	}
+
+	delta := new(big.Int).Mul(new(big.Int).SetUint64(uint64(minFee)), new(big.Int).SetUint64(diff))
+	delta.Div(delta, new(big.Int).Mul(new(big.Int).SetUint64(target), new(big.Int).SetUint64(common.MinFeeChangeDenominator)))
+
+	if h.Ops < target {
+		// `diff` is not over `target`, so `delta` is not over `minFee`
+		if next := minFee - common.Amount(delta.Uint64()); next > common.BaseFee {
+			return next
+		}
+		return common.BaseFee
+	}
+
+	if !delta.IsUint64() || delta.Uint64() > uint64(common.MaximumBalance) {
+		return common.MaximumBalance
+	}
+	d := common.Amount(delta.Uint64())
+	if d < 1 {
+		d = 1
+	}
+	next, err := minFee.Add(d)
+	if err != nil {
+		return common.MaximumBalance
+	}
+
+	return next
+}
+
+// GetNextMinFee returns the minimum fee per operation of the next block of
+// the latest block.
+func GetNextMinFee(st *storage.LevelDBBackend, opsInBallotLimit int) common.Amount {
+	return NextMinFee(GetLatestBlock(st).Header, opsInBallotLimit)
+}
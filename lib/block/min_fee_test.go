@@ -0,0 +1,69 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/voting"
+)
+
+func TestNextMinFee(t *testing.T) {
+	limit := 100 // target is 50 operations
+	minFee := common.BaseFee.MustMult(8)
+
+	{ // at target, not changed
+		require.Equal(t, minFee, NextMinFee(Header{MinFee: minFee, Ops: 50}, limit))
+	}
+
+	{ // full block, 1/8 up
+		require.Equal(t, common.BaseFee.MustMult(9), NextMinFee(Header{MinFee: minFee, Ops: 100}, limit))
+	}
+
+	{ // empty block, 1/8 down
+		require.Equal(t, common.BaseFee.MustMult(7), NextMinFee(Header{MinFee: minFee, Ops: 0}, limit))
+	}
+
+	{ // over target, by the ratio and at least 1 up
+		require.Equal(t, common.BaseFee+25, NextMinFee(Header{MinFee: common.BaseFee, Ops: 51}, limit))
+		require.Equal(t, common.BaseFee+1, NextMinFee(Header{MinFee: common.BaseFee, Ops: 1000001}, 2000000))
+	}
+
+	{ // not under BaseFee
+		require.Equal(t, common.BaseFee, NextMinFee(Header{MinFee: common.BaseFee, Ops: 0}, limit))
+		require.Equal(t, common.BaseFee, NextMinFee(Header{MinFee: common.BaseFee + 1, Ops: 0}, limit))
+		require.Equal(t, common.BaseFee, NextMinFee(Header{Ops: 50}, limit))
+	}
+
+	{ // not over MaximumBalance
+		require.Equal(t, common.MaximumBalance, NextMinFee(Header{MinFee: common.MaximumBalance, Ops: 100}, limit))
+	}
+
+	{ // without limit, not changed
+		require.Equal(t, minFee, NextMinFee(Header{MinFee: minFee, Ops: 100}, 0))
+	}
+}
+
+func TestGetNextMinFee(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	genesis := TestMakeNewBlock(nil)
+	genesis.MustSave(st)
+	require.Equal(t, common.BaseFee, GetNextMinFee(st, 100))
+
+	blk := *NewBlockWithFee(
+		genesis.Proposer,
+		voting.Basis{Height: genesis.Height + 1, BlockHash: genesis.Hash},
+		"",
+		nil,
+		"",
+		common.NowISO8601(),
+		common.BaseFee.MustMult(8),
+		100,
+	)
+	blk.MustSave(st)
+	require.Equal(t, common.BaseFee.MustMult(9), GetNextMinFee(st, 100))
+}
@@ -108,6 +108,7 @@ func TestMakeNewBlock(transactions []string) Block {
 		},
 		"",
 		transactions,
+		"",
 		common.NowISO8601(),
 	)
 }
@@ -125,6 +126,7 @@ func TestMakeNewBlockWithPrevBlock(prevBlock Block, txs []string) Block {
 		},
 		"",
 		txs,
+		"",
 		common.NowISO8601(),
 	)
 }
@@ -19,6 +19,7 @@ import (
 //  * get list by `Confirmed` order
 //  * get list by `Account` and created order
 //  * get list by `Block` and created order
+//  * get list by `Account`, `Memo` and created order
 
 // TODO(BlockTransaction): support counting
 
@@ -33,6 +34,8 @@ type BlockTransaction struct {
 	Operations []string      `json:"operations"`
 	Amount     common.Amount `json:"amount"`
 
+	Memo *transaction.Memo `json:"memo,omitempty"`
+
 	Confirmed string `json:"confirmed"`
 	Created   string `json:"created"`
 	Message   []byte `json:"message"`
@@ -57,6 +60,7 @@ func NewBlockTransactionFromTransaction(blockHash string, blockHeight uint64, co
 		Fee:        tx.B.Fee,
 		Operations: opHashes,
 		Amount:     tx.TotalAmount(true),
+		Memo:       tx.B.Memo,
 		Confirmed:  confirmed,
 		Created:    tx.H.Created,
 
@@ -93,6 +97,16 @@ func (bt BlockTransaction) NewBlockTransactionKeyByAccount(accountAddress string
 	)
 }
 
+func (bt BlockTransaction) NewBlockTransactionKeyByAccountMemo(accountAddress string) string {
+	return fmt.Sprintf(
+		"%s%s%s%s",
+		GetBlockTransactionKeyPrefixAccountMemo(accountAddress, *bt.Memo),
+		common.EncodeUint64ToByteSlice(bt.blockHeight),
+		common.EncodeUint64ToByteSlice(bt.SequenceID),
+		common.GetUniqueIDFromUUID(),
+	)
+}
+
 func (bt BlockTransaction) NewBlockTransactionKeyByBlock(hash string) string {
 	return fmt.Sprintf(
 		"%s%s%s%s",
@@ -133,6 +147,11 @@ func (bt *BlockTransaction) Save(st *storage.LevelDBBackend) (err error) {
 	if err = st.New(bt.NewBlockTransactionKeyByBlock(bt.Block), bt.Hash); err != nil {
 		return
 	}
+	if bt.Memo != nil {
+		if err = st.New(bt.NewBlockTransactionKeyByAccountMemo(bt.Source), bt.Hash); err != nil {
+			return
+		}
+	}
 
 	bt.isSaved = true
 
@@ -210,6 +229,12 @@ func (bt *BlockTransaction) SaveBlockOperation(st *storage.LevelDBBackend, op op
 		if err != nil {
 			return
 		}
+		if bt.Memo != nil {
+			err = st.New(bt.NewBlockTransactionKeyByAccountMemo(pop.TargetAddress()), bt.Hash)
+			if err != nil {
+				return
+			}
+		}
 	}
 
 	return nil
@@ -241,6 +266,10 @@ func GetBlockTransactionKeyPrefixAccount(accountAddress string) string {
 	return fmt.Sprintf("%s%s-", common.BlockTransactionPrefixAccount, accountAddress)
 }
 
+func GetBlockTransactionKeyPrefixAccountMemo(accountAddress string, memo transaction.Memo) string {
+	return fmt.Sprintf("%s%s-%s-", common.BlockTransactionPrefixAccountMemo, accountAddress, memo.Key())
+}
+
 func GetBlockTransactionKeyPrefixBlock(hash string) string {
 	return fmt.Sprintf("%s%s-", common.BlockTransactionPrefixBlock, hash)
 }
@@ -317,6 +346,14 @@ func GetBlockTransactionsByAccount(st *storage.LevelDBBackend, accountAddress st
 	return LoadBlockTransactionsInsideIterator(st, iterFunc, closeFunc)
 }
 
+func GetBlockTransactionsByAccountMemo(st *storage.LevelDBBackend, accountAddress string, memo transaction.Memo, options storage.ListOptions) (
+	func() (BlockTransaction, bool, []byte),
+	func(),
+) {
+	iterFunc, closeFunc := st.GetIterator(GetBlockTransactionKeyPrefixAccountMemo(accountAddress, memo), options)
+	return LoadBlockTransactionsInsideIterator(st, iterFunc, closeFunc)
+}
+
 func GetBlockTransactionsByBlock(st *storage.LevelDBBackend, hash string, options storage.ListOptions) (
 	func() (BlockTransaction, bool, []byte),
 	func(),
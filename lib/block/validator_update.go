@@ -0,0 +1,103 @@
+package block
+
+import (
+	"fmt"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+// ValidatorUpdate is the scheduled change of validator set by
+// `operation.ValidatorUpdate`; it is applied from the block of `Height`.
+type ValidatorUpdate struct {
+	Height   uint64                          `json:"height"`
+	Action   operation.ValidatorUpdateAction `json:"action"`
+	Address  string                          `json:"address"`
+	Endpoint string                          `json:"endpoint"`
+}
+
+func NewValidatorUpdate(opb operation.ValidatorUpdate) ValidatorUpdate {
+	return ValidatorUpdate{
+		Height:   opb.Height,
+		Action:   opb.Action,
+		Address:  opb.Address,
+		Endpoint: opb.Endpoint,
+	}
+}
+
+func GetValidatorUpdateKeyPrefixHeight(height uint64) string {
+	return fmt.Sprintf("%s%020d-", common.ValidatorUpdatePrefix, height)
+}
+
+func (v ValidatorUpdate) NewValidatorUpdateKey() string {
+	return fmt.Sprintf(
+		"%s%s",
+		GetValidatorUpdateKeyPrefixHeight(v.Height),
+		common.MustMakeObjectHashString(v),
+	)
+}
+
+// Save stores the update; the same update for the same height is stored only
+// once.
+func (v ValidatorUpdate) Save(st *storage.LevelDBBackend) (err error) {
+	key := v.NewValidatorUpdateKey()
+
+	var exists bool
+	if exists, err = st.Has(key); err != nil || exists {
+		return
+	}
+
+	return st.New(key, v)
+}
+
+// GetValidatorUpdates returns the updates, which are scheduled from `start`
+// to `end` height in order of height.
+func GetValidatorUpdates(st *storage.LevelDBBackend, start, end uint64) (updates []ValidatorUpdate) {
+	iterFunc, closeFunc := st.GetIterator(common.ValidatorUpdatePrefix, nil)
+	defer closeFunc()
+
+	for {
+		item, hasNext := iterFunc()
+		if !hasNext {
+			break
+		}
+
+		var v ValidatorUpdate
+		common.MustUnmarshalJSON(item.Value, &v)
+		if v.Height < start {
+			continue
+		}
+		if v.Height > end {
+			break
+		}
+		updates = append(updates, v)
+	}
+
+	return
+}
+
+// GetValidatorsAtHeight returns the addresses of validators, which are in
+// force at `height`; the updates until `height` are applied to `base`, the
+// validators of genesis, in the same way with `NodeRunner`, so the last
+// validator is not removed.
+func GetValidatorsAtHeight(st *storage.LevelDBBackend, base []string, height uint64) map[string]bool {
+	validators := map[string]bool{}
+	for _, address := range base {
+		validators[address] = true
+	}
+
+	for _, u := range GetValidatorUpdates(st, common.GenesisBlockHeight+1, height) {
+		switch u.Action {
+		case operation.ValidatorUpdateAdd:
+			validators[u.Address] = true
+		case operation.ValidatorUpdateRemove:
+			if !validators[u.Address] || len(validators) < 2 {
+				continue
+			}
+			delete(validators, u.Address)
+		}
+	}
+
+	return validators
+}
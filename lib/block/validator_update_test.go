@@ -0,0 +1,55 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+func TestValidatorUpdates(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	address := keypair.Random().Address()
+	add := NewValidatorUpdate(operation.NewValidatorUpdate(10, operation.ValidatorUpdateAdd, address, ""))
+	remove := NewValidatorUpdate(operation.NewValidatorUpdate(20, operation.ValidatorUpdateRemove, address, ""))
+	other := NewValidatorUpdate(operation.NewValidatorUpdate(100, operation.ValidatorUpdateAdd, keypair.Random().Address(), ""))
+
+	for _, v := range []ValidatorUpdate{other, remove, add, add} {
+		require.NoError(t, v.Save(st))
+	}
+
+	require.Equal(t, 0, len(GetValidatorUpdates(st, 1, 9)))
+	require.Equal(t, []ValidatorUpdate{add}, GetValidatorUpdates(st, 1, 10))
+	require.Equal(t, []ValidatorUpdate{add, remove}, GetValidatorUpdates(st, 1, 99))
+	require.Equal(t, []ValidatorUpdate{remove, other}, GetValidatorUpdates(st, 11, 100))
+}
+
+func TestGetValidatorsAtHeight(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	a, b, c := keypair.Random().Address(), keypair.Random().Address(), keypair.Random().Address()
+	base := []string{a, b}
+
+	for _, v := range []ValidatorUpdate{
+		NewValidatorUpdate(operation.NewValidatorUpdate(10, operation.ValidatorUpdateAdd, c, "")),
+		NewValidatorUpdate(operation.NewValidatorUpdate(20, operation.ValidatorUpdateRemove, a, "")),
+		NewValidatorUpdate(operation.NewValidatorUpdate(30, operation.ValidatorUpdateRemove, b, "")),
+		NewValidatorUpdate(operation.NewValidatorUpdate(40, operation.ValidatorUpdateRemove, c, "")),
+	} {
+		require.NoError(t, v.Save(st))
+	}
+
+	require.Equal(t, map[string]bool{a: true, b: true}, GetValidatorsAtHeight(st, base, 9))
+	require.Equal(t, map[string]bool{a: true, b: true, c: true}, GetValidatorsAtHeight(st, base, 10))
+	require.Equal(t, map[string]bool{b: true, c: true}, GetValidatorsAtHeight(st, base, 29))
+	require.Equal(t, map[string]bool{c: true}, GetValidatorsAtHeight(st, base, 30))
+
+	// the last validator is not removed
+	require.Equal(t, map[string]bool{c: true}, GetValidatorsAtHeight(st, base, 40))
+}
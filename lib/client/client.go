@@ -22,12 +22,18 @@ const (
 	UrlAccount               = "/accounts/{id}"
 	UrlAccountOperations     = "/accounts/{id}/operations"
 	UrlAccountFrozenAccounts = "/accounts/{id}/frozen-accounts"
+	UrlAccountProof          = "/accounts/{id}/proof"
+	UrlAccountHistory        = "/accounts/{id}/history"
 	UrlFrozenAccounts        = "/frozen-accounts"
 	UrlTransactions          = "/transactions"
 	UrlTransactionByHash     = "/transactions/{id}"
 	UrlTransactionStatus     = "/transactions/{id}/status"
 	UrlTransactionOperations = "/transactions/{id}/operations"
+	UrlTransactionProof      = "/transactions/{id}/proof"
 	UrlSubscribe             = "/subscribe"
+	UrlFeeStats              = "/fee-stats"
+	UrlValidatorDelegations  = "/validators/{id}/delegations"
+	UrlValidatorStake        = "/validators/{id}/stake"
 )
 
 type QueryKey string
@@ -41,6 +47,8 @@ const (
 	QueryOrder  QueryKey = "reverse"
 	QueryCursor QueryKey = "cursor"
 	QueryType   QueryKey = "type"
+	QueryHeight QueryKey = "height"
+	QueryBlocks QueryKey = "blocks"
 )
 
 type Q struct {
@@ -65,6 +73,10 @@ func (qs Queries) toQueryString() string {
 			urlValues.Add(QueryCursor.String(), q.Value)
 		case QueryType:
 			urlValues.Add(QueryType.String(), q.Value)
+		case QueryHeight:
+			urlValues.Add(QueryHeight.String(), q.Value)
+		case QueryBlocks:
+			urlValues.Add(QueryBlocks.String(), q.Value)
 
 		}
 	}
@@ -167,6 +179,20 @@ func (c *Client) LoadAccount(id string, queries ...Q) (account Account, err erro
 	return
 }
 
+func (c *Client) LoadAccountHistory(id string, queries ...Q) (hPage AccountHistoryPage, err error) {
+	url := strings.Replace(UrlAccountHistory, "{id}", id, -1)
+	url += Queries(queries).toQueryString()
+	err = c.getResponse(url, http.Header{}, &hPage)
+	return
+}
+
+func (c *Client) LoadAccountProof(id string, queries ...Q) (proof AccountProof, err error) {
+	url := strings.Replace(UrlAccountProof, "{id}", id, -1)
+	url += Queries(queries).toQueryString()
+	err = c.getResponse(url, http.Header{}, &proof)
+	return
+}
+
 func (c *Client) LoadFrozenAccountsByLinked(id string, queries ...Q) (fPage FrozenAccountsPage, err error) {
 	url := strings.Replace(UrlAccountFrozenAccounts, "{id}", id, -1)
 	url += Queries(queries).toQueryString()
@@ -195,6 +221,13 @@ func (c *Client) LoadTransactionStatus(id string, queries ...Q) (transactionHist
 	return
 }
 
+func (c *Client) LoadTransactionProof(id string, queries ...Q) (proof TransactionProof, err error) {
+	url := strings.Replace(UrlTransactionProof, "{id}", id, -1)
+	url += Queries(queries).toQueryString()
+	err = c.getResponse(url, http.Header{}, &proof)
+	return
+}
+
 func (c *Client) LoadTransactions(queries ...Q) (tPage TransactionsPage, err error) {
 	url := UrlTransactions
 	url += Queries(queries).toQueryString()
@@ -223,6 +256,31 @@ func (c *Client) LoadOperationsByTransaction(id string, queries ...Q) (oPage Ope
 	return
 }
 
+// LoadFeeStats loads the fees of the recent blocks and the recommended fee
+// per operation; the number of blocks can be set by `QueryBlocks`.
+func (c *Client) LoadFeeStats(queries ...Q) (stats FeeStats, err error) {
+	url := UrlFeeStats
+	url += Queries(queries).toQueryString()
+	err = c.getResponse(url, http.Header{}, &stats)
+	return
+}
+
+// LoadValidatorDelegations loads the delegations of frozen accounts to the
+// validator.
+func (c *Client) LoadValidatorDelegations(id string, queries ...Q) (dPage DelegationsPage, err error) {
+	url := strings.Replace(UrlValidatorDelegations, "{id}", id, -1)
+	url += Queries(queries).toQueryString()
+	err = c.getResponse(url, http.Header{}, &dPage)
+	return
+}
+
+// LoadValidatorStake loads the total of delegated stake to the validator.
+func (c *Client) LoadValidatorStake(id string) (stake DelegatedStake, err error) {
+	url := strings.Replace(UrlValidatorStake, "{id}", id, -1)
+	err = c.getResponse(url, http.Header{}, &stake)
+	return
+}
+
 func (c *Client) SubmitTransaction(tx []byte) (pTransaction TransactionPost, err error) {
 	url := UrlTransactions
 	headers := http.Header{}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/storage/statedb/trie"
+)
+
+// makeBlockHash rebuilds the block from the header and the other fields of
+// proof and returns it's hash.
+func makeBlockHash(header block.Header, transactions []string, proposerTransaction, proposer string, round uint64) string {
+	blk := block.Block{
+		Header:              header,
+		Transactions:        transactions,
+		ProposerTransaction: proposerTransaction,
+		Proposer:            proposer,
+		Round:               round,
+	}
+
+	return blk.MakeHash()
+}
+
+// VerifyTransactionProof checks the transaction, `hash` is included in the
+// block by the merkle proof from `/transactions/{id}/proof`. The header is
+// checked with `proof.Block`, so the block hash of proof should be trusted
+// one.
+func VerifyTransactionProof(hash string, proof TransactionProof) bool {
+	if proof.Hash != hash {
+		return false
+	}
+
+	if makeBlockHash(proof.Header, proof.Transactions, proof.ProposerTransaction, proof.Proposer, proof.Round) != proof.Block {
+		return false
+	}
+
+	mp := common.MerkleProof{
+		Index:    proof.Index,
+		Siblings: proof.Siblings,
+	}
+
+	// the proposer transaction is the first leaf
+	return mp.Verify(hash, proof.Header.TransactionsRoot, uint64(len(proof.Transactions)+1))
+}
+
+// VerifyAccountProof checks the account of proof from `/accounts/{id}/proof`
+// is stored in the state trie of the block header. The header is checked with
+// `proof.Block`, so the block hash of proof should be trusted one.
+func VerifyAccountProof(address string, proof AccountProof) bool {
+	if proof.Address != address || proof.Account.Address != address {
+		return false
+	}
+	if len(proof.Header.StateRoot) < 1 {
+		return false
+	}
+
+	if makeBlockHash(proof.Header, proof.Transactions, proof.ProposerTransaction, proof.Proposer, proof.Round) != proof.Block {
+		return false
+	}
+
+	root := common.BytesToHash(base58.Decode(proof.Header.StateRoot))
+	value, err := trie.VerifyProof(root, []byte(address), proof.Proof)
+	if err != nil || value == nil {
+		return false
+	}
+
+	var ba block.BlockAccount
+	if err = json.Unmarshal(value, &ba); err != nil {
+		return false
+	}
+
+	expected, err := json.Marshal(ba)
+	if err != nil {
+		return false
+	}
+	got, err := json.Marshal(proof.Account)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(expected, got)
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"encoding/json"
 
+	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/node/runner/api/resource"
 )
@@ -21,6 +22,7 @@ type Account struct {
 		Self         Link `json:"self"`
 		Transactions Link `json:"transactions"`
 		Operations   Link `json:"operations"`
+		History      Link `json:"history"`
 	} `json:"_links"`
 
 	Address    string `json:"address"`
@@ -29,6 +31,48 @@ type Account struct {
 	Linked     string `json:"linked"`
 }
 
+type AccountHistory struct {
+	Links struct {
+		Self    Link `json:"self"`
+		Account Link `json:"account"`
+	} `json:"_links"`
+
+	Address    string `json:"address"`
+	Height     uint64 `json:"height"`
+	SequenceID uint64 `json:"sequence_id"`
+	Balance    string `json:"balance"`
+	Linked     string `json:"linked"`
+}
+
+type AccountHistoryPage struct {
+	Links struct {
+		Self Link `json:"self"`
+		Next Link `json:"next"`
+		Prev Link `json:"prev"`
+	} `json:"_links"`
+	Embedded struct {
+		Records []AccountHistory `json:"records"`
+	} `json:"_embedded"`
+}
+
+type AccountProof struct {
+	Links struct {
+		Self    Link `json:"self"`
+		Account Link `json:"account"`
+		Block   Link `json:"block"`
+	} `json:"_links"`
+
+	Address             string             `json:"address"`
+	Account             block.BlockAccount `json:"account"`
+	Block               string             `json:"block"`
+	Header              block.Header       `json:"header"`
+	Transactions        []string           `json:"transactions"`
+	ProposerTransaction string             `json:"proposer_transaction"`
+	Proposer            string             `json:"proposer"`
+	Round               uint64             `json:"round"`
+	Proof               [][]byte           `json:"proof"`
+}
+
 type FrozenAccount struct {
 	Links struct {
 		Self Link `json:"self"`
@@ -56,6 +100,40 @@ type FrozenAccountsPage struct {
 	} `json:"_embedded"`
 }
 
+type Delegation struct {
+	Links struct {
+		Self    Link `json:"self"`
+		Account Link `json:"account"`
+		Stake   Link `json:"stake"`
+	} `json:"_links"`
+
+	Account   string `json:"account"`
+	Validator string `json:"validator"`
+	Amount    string `json:"amount"`
+}
+
+type DelegationsPage struct {
+	Links struct {
+		Self Link `json:"self"`
+		Next Link `json:"next"`
+		Prev Link `json:"prev"`
+	} `json:"_links"`
+	Embedded struct {
+		Records []Delegation `json:"records"`
+	} `json:"_embedded"`
+}
+
+type DelegatedStake struct {
+	Links struct {
+		Self        Link `json:"self"`
+		Delegations Link `json:"delegations"`
+	} `json:"_links"`
+
+	Validator  string `json:"validator"`
+	Amount     string `json:"amount"`
+	Delegators uint64 `json:"delegators"`
+}
+
 type Link struct {
 	Href      string `json:"href"`
 	Templated bool   `json:"templated,omitempty"`
@@ -94,6 +172,23 @@ type TransactionStatus struct {
 	Status string `json:"status"`
 }
 
+type TransactionProof struct {
+	Links struct {
+		Self        Link `json:"self"`
+		Transaction Link `json:"transaction"`
+		Block       Link `json:"block"`
+	} `json:"_links"`
+	Hash                string                   `json:"hash"`
+	Block               string                   `json:"block"`
+	Header              block.Header             `json:"header"`
+	Transactions        []string                 `json:"transactions"`
+	ProposerTransaction string                   `json:"proposer_transaction"`
+	Proposer            string                   `json:"proposer"`
+	Round               uint64                   `json:"round"`
+	Index               uint64                   `json:"index"`
+	Siblings            []common.MerkleProofNode `json:"siblings"`
+}
+
 type TransactionsPage struct {
 	Links struct {
 		Self Link `json:"self"`
@@ -105,6 +200,34 @@ type TransactionsPage struct {
 	} `json:"_embedded"`
 }
 
+type FeeStats struct {
+	Links struct {
+		Self Link `json:"self"`
+	} `json:"_links"`
+
+	BaseFee        string            `json:"base_fee"`
+	MinFee         string            `json:"min_fee"`
+	Blocks         uint64            `json:"blocks"`
+	Transactions   uint64            `json:"transactions"`
+	Fee            map[string]string `json:"fee"`
+	RecommendedFee string            `json:"recommended_fee"`
+	Pool           struct {
+		Size      int     `json:"size"`
+		Limit     int     `json:"limit"`
+		Occupancy float64 `json:"occupancy"`
+	} `json:"pool"`
+}
+
+// TransactionFee returns the recommended fee of the transaction, which has
+// `operations` operations.
+func (f FeeStats) TransactionFee(operations int) (common.Amount, error) {
+	fee, err := common.AmountFromString(f.RecommendedFee)
+	if err != nil {
+		return 0, err
+	}
+	return fee.MultInt(operations)
+}
+
 type Operation struct {
 	Links struct {
 		Self        Link `json:"self"`
@@ -162,6 +285,13 @@ type CongressVotingResult struct {
 	CongressVotingHash string `json:"congress_voting_hash"`
 }
 
+type ValidatorUpdate struct {
+	Height   uint64 `json:"height"`
+	Action   string `json:"action"`
+	Address  string `json:"address"`
+	Endpoint string `json:"endpoint"`
+}
+
 type CreateAccount struct {
 	Target string `json:"target"`
 	Amount []byte `json:"amount"`
@@ -193,3 +323,29 @@ type CollectTxFee struct {
 	TotalTxs  uint64 `json:"total-txs"`
 	TotalOps  uint64 `json:"total-ops"`
 }
+
+type DistributeTxFee struct {
+	Recipients []struct {
+		Address   string `json:"address"`
+		Amount    string `json:"amount"`
+		Signature string `json:"signature,omitempty"`
+	} `json:"recipients"`
+	Height    uint64 `json:"block-height"`
+	BlockHash string `json:"block-hash"`
+	TotalTxs  uint64 `json:"total-txs"`
+}
+
+type Delegate struct {
+	Validator string `json:"validator"`
+}
+
+type DelegationReward struct {
+	Rewards []struct {
+		Delegator string `json:"delegator"`
+		Target    string `json:"target"`
+		Amount    string `json:"amount"`
+	} `json:"rewards"`
+	Height    uint64 `json:"block-height"`
+	BlockHash string `json:"block-hash"`
+	TotalTxs  uint64 `json:"total-txs"`
+}
@@ -0,0 +1,227 @@
+package common
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time and the timers. The consensus uses it
+// instead of `time` package directly, so the tests can control the time with
+// `FakeClock`.
+type Clock interface {
+	Now() time.Time
+	NewTimer(time.Duration) Timer
+	Sleep(time.Duration)
+}
+
+// Timer is the `time.Timer` of `Clock`.
+type Timer interface {
+	C() <-chan time.Time
+	Reset(time.Duration) bool
+	Stop() bool
+}
+
+// SystemClock is the `Clock` by `time` package.
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (SystemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{Timer: time.NewTimer(d)}
+}
+
+func (SystemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+// Activity counts the works in progress; the work begins when it is posted
+// and ends when it is handled, so the caller can wait until every work is
+// done. Unlike `sync.WaitGroup`, the work can begin while waiting. The nil
+// `Activity` does nothing.
+type Activity struct {
+	sync.Mutex
+
+	cond *sync.Cond
+	n    int
+}
+
+func NewActivity() *Activity {
+	a := &Activity{}
+	a.cond = sync.NewCond(&a.Mutex)
+
+	return a
+}
+
+func (a *Activity) Begin() {
+	if a == nil {
+		return
+	}
+
+	a.Lock()
+	defer a.Unlock()
+	a.n++
+}
+
+func (a *Activity) End() {
+	if a == nil {
+		return
+	}
+
+	a.Lock()
+	defer a.Unlock()
+	a.n--
+	if a.n < 1 {
+		a.cond.Broadcast()
+	}
+}
+
+// Wait blocks until every work is done.
+func (a *Activity) Wait() {
+	if a == nil {
+		return
+	}
+
+	a.Lock()
+	defer a.Unlock()
+	for a.n > 0 {
+		a.cond.Wait()
+	}
+}
+
+// FakeClock is the `Clock`, which is moved only by `Advance`; the timers are
+// fired when the clock passes their deadline.
+type FakeClock struct {
+	sync.Mutex
+
+	now      time.Time
+	timers   map[*fakeTimer]bool
+	activity *Activity
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{
+		now:    now,
+		timers: map[*fakeTimer]bool{},
+	}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.now
+}
+
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	t := &fakeTimer{clock: c, ch: make(chan time.Time, 1)}
+	t.Reset(d)
+
+	return t
+}
+
+// SetActivity sets the `Activity` of the timers; the fired timer begins the
+// work, which the receiver of timer must end. While sleeping, the caller's
+// work is ended and it begins again when the timer is fired.
+func (c *FakeClock) SetActivity(a *Activity) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.activity = a
+}
+
+// Sleep blocks until the clock is advanced by `d`.
+func (c *FakeClock) Sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+
+	timer := c.NewTimer(d)
+
+	c.Lock()
+	activity := c.activity
+	c.Unlock()
+
+	activity.End()
+	<-timer.C()
+}
+
+// Advance moves the clock by `d` and fires the timers in order of deadline.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.now = c.now.Add(d)
+	c.fireUnlocked()
+}
+
+// Timers returns the number of active timers.
+func (c *FakeClock) Timers() int {
+	c.Lock()
+	defer c.Unlock()
+
+	return len(c.timers)
+}
+
+func (c *FakeClock) fireUnlocked() {
+	var due []*fakeTimer
+	for t := range c.timers {
+		if !t.deadline.After(c.now) {
+			due = append(due, t)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].deadline.Before(due[j].deadline)
+	})
+
+	for _, t := range due {
+		delete(c.timers, t)
+		select {
+		case t.ch <- c.now:
+			c.activity.Begin()
+		default:
+		}
+	}
+}
+
+type fakeTimer struct {
+	clock    *FakeClock
+	ch       chan time.Time
+	deadline time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.Lock()
+	defer t.clock.Unlock()
+
+	active := t.clock.timers[t]
+	t.deadline = t.clock.now.Add(d)
+	t.clock.timers[t] = true
+	t.clock.fireUnlocked()
+
+	return active
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.Lock()
+	defer t.clock.Unlock()
+
+	active := t.clock.timers[t]
+	delete(t.clock.timers, t)
+
+	return active
+}
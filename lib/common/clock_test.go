@@ -0,0 +1,116 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2018, 11, 27, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+
+	fired := func(timer Timer) bool {
+		select {
+		case <-timer.C():
+			return true
+		default:
+			return false
+		}
+	}
+
+	t1 := c.NewTimer(time.Second)
+	t2 := c.NewTimer(3 * time.Second)
+	require.Equal(t, 2, c.Timers())
+
+	c.Advance(500 * time.Millisecond)
+	require.False(t, fired(t1))
+	require.Equal(t, start.Add(500*time.Millisecond), c.Now())
+
+	c.Advance(500 * time.Millisecond)
+	require.True(t, fired(t1))
+	require.False(t, fired(t2))
+	require.Equal(t, 1, c.Timers())
+
+	// reset extends the deadline from now
+	require.True(t, t2.Reset(time.Second))
+	c.Advance(time.Second)
+	require.True(t, fired(t2))
+
+	// stopped timer is not fired
+	require.False(t, t1.Reset(time.Second))
+	require.True(t, t1.Stop())
+	c.Advance(time.Hour)
+	require.False(t, fired(t1))
+
+	// zero duration fires immediately
+	t3 := c.NewTimer(0)
+	require.True(t, fired(t3))
+
+	{ // sleep returns after advanced
+		done := make(chan struct{})
+		go func() {
+			c.Sleep(time.Minute)
+			close(done)
+		}()
+
+		for c.Timers() < 1 {
+			time.Sleep(time.Millisecond)
+		}
+		c.Advance(time.Minute)
+		<-done
+	}
+}
+
+func TestActivity(t *testing.T) {
+	a := NewActivity()
+	a.Wait()
+
+	a.Begin()
+	a.Begin()
+
+	done := make(chan struct{})
+	go func() {
+		a.Wait()
+		close(done)
+	}()
+
+	a.End()
+	select {
+	case <-done:
+		require.Fail(t, "waiting must be blocked until every work is done")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	a.End()
+	<-done
+
+	{ // the fired timer begins the work
+		c := NewFakeClock(time.Now())
+		c.SetActivity(a)
+
+		timer := c.NewTimer(time.Second)
+		c.Advance(time.Second)
+		<-timer.C()
+
+		done := make(chan struct{})
+		go func() {
+			a.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+			require.Fail(t, "the fired timer must be handled")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		a.End()
+		<-done
+	}
+
+	var nilActivity *Activity
+	nilActivity.Begin()
+	nilActivity.End()
+	nilActivity.Wait()
+}
@@ -23,6 +23,24 @@ type Config struct {
 	NetworkID      []byte
 	InitialBalance Amount
 
+	// ProposerSelector is the name of proposer selector; see
+	// `ProposerSelectorNames`. ValidatorWeights is used by the weighted
+	// selector. They must be same in all the validators.
+	ProposerSelector string
+	ValidatorWeights map[string]uint64
+
+	// TxFeeValidatorShare is the percentage of collected transaction fee,
+	// which goes to the validators by TxFeeDistribution, see
+	// `TxFeeDistributionNames`; the rest goes to the common account. They
+	// must be same in all the validators.
+	TxFeeValidatorShare uint64
+	TxFeeDistribution   string
+
+	// InflationToDelegators routes the inflation of block from the common
+	// account to the delegators pro rata to the delegated stake. It must be
+	// same in all the validators.
+	InflationToDelegators bool
+
 	// Those fields are not consensus-related
 	RateLimitRuleAPI  RateLimitRule
 	RateLimitRuleNode RateLimitRule
@@ -37,6 +55,13 @@ type Config struct {
 	JSONRPCEndpoint *Endpoint
 
 	WatcherMode bool
+	// LightMode follows only the blocks and their commit certificates; it
+	// works with `WatcherMode`.
+	LightMode bool
 
 	DiscoveryEndpoints []*Endpoint
+
+	// ConsensusWALPath is the file path of the write-ahead log of consensus;
+	// if empty, the log is not kept.
+	ConsensusWALPath string
 }
@@ -15,6 +15,11 @@ const (
 	// is `0.1` BOS.
 	BaseReserve Amount = 1000000
 
+	// MinFeeChangeDenominator bounds the change of the minimum fee of block;
+	// the minimum fee of the next block changes by up to `1 /
+	// MinFeeChangeDenominator` of the current one. See `block.NextMinFee`.
+	MinFeeChangeDenominator uint64 = 8
+
 	// FrozenFee is a special transaction fee about freezing, and unfreezing.
 	FrozenFee Amount = 0
 
@@ -57,6 +62,19 @@ const (
 	// `ProposerTransaction`.
 	DefaultOperationsInBallotLimit int = 10000
 
+	// MaxSignersInAccount is the maximum number of additional signers of one
+	// account.
+	MaxSignersInAccount int = 20
+
+	// MaxDelegatorsOfValidator is the maximum number of delegators of one
+	// validator; it bounds the rewards of delegators in the proposer
+	// transaction.
+	MaxDelegatorsOfValidator uint64 = 1000
+
+	// MaxMemoTextSize is the maximum length of text memo of transaction in
+	// bytes.
+	MaxMemoTextSize int = 64
+
 	DefaultTimeoutINIT       = 2 * time.Second
 	DefaultTimeoutSIGN       = 2 * time.Second
 	DefaultTimeoutACCEPT     = 2 * time.Second
@@ -64,6 +82,25 @@ const (
 	DefaultBlockTime         = 5 * time.Second
 	DefaultBlockTimeDelta    = 1 * time.Second
 
+	ProposerSelectorSequentialName = "sequential"
+	ProposerSelectorHashName       = "hash"
+	ProposerSelectorWeightedName   = "weighted"
+	ProposerSelectorSkipMissedName = "skip-missed"
+
+	// TxFeeDistributionProposer and TxFeeDistributionSigners decide who
+	// receives the validator share of collected transaction fee; the proposer
+	// of block or the validators, which signed the commit of previous block.
+	TxFeeDistributionProposer = "proposer"
+	TxFeeDistributionSigners  = "signers"
+
+	// DefaultValidatorWeight is the weight of validator for the weighted
+	// proposer selector, when the weight is not configured.
+	DefaultValidatorWeight uint64 = 1
+
+	// MissedProposerWindow is the number of the latest blocks, which the
+	// skip-missed proposer selector looks into to find the missed proposers.
+	MissedProposerWindow uint64 = 100
+
 	// DiscoveryMessageCreatedAllowDuration limit the `DiscoveryMessage.Created`
 	// is allowed or not.
 	DiscoveryMessageCreatedAllowDuration time.Duration = time.Second * 10
@@ -98,5 +135,15 @@ var (
 		HTTPCacheRedisAdapterName:  true,
 		"":                         true, // default value is nop cache
 	}
+	ProposerSelectorNames = map[string]bool{
+		ProposerSelectorSequentialName: true,
+		ProposerSelectorHashName:       true,
+		ProposerSelectorWeightedName:   true,
+		ProposerSelectorSkipMissedName: true,
+	}
+	TxFeeDistributionNames = map[string]bool{
+		TxFeeDistributionProposer: true,
+		TxFeeDistributionSigners:  true,
+	}
 	DefaultJSONRPCBindURL string = "http://127.0.0.1:54321/jsonrpc" // JSONRPC only can be accessed from localhost
 )
@@ -0,0 +1,140 @@
+package common
+
+import (
+	"bytes"
+
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// Prefixes of the hashed data in merkle tree; the leaf and the inner node are
+// hashed differently, so the inner node can not be presented as a leaf.
+const (
+	merkleLeafPrefix byte = 0x00
+	merkleNodePrefix byte = 0x01
+)
+
+// MerkleProofNode is the sibling hash in the path from the leaf to the root.
+// `Left` means the sibling is the left side of the pair.
+type MerkleProofNode struct {
+	Hash string `json:"hash"`
+	Left bool   `json:"left"`
+}
+
+// MerkleProof is the inclusion proof of the leaf in the merkle tree.
+type MerkleProof struct {
+	Index    uint64            `json:"index"`
+	Siblings []MerkleProofNode `json:"siblings"`
+}
+
+func makeMerkleLeaf(leaf string) []byte {
+	return MakeHash(append([]byte{merkleLeafPrefix}, []byte(leaf)...))
+}
+
+func makeMerkleNode(left, right []byte) []byte {
+	b := make([]byte, 0, 1+len(left)+len(right))
+	b = append(b, merkleNodePrefix)
+	b = append(b, left...)
+	b = append(b, right...)
+	return MakeHash(b)
+}
+
+// makeMerkleLevels returns the all levels of tree from the leaves to the root.
+// The last node of the odd level is promoted to the upper level without
+// hashing.
+func makeMerkleLevels(leaves []string) (levels [][][]byte) {
+	level := make([][]byte, len(leaves))
+	for i, leaf := range leaves {
+		level[i] = makeMerkleLeaf(leaf)
+	}
+	levels = append(levels, level)
+
+	for len(level) > 1 {
+		var upper [][]byte
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				upper = append(upper, level[i])
+				continue
+			}
+			upper = append(upper, makeMerkleNode(level[i], level[i+1]))
+		}
+		levels = append(levels, upper)
+		level = upper
+	}
+
+	return
+}
+
+// MakeMerkleRoot returns the base58 encoded merkle root of leaves.
+func MakeMerkleRoot(leaves []string) string {
+	if len(leaves) < 1 {
+		return base58.Encode(MakeHash(nil))
+	}
+
+	levels := makeMerkleLevels(leaves)
+	return base58.Encode(levels[len(levels)-1][0])
+}
+
+// MakeMerkleProof returns the `MerkleProof` of the leaf at `index`.
+func MakeMerkleProof(leaves []string, index int) (proof MerkleProof, found bool) {
+	if index < 0 || index >= len(leaves) {
+		return
+	}
+
+	proof.Index = uint64(index)
+	levels := makeMerkleLevels(leaves)
+	for _, level := range levels[:len(levels)-1] {
+		sibling := index ^ 1
+		if sibling < len(level) {
+			proof.Siblings = append(
+				proof.Siblings,
+				MerkleProofNode{Hash: base58.Encode(level[sibling]), Left: sibling < index},
+			)
+		}
+		index /= 2
+	}
+
+	found = true
+	return
+}
+
+// Verify checks the leaf at `Index` is included in the merkle tree of `root`,
+// which has `leaves` leaves; the siblings must be on the path from `Index` to
+// the root, so the proof can not claim the other position.
+func (p MerkleProof) Verify(leaf, root string, leaves uint64) bool {
+	if p.Index >= leaves {
+		return false
+	}
+
+	h := makeMerkleLeaf(leaf)
+	siblings := p.Siblings
+	for index, width := p.Index, leaves; width > 1; index, width = index/2, (width+1)/2 {
+		// the last node of the odd level has no sibling
+		if index^1 >= width {
+			continue
+		}
+		if len(siblings) < 1 {
+			return false
+		}
+
+		node := siblings[0]
+		siblings = siblings[1:]
+		if node.Left != (index%2 == 1) {
+			return false
+		}
+
+		sibling := base58.Decode(node.Hash)
+		if len(sibling) < 1 {
+			return false
+		}
+		if node.Left {
+			h = makeMerkleNode(sibling, h)
+		} else {
+			h = makeMerkleNode(h, sibling)
+		}
+	}
+	if len(siblings) > 0 {
+		return false
+	}
+
+	return bytes.Equal(h, base58.Decode(root))
+}
@@ -0,0 +1,53 @@
+package common
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerkleProof(t *testing.T) {
+	for n := 1; n <= 9; n++ {
+		var leaves []string
+		for i := 0; i < n; i++ {
+			leaves = append(leaves, fmt.Sprintf("leaf-%d", i))
+		}
+		root := MakeMerkleRoot(leaves)
+
+		for i, leaf := range leaves {
+			proof, found := MakeMerkleProof(leaves, i)
+			require.True(t, found)
+			require.True(t, proof.Verify(leaf, root, uint64(n)), "leaves=%d index=%d", n, i)
+
+			// the other leaf can not be verified with same proof
+			require.False(t, proof.Verify("unknown", root, uint64(n)))
+
+			// the proof can not claim the other position
+			for j := 0; j <= n; j++ {
+				if j == i {
+					continue
+				}
+				moved := proof
+				moved.Index = uint64(j)
+				require.False(t, moved.Verify(leaf, root, uint64(n)), "leaves=%d index=%d claimed=%d", n, i, j)
+			}
+		}
+
+		_, found := MakeMerkleProof(leaves, n)
+		require.False(t, found)
+	}
+}
+
+func TestMerkleRoot(t *testing.T) {
+	// the root is changed by the order of leaves
+	require.NotEqual(t, MakeMerkleRoot([]string{"a", "b"}), MakeMerkleRoot([]string{"b", "a"}))
+
+	// the last leaf is not duplicated
+	require.NotEqual(t, MakeMerkleRoot([]string{"a", "b", "c"}), MakeMerkleRoot([]string{"a", "b", "c", "c"}))
+
+	// single leaf
+	proof, _ := MakeMerkleProof([]string{"a"}, 0)
+	require.Equal(t, 0, len(proof.Siblings))
+	require.True(t, proof.Verify("a", MakeMerkleRoot([]string{"a"}), 1))
+}
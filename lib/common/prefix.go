@@ -5,11 +5,13 @@ const (
 	BlockPrefixConfirmed                  = string(0x01)
 	BlockPrefixHeight                     = string(0x02)
 	BlockPrefixTime                       = string(0x03)
+	BlockPrefixCommitCertificate          = string(0x04) // commit certificates by height
 	BlockTransactionPrefixHash            = string(0x10)
 	BlockTransactionPrefixSource          = string(0x11)
 	BlockTransactionPrefixConfirmed       = string(0x12)
 	BlockTransactionPrefixAccount         = string(0x13)
 	BlockTransactionPrefixBlock           = string(0x14)
+	BlockTransactionPrefixAccountMemo     = string(0x15)
 	BlockOperationPrefixHash              = string(0x20)
 	BlockOperationPrefixTxHash            = string(0x21)
 	BlockOperationPrefixSource            = string(0x22)
@@ -26,6 +28,14 @@ const (
 	BlockAccountSequenceIDPrefix          = string(0x32)
 	BlockAccountSequenceIDByAddressPrefix = string(0x33)
 	BlockAccountPrefixFrozen              = string(0x34)
+	BlockAccountPrefixHistory             = string(0x35)
 	TransactionPoolPrefix                 = string(0x40)
+	TransactionPoolJournalPrefix          = string(0x41) // pending transactions of `transaction.Pool`
 	InternalPrefix                        = string(0x50) // internal data
+	StateTriePrefix                       = string(0x60) // nodes of state trie
+	EvidencePrefix                        = string(0x70) // evidences of equivocation
+	ValidatorUpdatePrefix                 = string(0x80) // scheduled validator updates by height
+	DelegationPrefixAccount               = string(0x90) // delegations by frozen account
+	DelegationPrefixValidator             = string(0x91) // delegations by validator
+	DelegatedStakePrefix                  = string(0x92) // total delegated stake by validator
 )
@@ -0,0 +1,167 @@
+package consensus
+
+import (
+	"fmt"
+	"sync"
+
+	"boscoin.io/sebak/lib/ballot"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+)
+
+// Evidence is the proof of equivocation; the validator signed two different
+// ballots for the same height, round and ballot state. Both ballots keep
+// their signatures, so anyone can verify the evidence by `Evidence.Verify`.
+type Evidence struct {
+	Validator string           `json:"validator"`
+	Height    uint64           `json:"height"`
+	Round     uint64           `json:"round"`
+	State     ballot.State     `json:"state"`
+	Ballots   [2]ballot.Ballot `json:"ballots"`
+	Detected  string           `json:"detected"` // detected time, ISO8601
+}
+
+func NewEvidence(first, second ballot.Ballot) Evidence {
+	return Evidence{
+		Validator: first.Source(),
+		Height:    first.VotingBasis().Height,
+		Round:     first.VotingBasis().Round,
+		State:     first.State(),
+		Ballots:   [2]ballot.Ballot{first, second},
+		Detected:  common.NowISO8601(),
+	}
+}
+
+func GetEvidenceKeyPrefixValidator(validator string) string {
+	return fmt.Sprintf("%s%s-", common.EvidencePrefix, validator)
+}
+
+func (e Evidence) NewEvidenceKey() string {
+	return fmt.Sprintf(
+		"%s%020d-%020d-%d",
+		GetEvidenceKeyPrefixValidator(e.Validator),
+		e.Height,
+		e.Round,
+		e.State,
+	)
+}
+
+func (e Evidence) String() string {
+	return string(common.MustMarshalJSON(e))
+}
+
+// Verify checks the both ballots are signed by the validator and they are
+// conflicted.
+func (e Evidence) Verify(networkID []byte) (err error) {
+	for _, b := range e.Ballots {
+		if b.Source() != e.Validator ||
+			b.VotingBasis().Height != e.Height ||
+			b.VotingBasis().Round != e.Round ||
+			b.State() != e.State {
+			return errors.InvalidEvidence
+		}
+		if err = b.VerifySource(networkID); err != nil {
+			return
+		}
+	}
+
+	if e.Ballots[0].GetHash() == e.Ballots[1].GetHash() {
+		return errors.InvalidEvidence
+	}
+
+	return
+}
+
+// Save stores the evidence. Only the first evidence is kept for the same
+// validator, height, round and ballot state.
+func (e Evidence) Save(st *storage.LevelDBBackend) (err error) {
+	key := e.NewEvidenceKey()
+
+	var exists bool
+	if exists, err = st.Has(key); err != nil || exists {
+		return
+	}
+
+	return st.New(key, e)
+}
+
+// GetEvidences returns the stored evidences; if `validator` is empty, the
+// evidences of all the validators are returned.
+func GetEvidences(st *storage.LevelDBBackend, validator string, options storage.ListOptions) (func() (Evidence, bool, []byte), func()) {
+	prefix := common.EvidencePrefix
+	if len(validator) > 0 {
+		prefix = GetEvidenceKeyPrefixValidator(validator)
+	}
+	iterFunc, closeFunc := st.GetIterator(prefix, options)
+
+	return (func() (Evidence, bool, []byte) {
+			item, hasNext := iterFunc()
+			if !hasNext {
+				return Evidence{}, false, item.Key
+			}
+
+			var e Evidence
+			common.MustUnmarshalJSON(item.Value, &e)
+			return e, hasNext, item.Key
+		}), (func() {
+			closeFunc()
+		})
+}
+
+// EquivocationDetector keeps the first received ballot of the validators by
+// height, round and ballot state, and finds the different ballot for them.
+type EquivocationDetector struct {
+	sync.Mutex
+
+	ballots map[ /* height-round-state-source */ string]ballot.Ballot
+}
+
+func NewEquivocationDetector() *EquivocationDetector {
+	return &EquivocationDetector{
+		ballots: map[string]ballot.Ballot{},
+	}
+}
+
+func equivocationKey(b ballot.Ballot) string {
+	return fmt.Sprintf(
+		"%d-%d-%d-%s",
+		b.VotingBasis().Height,
+		b.VotingBasis().Round,
+		b.State(),
+		b.Source(),
+	)
+}
+
+// Detect records the ballot and returns the `Evidence`, if the source of
+// ballot already signed the different ballot.
+func (d *EquivocationDetector) Detect(b ballot.Ballot) (evidence Evidence, found bool) {
+	d.Lock()
+	defer d.Unlock()
+
+	key := equivocationKey(b)
+	first, exists := d.ballots[key]
+	if !exists {
+		d.ballots[key] = b
+		return
+	}
+
+	if first.GetHash() == b.GetHash() {
+		return
+	}
+
+	return NewEvidence(first, b), true
+}
+
+// RemoveLowerThanOrEqualHeight removes the recorded ballots, which are lower
+// than or equal to `height`.
+func (d *EquivocationDetector) RemoveLowerThanOrEqualHeight(height uint64) {
+	d.Lock()
+	defer d.Unlock()
+
+	for key, b := range d.ballots {
+		if b.VotingBasis().Height <= height {
+			delete(d.ballots, key)
+		}
+	}
+}
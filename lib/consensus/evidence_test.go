@@ -0,0 +1,117 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/ballot"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/voting"
+)
+
+func makeEvidenceBallot(kp *keypair.Full, networkID []byte, height uint64, vote voting.Hole) ballot.Ballot {
+	basis := voting.Basis{Height: height, Round: 0}
+	b := ballot.NewBallot(kp.Address(), keypair.Random().Address(), basis, []string{})
+	b.SetVote(ballot.StateSIGN, vote)
+	b.Sign(kp, networkID)
+	return *b
+}
+
+func TestEquivocationDetector(t *testing.T) {
+	networkID := common.NewTestConfig().NetworkID
+	kp := keypair.Random()
+	d := NewEquivocationDetector()
+
+	first := makeEvidenceBallot(kp, networkID, 10, voting.YES)
+	_, found := d.Detect(first)
+	require.False(t, found)
+
+	// same ballot
+	_, found = d.Detect(first)
+	require.False(t, found)
+
+	// the ballot of the other validator
+	_, found = d.Detect(makeEvidenceBallot(keypair.Random(), networkID, 10, voting.NO))
+	require.False(t, found)
+
+	second := makeEvidenceBallot(kp, networkID, 10, voting.NO)
+	evidence, found := d.Detect(second)
+	require.True(t, found)
+	require.Equal(t, kp.Address(), evidence.Validator)
+	require.Equal(t, uint64(10), evidence.Height)
+	require.Equal(t, ballot.StateSIGN, evidence.State)
+	require.Equal(t, first.GetHash(), evidence.Ballots[0].GetHash())
+	require.Equal(t, second.GetHash(), evidence.Ballots[1].GetHash())
+	require.NoError(t, evidence.Verify(networkID))
+
+	// invalid network id
+	require.Error(t, evidence.Verify([]byte("showme")))
+
+	// same ballots are not evidence
+	invalid := NewEvidence(first, first)
+	require.Equal(t, errors.InvalidEvidence, invalid.Verify(networkID))
+
+	// removed records
+	d.RemoveLowerThanOrEqualHeight(10)
+	_, found = d.Detect(second)
+	require.False(t, found)
+}
+
+func TestEvidenceSave(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	networkID := common.NewTestConfig().NetworkID
+
+	kp0 := keypair.Random()
+	kp1 := keypair.Random()
+
+	var saved []Evidence
+	for _, kp := range []*keypair.Full{kp0, kp1} {
+		for height := uint64(1); height < 4; height++ {
+			e := NewEvidence(
+				makeEvidenceBallot(kp, networkID, height, voting.YES),
+				makeEvidenceBallot(kp, networkID, height, voting.NO),
+			)
+			require.NoError(t, e.Save(st))
+			saved = append(saved, e)
+		}
+	}
+
+	// the same evidence is saved only once
+	require.NoError(t, saved[0].Save(st))
+
+	var all []Evidence
+	iterFunc, closeFunc := GetEvidences(st, "", storage.NewDefaultListOptions(false, nil, 100))
+	for {
+		e, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+		require.NoError(t, e.Verify(networkID))
+		all = append(all, e)
+	}
+	closeFunc()
+	require.Equal(t, len(saved), len(all))
+
+	var byValidator []Evidence
+	iterFunc, closeFunc = GetEvidences(st, kp1.Address(), storage.NewDefaultListOptions(false, nil, 100))
+	for {
+		e, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+		byValidator = append(byValidator, e)
+	}
+	closeFunc()
+
+	require.Equal(t, 3, len(byValidator))
+	for i, e := range byValidator {
+		require.Equal(t, kp1.Address(), e.Validator)
+		require.Equal(t, uint64(i+1), e.Height)
+	}
+}
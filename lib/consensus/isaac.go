@@ -43,13 +43,18 @@ type ISAAC struct {
 func NewISAAC(node *node.LocalNode, p voting.ThresholdPolicy,
 	cm network.ConnectionManager, st *storage.LevelDBBackend, conf common.Config, syncer SyncController) (is *ISAAC, err error) {
 
+	var proposerSelector ProposerSelector
+	if proposerSelector, err = NewProposerSelector(conf, cm, st); err != nil {
+		return
+	}
+
 	is = &ISAAC{
 		Node:              node,
 		policy:            p,
 		RunningRounds:     map[string]*RunningRound{},
 		connectionManager: cm,
 		storage:           st,
-		proposerSelector:  SequentialSelector{cm},
+		proposerSelector:  proposerSelector,
 		Conf:              conf,
 		log:               log.New(logging.Ctx{"node": node.Alias()}),
 		syncer:            syncer,
@@ -170,6 +175,9 @@ func (is *ISAAC) Vote(b ballot.Ballot) (isNew bool, err error) {
 			b.VotingBasis().Height,
 			b.VotingBasis().Round,
 		)
+		if proposer == "" {
+			return false, errors.ProposerNotDecided
+		}
 
 		if runningRound, err = NewRunningRound(proposer, b); err != nil {
 			return true, err
@@ -222,6 +230,33 @@ func (is *ISAAC) CanGetVotingResult(b ballot.Ballot) (rv RoundVoteResult, vh vot
 	return
 }
 
+// BlockSignatures returns the block signatures of the validators, which voted
+// YES to the ACCEPT ballots of the same proposer with `b`.
+func (is *ISAAC) BlockSignatures(b ballot.Ballot) map[string]string {
+	is.RLock()
+	defer is.RUnlock()
+
+	signatures := map[string]string{}
+
+	runningRound, found := is.RunningRounds[b.VotingBasis().Index()]
+	if !found {
+		return signatures
+	}
+
+	runningRound.RLock()
+	defer runningRound.RUnlock()
+
+	roundVote, err := runningRound.RoundVote(b.Proposer())
+	if err != nil {
+		return signatures
+	}
+	for source, signature := range roundVote.BlockSignatures {
+		signatures[source] = signature
+	}
+
+	return signatures
+}
+
 func (is *ISAAC) IsVotedByNode(b ballot.Ballot, node string) (bool, error) {
 	is.RLock()
 	defer is.RUnlock()
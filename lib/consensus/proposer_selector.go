@@ -1,21 +1,289 @@
 package consensus
 
 import (
+	"encoding/binary"
+	"fmt"
 	"sort"
+	"strings"
+	"sync"
 
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/storage"
 )
 
+// ProposerSelector selects the proposer of the next block of `blockHeight`
+// at `round`. If the proposer can not be decided, for example, the block is
+// not stored yet, it returns empty string; it never matches with any
+// proposer, so the ballot is not accepted.
 type ProposerSelector interface {
 	Select(uint64, uint64) string
 }
 
+// NewProposerSelector returns the `ProposerSelector` by
+// `common.Config.ProposerSelector`. Every selector only depends on the
+// validators, the stored blocks and the config, so the validators, which have
+// same config, select same proposer.
+func NewProposerSelector(conf common.Config, cm network.ConnectionManager, st *storage.LevelDBBackend) (ProposerSelector, error) {
+	switch conf.ProposerSelector {
+	case common.ProposerSelectorSequentialName, "":
+		return SequentialSelector{cm}, nil
+	case common.ProposerSelectorHashName:
+		return HashSelector{cm: cm, st: st}, nil
+	case common.ProposerSelectorWeightedName:
+		return WeightedSelector{cm: cm, st: st, weights: conf.ValidatorWeights}, nil
+	case common.ProposerSelectorSkipMissedName:
+		return NewSkipMissedSelector(cm, st, common.MissedProposerWindow), nil
+	default:
+		return nil, errors.UnknownProposerSelector.Clone().SetData("selector", conf.ProposerSelector)
+	}
+}
+
+func sortedValidators(cm network.ConnectionManager) []string {
+	candidates := sort.StringSlice(cm.AllValidators())
+	candidates.Sort()
+	return candidates
+}
+
 type SequentialSelector struct {
 	cm network.ConnectionManager
 }
 
 func (s SequentialSelector) Select(blockHeight uint64, round uint64) string {
-	candidates := sort.StringSlice(s.cm.AllValidators())
-	candidates.Sort()
+	candidates := sortedValidators(s.cm)
+	return candidates[(blockHeight+round)%uint64(len(candidates))]
+}
+
+// getSelectorSeed returns the seed from the hash of the block at
+// `blockHeight` and `round`. If the block is not found, it returns false; the
+// seed must not be made from the other data, the validators, which have the
+// block, would select the different proposer.
+func getSelectorSeed(st *storage.LevelDBBackend, blockHeight uint64, round uint64) (uint64, bool) {
+	blk, err := block.GetBlockByHeight(st, blockHeight)
+	if err != nil {
+		return 0, false
+	}
+
+	h := common.MakeHash([]byte(fmt.Sprintf("%s-%d", blk.Hash, round)))
+	return binary.BigEndian.Uint64(h[:8]), true
+}
+
+// HashSelector selects the proposer by the hash of the previous block, so the
+// proposer can not be known before the previous block is confirmed.
+type HashSelector struct {
+	cm network.ConnectionManager
+	st *storage.LevelDBBackend
+}
+
+func (s HashSelector) Select(blockHeight uint64, round uint64) string {
+	candidates := sortedValidators(s.cm)
+	seed, found := getSelectorSeed(s.st, blockHeight, round)
+	if !found {
+		return ""
+	}
+	return candidates[seed%uint64(len(candidates))]
+}
+
+// WeightedSelector selects the proposer like `HashSelector`, but the chance
+// of validator is proportional to it's weight. The validator, which is not in
+// `weights`, has `common.DefaultValidatorWeight` and the validator of zero
+// weight is never selected.
+type WeightedSelector struct {
+	cm      network.ConnectionManager
+	st      *storage.LevelDBBackend
+	weights map[string]uint64
+}
+
+func (s WeightedSelector) weight(address string) uint64 {
+	if w, found := s.weights[address]; found {
+		return w
+	}
+	return common.DefaultValidatorWeight
+}
+
+func (s WeightedSelector) Select(blockHeight uint64, round uint64) string {
+	candidates := sortedValidators(s.cm)
+
+	var total uint64
+	for _, address := range candidates {
+		total += s.weight(address)
+	}
+	if total < 1 {
+		return candidates[(blockHeight+round)%uint64(len(candidates))]
+	}
+
+	seed, found := getSelectorSeed(s.st, blockHeight, round)
+	if !found {
+		return ""
+	}
+
+	point := seed % total
+	for _, address := range candidates {
+		w := s.weight(address)
+		if point < w {
+			return address
+		}
+		point -= w
+	}
+
+	return candidates[len(candidates)-1]
+}
+
+// SkipMissedSelector selects the proposer like `SequentialSelector`, but
+// skips the validators, which missed their proposal in the last `window`
+// blocks. The block of round `n` means the `n` candidates before it's proposer
+// missed; the candidates of each block are the validators except the missed
+// ones in the window before the block, so they are found from the stored
+// proposers and rounds of blocks. If all the validators missed, none is
+// skipped.
+type SkipMissedSelector struct {
+	sync.Mutex
+
+	cm     network.ConnectionManager
+	st     *storage.LevelDBBackend
+	window uint64
+
+	cachedValidators string
+	cachedMissed     map[uint64][]string // missed validators by height of block
+}
+
+func NewSkipMissedSelector(cm network.ConnectionManager, st *storage.LevelDBBackend, window uint64) *SkipMissedSelector {
+	return &SkipMissedSelector{
+		cm:     cm,
+		st:     st,
+		window: window,
+	}
+}
+
+func (s *SkipMissedSelector) candidates(validators []string, missed map[string]bool) []string {
+	var candidates []string
+	for _, address := range validators {
+		if !missed[address] {
+			candidates = append(candidates, address)
+		}
+	}
+	if len(candidates) < 1 {
+		return validators
+	}
+
+	return candidates
+}
+
+// windowStart returns the height before the first block of the window until
+// `blockHeight`.
+func (s *SkipMissedSelector) windowStart(blockHeight uint64) uint64 {
+	if blockHeight > s.window+common.GenesisBlockHeight {
+		return blockHeight - s.window
+	}
+	return common.GenesisBlockHeight
+}
+
+// missedInWindow collects the missed validators of the blocks from
+// `start + 1` to `end`.
+func (s *SkipMissedSelector) missedInWindow(start, end uint64) map[string]bool {
+	missed := map[string]bool{}
+	for height := start + 1; height <= end; height++ {
+		for _, address := range s.cachedMissed[height] {
+			missed[address] = true
+		}
+	}
+
+	return missed
+}
+
+// missedAt returns the validators, which missed their proposal before
+// `proposer` of the block of `height` at `round`; they are the `round`
+// candidates before `proposer`.
+func (s *SkipMissedSelector) missedAt(validators []string, height uint64, proposer string, round uint64) (missed []string) {
+	candidates := s.candidates(validators, s.missedInWindow(s.windowStart(height-1), height-1))
+	n := uint64(len(candidates))
+
+	index := (height - 1 + round) % n
+	for i, address := range candidates {
+		if address == proposer {
+			index = uint64(i)
+			break
+		}
+	}
+
+	for i := uint64(1); i <= round && i < n; i++ {
+		missed = append(missed, candidates[(index+n-i)%n])
+	}
+
+	return
+}
+
+// missed returns the validators, which missed their proposal in the blocks
+// of the window until `blockHeight`. If the blocks are not stored, it returns
+// false.
+func (s *SkipMissedSelector) missed(validators []string, blockHeight uint64) (map[string]bool, bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	// the validators can be changed by `operation.ValidatorUpdate`
+	key := strings.Join(validators, ",")
+	if s.cachedMissed == nil || s.cachedValidators != key {
+		s.cachedValidators = key
+		s.cachedMissed = map[uint64][]string{}
+	}
+
+	// find the first block to be checked; the missed validators of the block
+	// of round `n > 0` depend on the window before it.
+	type proposal struct {
+		proposer string
+		round    uint64
+	}
+	proposals := map[uint64]proposal{}
+
+	start := s.windowStart(blockHeight)
+	for height := blockHeight; height > start; height-- {
+		if _, found := s.cachedMissed[height]; found {
+			continue
+		}
+
+		blk, err := block.GetBlockByHeight(s.st, height)
+		if err != nil {
+			return nil, false
+		}
+		proposals[height] = proposal{proposer: blk.Proposer, round: blk.Round}
+		if blk.Round > 0 {
+			if w := s.windowStart(height - 1); w < start {
+				start = w
+			}
+		}
+	}
+
+	for height := start + 1; height <= blockHeight; height++ {
+		p, found := proposals[height]
+		if !found {
+			continue
+		}
+		var missed []string
+		if p.round > 0 {
+			missed = s.missedAt(validators, height, p.proposer, p.round)
+		}
+		s.cachedMissed[height] = missed
+	}
+
+	// the old ones are not needed anymore
+	for height := range s.cachedMissed {
+		if height+2*s.window < blockHeight {
+			delete(s.cachedMissed, height)
+		}
+	}
+
+	return s.missedInWindow(s.windowStart(blockHeight), blockHeight), true
+}
+
+func (s *SkipMissedSelector) Select(blockHeight uint64, round uint64) string {
+	validators := sortedValidators(s.cm)
+	missed, found := s.missed(validators, blockHeight)
+	if !found {
+		return ""
+	}
+
+	candidates := s.candidates(validators, missed)
 	return candidates[(blockHeight+round)%uint64(len(candidates))]
 }
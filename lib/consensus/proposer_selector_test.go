@@ -0,0 +1,222 @@
+package consensus
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/voting"
+)
+
+type selectorConnectionManager struct {
+	network.ConnectionManager
+	validators []string
+}
+
+func (c selectorConnectionManager) AllValidators() []string {
+	return append([]string{}, c.validators...)
+}
+
+func makeSelectorValidators(n int) []string {
+	var validators []string
+	for i := 0; i < n; i++ {
+		validators = append(validators, keypair.Random().Address())
+	}
+	sort.Strings(validators)
+	return validators
+}
+
+// makeSelectorBlocks stores the blocks until `height`; the block of height in
+// `rounds` is confirmed in the given round by the proposer in `proposers`.
+func makeSelectorBlocks(st *storage.LevelDBBackend, height uint64, rounds map[uint64]uint64, proposers map[uint64]string) {
+	prev := block.TestMakeNewBlock(nil)
+	prev.MustSave(st)
+	for h := prev.Height + 1; h <= height; h++ {
+		proposer, found := proposers[h]
+		if !found {
+			proposer = keypair.Random().Address()
+		}
+		blk := block.NewBlock(
+			proposer,
+			voting.Basis{Height: h, Round: rounds[h], BlockHash: prev.Hash},
+			"",
+			nil,
+			"",
+			common.NowISO8601(),
+		)
+		blk.MustSave(st)
+		prev = *blk
+	}
+}
+
+func TestNewProposerSelector(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	cm := selectorConnectionManager{validators: makeSelectorValidators(3)}
+
+	for name := range common.ProposerSelectorNames {
+		conf := common.NewTestConfig()
+		conf.ProposerSelector = name
+		selector, err := NewProposerSelector(conf, cm, st)
+		require.NoError(t, err)
+		require.NotNil(t, selector)
+	}
+
+	conf := common.NewTestConfig()
+	conf.ProposerSelector = "unknown"
+	_, err := NewProposerSelector(conf, cm, st)
+	require.Equal(t, errors.UnknownProposerSelector.Code, err.(*errors.Error).Code)
+}
+
+func TestHashSelector(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	validators := makeSelectorValidators(4)
+	makeSelectorBlocks(st, 30, nil, nil)
+
+	selector := HashSelector{cm: selectorConnectionManager{validators: validators}, st: st}
+	other := HashSelector{cm: selectorConnectionManager{validators: validators}, st: st}
+
+	var sequential int
+	selected := map[string]bool{}
+	for height := uint64(1); height <= 30; height++ {
+		proposer := selector.Select(height, 0)
+		require.Equal(t, proposer, other.Select(height, 0))
+		require.Contains(t, validators, proposer)
+
+		selected[proposer] = true
+		if proposer == validators[height%uint64(len(validators))] {
+			sequential++
+		}
+	}
+
+	// the proposers are not same with the sequential order
+	require.True(t, sequential < 30)
+	require.True(t, len(selected) > 1)
+
+	// without the block, the proposer can not be decided
+	require.Equal(t, "", selector.Select(31, 0))
+}
+
+func TestWeightedSelector(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	validators := makeSelectorValidators(3)
+	makeSelectorBlocks(st, 2, nil, nil)
+
+	selector := WeightedSelector{
+		cm: selectorConnectionManager{validators: validators},
+		st: st,
+		weights: map[string]uint64{
+			validators[0]: 0,
+			validators[1]: 9,
+		},
+	}
+
+	counts := map[string]int{}
+	for round := uint64(0); round < 200; round++ {
+		proposer := selector.Select(2, round)
+		require.Equal(t, proposer, selector.Select(2, round))
+		counts[proposer]++
+	}
+
+	// zero weight is never selected and `validators[2]` has default weight
+	require.Equal(t, 0, counts[validators[0]])
+	require.True(t, counts[validators[1]] > counts[validators[2]])
+	require.True(t, counts[validators[2]] > 0)
+
+	// all the weights are zero
+	selector.weights = map[string]uint64{validators[0]: 0, validators[1]: 0, validators[2]: 0}
+	require.Equal(t, validators[2], selector.Select(2, 0))
+
+	// without the block, the proposer can not be decided
+	selector.weights = nil
+	require.Equal(t, "", selector.Select(3, 0))
+}
+
+func TestSkipMissedSelector(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	validators := makeSelectorValidators(4)
+	cm := selectorConnectionManager{validators: validators}
+
+	// nothing missed
+	makeSelectorBlocks(st, 5, nil, nil)
+	selector := NewSkipMissedSelector(cm, st, 10)
+	for round := uint64(0); round < 4; round++ {
+		require.Equal(t, SequentialSelector{cm}.Select(5, round), selector.Select(5, round))
+	}
+
+	// the block of height 5 is confirmed in round 1, so the proposer of round 0
+	// in height 4 missed
+	st = storage.NewTestStorage()
+	defer st.Close()
+
+	makeSelectorBlocks(st, 5, map[uint64]uint64{5: 1}, nil)
+	missed := validators[4%len(validators)]
+
+	selector = NewSkipMissedSelector(cm, st, 10)
+	selected := map[string]bool{}
+	for round := uint64(0); round < 6; round++ {
+		selected[selector.Select(5, round)] = true
+	}
+	require.Equal(t, len(validators)-1, len(selected))
+	require.False(t, selected[missed])
+
+	// out of window
+	selector = NewSkipMissedSelector(cm, st, 0)
+	selected = map[string]bool{}
+	for round := uint64(0); round < 6; round++ {
+		selected[selector.Select(5, round)] = true
+	}
+	require.True(t, selected[missed])
+}
+
+func TestSkipMissedSelectorCandidatesOfBlock(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	validators := makeSelectorValidators(4)
+	cm := selectorConnectionManager{validators: validators}
+
+	// height 3: the candidates are all the validators, `validators[3]` is
+	// selected in round 1, so `validators[2]` missed.
+	// height 4: the candidates are `validators[0, 1, 3]`, `validators[1]` is
+	// selected in round 1, so `validators[0]` missed.
+	makeSelectorBlocks(
+		st, 5,
+		map[uint64]uint64{3: 1, 4: 1},
+		map[uint64]string{3: validators[3], 4: validators[1]},
+	)
+
+	selector := NewSkipMissedSelector(cm, st, 2)
+	require.Equal(t, validators[3], selector.Select(2, 1))
+	require.Equal(t, validators[1], selector.Select(3, 1))
+
+	// in the window of height 5, only `validators[0]` missed
+	selected := map[string]bool{}
+	for round := uint64(0); round < 6; round++ {
+		selected[selector.Select(5, round)] = true
+	}
+	require.Equal(t, map[string]bool{validators[1]: true, validators[2]: true, validators[3]: true}, selected)
+
+	// the new selector finds same result from the stored blocks
+	other := NewSkipMissedSelector(cm, st, 2)
+	for round := uint64(0); round < 6; round++ {
+		require.Equal(t, selector.Select(5, round), other.Select(5, round))
+	}
+
+	// without the block, the proposer can not be decided
+	require.Equal(t, "", selector.Select(6, 0))
+}
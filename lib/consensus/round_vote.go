@@ -12,12 +12,16 @@ type RoundVoteResult map[ /* Node.Address() */ string]voting.Hole
 type RoundVote struct {
 	SIGN   RoundVoteResult
 	ACCEPT RoundVoteResult
+
+	// BlockSignatures keeps the block signatures of YES ACCEPT ballots.
+	BlockSignatures map[ /* Node.Address() */ string]string
 }
 
 func NewRoundVote(ballot ballot.Ballot) (rv *RoundVote) {
 	rv = &RoundVote{
-		SIGN:   RoundVoteResult{},
-		ACCEPT: RoundVoteResult{},
+		SIGN:            RoundVoteResult{},
+		ACCEPT:          RoundVoteResult{},
+		BlockSignatures: map[string]string{},
 	}
 
 	rv.Vote(ballot)
@@ -47,6 +51,10 @@ func (rv *RoundVote) Vote(b ballot.Ballot) (isNew bool, err error) {
 		result[b.Source()] = b.Vote()
 	}
 
+	if b.State() == ballot.StateACCEPT && b.Vote() == voting.YES && len(b.BlockSignature()) > 0 {
+		rv.BlockSignatures[b.Source()] = b.BlockSignature()
+	}
+
 	return
 }
 
@@ -0,0 +1,200 @@
+package consensus
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"boscoin.io/sebak/lib/ballot"
+)
+
+type WALRecordType string
+
+const (
+	WALRecordBallot WALRecordType = "ballot"
+	WALRecordState  WALRecordType = "state"
+)
+
+// WALRecord is the record of `WAL`; it has the ballot, which the node signed,
+// or the `ISAACState`, which the node transited to.
+type WALRecord struct {
+	Type   WALRecordType  `json:"type"`
+	Ballot *ballot.Ballot `json:"ballot,omitempty"`
+	State  *ISAACState    `json:"state,omitempty"`
+}
+
+// Height returns the block height of the record.
+func (r WALRecord) Height() uint64 {
+	if r.Ballot != nil {
+		return r.Ballot.VotingBasis().Height
+	}
+	if r.State != nil {
+		return r.State.Height
+	}
+
+	return 0
+}
+
+// WAL is the write-ahead log of consensus. Every record is synced to the disk
+// before the ballot is broadcasted, so after the crash, the node knows which
+// ballots it already signed.
+type WAL struct {
+	sync.Mutex
+
+	path string
+	f    *os.File
+}
+
+func OpenWAL(path string) (w *WAL, err error) {
+	if err = repairWAL(path); err != nil {
+		return nil, err
+	}
+
+	w = &WAL{path: path}
+	if w.f, err = os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// repairWAL removes the partially written record at the end of file; the
+// new records will be appended after the last complete record.
+func repairWAL(path string) (err error) {
+	var b []byte
+	if b, err = ioutil.ReadFile(path); err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+
+	size := bytes.LastIndexByte(b, '\n') + 1
+	if size == len(b) {
+		return
+	}
+
+	return os.Truncate(path, int64(size))
+}
+
+func (w *WAL) Path() string {
+	return w.path
+}
+
+func (w *WAL) WriteBallot(b ballot.Ballot) error {
+	return w.write(WALRecord{Type: WALRecordBallot, Ballot: &b})
+}
+
+func (w *WAL) WriteState(state ISAACState) error {
+	return w.write(WALRecord{Type: WALRecordState, State: &state})
+}
+
+func (w *WAL) write(records ...WALRecord) (err error) {
+	w.Lock()
+	defer w.Unlock()
+
+	return writeWALRecords(w.f, records...)
+}
+
+func writeWALRecords(f *os.File, records ...WALRecord) (err error) {
+	for _, r := range records {
+		var b []byte
+		if b, err = json.Marshal(r); err != nil {
+			return
+		}
+		if _, err = f.Write(append(b, '\n')); err != nil {
+			return
+		}
+	}
+
+	return f.Sync()
+}
+
+// Records returns all the records in order. The broken record at the end,
+// which was written partially by the crash, is ignored.
+func (w *WAL) Records() (records []WALRecord, err error) {
+	w.Lock()
+	defer w.Unlock()
+
+	return w.records()
+}
+
+func (w *WAL) records() (records []WALRecord, err error) {
+	var f *os.File
+	if f, err = os.Open(w.path); err != nil {
+		return
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	for {
+		var line []byte
+		if line, err = reader.ReadBytes('\n'); err != nil {
+			// the last line without newline is not completely written
+			err = nil
+			break
+		}
+
+		var r WALRecord
+		if err = json.Unmarshal(line, &r); err != nil {
+			return
+		}
+		records = append(records, r)
+	}
+
+	return
+}
+
+// Truncate removes the records lower than or equal to `height`; the file is
+// replaced atomically by the remaining records.
+func (w *WAL) Truncate(height uint64) (err error) {
+	w.Lock()
+	defer w.Unlock()
+
+	var records []WALRecord
+	if records, err = w.records(); err != nil {
+		return
+	}
+
+	var remains []WALRecord
+	for _, r := range records {
+		if r.Height() > height {
+			remains = append(remains, r)
+		}
+	}
+	if len(remains) == len(records) {
+		return
+	}
+
+	// the new file is kept opened to be appended after renamed, so `w.f` is
+	// replaced only when the truncate is done; if failed, the records are
+	// appended to the old file.
+	tmp := w.path + ".tmp"
+	var f *os.File
+	if f, err = os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_APPEND|os.O_WRONLY, 0600); err != nil {
+		return
+	}
+	if err = writeWALRecords(f, remains...); err != nil {
+		f.Close()
+		return
+	}
+	if err = os.Rename(tmp, w.path); err != nil {
+		f.Close()
+		return
+	}
+
+	w.f.Close()
+	w.f = f
+
+	return
+}
+
+func (w *WAL) Close() error {
+	w.Lock()
+	defer w.Unlock()
+
+	return w.f.Close()
+}
@@ -0,0 +1,125 @@
+package consensus
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/ballot"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/voting"
+)
+
+func TestWAL(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sebak-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "consensus.wal")
+	w, err := OpenWAL(path)
+	require.NoError(t, err)
+
+	kp := keypair.Random()
+	networkID := common.NewTestConfig().NetworkID
+	newBallot := func(height uint64, state ballot.State) ballot.Ballot {
+		b := ballot.NewBallot(kp.Address(), kp.Address(), voting.Basis{Height: height}, []string{})
+		b.SetVote(state, voting.YES)
+		b.Sign(kp, networkID)
+		return *b
+	}
+
+	b1 := newBallot(1, ballot.StateSIGN)
+	b2 := newBallot(2, ballot.StateINIT)
+	require.NoError(t, w.WriteBallot(b1))
+	require.NoError(t, w.WriteState(ISAACState{Height: 1, Round: 2, BallotState: ballot.StateSIGN}))
+	require.NoError(t, w.WriteBallot(b2))
+	require.NoError(t, w.Close())
+
+	// reopened
+	w, err = OpenWAL(path)
+	require.NoError(t, err)
+	defer func() { w.Close() }()
+
+	records, err := w.Records()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(records))
+	require.Equal(t, WALRecordBallot, records[0].Type)
+	require.Equal(t, b1.GetHash(), records[0].Ballot.GetHash())
+	require.NoError(t, records[0].Ballot.VerifySource(networkID))
+	require.Equal(t, WALRecordState, records[1].Type)
+	require.Equal(t, ISAACState{Height: 1, Round: 2, BallotState: ballot.StateSIGN}, *records[1].State)
+	require.Equal(t, uint64(2), records[2].Height())
+
+	{ // the partially written record is ignored
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(`{"type":"ballot","ballot":{"H"`))
+		require.NoError(t, err)
+		f.Close()
+
+		records, err := w.Records()
+		require.NoError(t, err)
+		require.Equal(t, 3, len(records))
+
+		// removed when reopened
+		w.Close()
+		w, err = OpenWAL(path)
+		require.NoError(t, err)
+		require.NoError(t, w.WriteState(ISAACState{Height: 2, Round: 1, BallotState: ballot.StateINIT}))
+
+		records, err = w.Records()
+		require.NoError(t, err)
+		require.Equal(t, 4, len(records))
+	}
+
+	require.NoError(t, w.Truncate(1))
+	records, err = w.Records()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(records))
+	require.Equal(t, b2.GetHash(), records[0].Ballot.GetHash())
+
+	// written after truncated
+	require.NoError(t, w.WriteState(ISAACState{Height: 2, Round: 2, BallotState: ballot.StateINIT}))
+	records, err = w.Records()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(records))
+}
+
+// TestWALTruncateFailed checks the records are still written after the
+// truncate is failed.
+func TestWALTruncateFailed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sebak-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "consensus.wal")
+	w, err := OpenWAL(path)
+	require.NoError(t, err)
+	defer func() { w.Close() }()
+
+	require.NoError(t, w.WriteState(ISAACState{Height: 1, BallotState: ballot.StateINIT}))
+	require.NoError(t, w.WriteState(ISAACState{Height: 2, BallotState: ballot.StateINIT}))
+
+	// the temporary file can not be created
+	require.NoError(t, os.Mkdir(path+".tmp", 0700))
+	require.Error(t, w.Truncate(1))
+
+	require.NoError(t, w.WriteState(ISAACState{Height: 3, BallotState: ballot.StateINIT}))
+	records, err := w.Records()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(records))
+
+	require.NoError(t, os.Remove(path+".tmp"))
+	require.NoError(t, w.Truncate(1))
+
+	require.NoError(t, w.WriteState(ISAACState{Height: 4, BallotState: ballot.StateINIT}))
+	records, err = w.Records()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(records))
+	require.Equal(t, uint64(2), records[0].Height())
+	require.Equal(t, uint64(4), records[2].Height())
+}
@@ -112,4 +112,34 @@ var (
 	EndpointNotFound                          = NewError(194, "endpoint not found")
 	DiscoveryFromUnknownValidator             = NewError(195, "DiscoveryMessage from unknown validator")
 	DiscoveryPolicyDoesNotMatch               = NewError(196, "policy does not matched with discovery node")
+	TooManySigners                            = NewError(197, "too many signers in account")
+	InvalidThresholds                         = NewError(198, "invalid thresholds of signers")
+	DuplicatedSigner                          = NewError(199, "duplicated signer found")
+	NotEnoughSignatureWeight                  = NewError(200, "signatures does not reach the threshold of operations")
+	InvalidMemo                               = NewError(201, "invalid memo")
+	InvalidTimeBounds                         = NewError(202, "invalid time bounds")
+	TransactionNotValidYet                    = NewError(203, "transaction is not valid yet")
+	TransactionExpired                        = NewError(204, "transaction is expired")
+	AccountMergeFromFrozenAccount             = NewError(205, "frozen account can not be merged")
+	AccountMergeFromLinkedAccount             = NewError(206, "account linked by frozen accounts can not be merged")
+	AccountMergeNotLastOperation              = NewError(207, "account merge must be the last operation of transaction")
+	AccountMergedInBallot                     = NewError(208, "account is merged by the other transaction in ballot")
+	StateRootDoesNotMatch                     = NewError(209, "state root does not match")
+	StateRootNotFound                         = NewError(210, "state root is not found in block")
+	UnknownProposerSelector                   = NewError(211, "unknown proposer selector")
+	BallotEquivocation                        = NewError(212, "validator signed the different ballots for same round")
+	InvalidEvidence                           = NewError(213, "invalid evidence")
+	CommitCertificateNotFound                 = NewError(214, "commit certificate is not found")
+	InvalidCommitCertificate                  = NewError(215, "invalid commit certificate")
+	InvalidAccountProof                       = NewError(216, "account proof is not verified")
+	ValidatorUpdateHeightNotFuture            = NewError(217, "validator update must be scheduled at the future height")
+	TransactionReplacementUnderpriced         = NewError(218, "replacement transaction must have the higher fee")
+	TransactionNotFoundInPool                 = NewError(219, "transaction not found in pool")
+	DelegationFromInvalidAccount              = NewError(220, "delegation must be requested by frozen account")
+	DelegationAlreadyExists                   = NewError(221, "stake is already delegated to the validator")
+	BlockAccountMerged                        = NewError(222, "merged account can not be created again")
+	ProposerNotDecided                        = NewError(223, "proposer can not be decided")
+	DelegationToNonValidator                  = NewError(224, "stake can be delegated only to the validator")
+	DelegatorsLimitExceeded                   = NewError(225, "validator has too many delegators")
+	NotAvailableInLightMode                   = NewError(226, "not available in light mode")
 )
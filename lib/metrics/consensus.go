@@ -20,6 +20,8 @@ type ConsensusMetrics struct {
 
 	Validators        metrics.Gauge
 	MissingValidators metrics.Gauge
+
+	Equivocations metrics.Counter
 }
 
 func (c *ConsensusMetrics) SetBlockIntervalSeconds(t time.Time) time.Time {
@@ -47,6 +49,10 @@ func (c *ConsensusMetrics) SetMissingValidators(num int) {
 	c.MissingValidators.Set(float64(num))
 }
 
+func (c *ConsensusMetrics) AddEquivocation(validator string) {
+	c.Equivocations.With("validator", validator).Add(1)
+}
+
 func PromConsensusMetrics() *ConsensusMetrics {
 	return &ConsensusMetrics{
 		Height: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
@@ -91,6 +97,12 @@ func PromConsensusMetrics() *ConsensusMetrics {
 			Name:      "missing_validators",
 			Help:      "Number of missing validators.",
 		}, []string{}),
+		Equivocations: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: ConsensusSubsystem,
+			Name:      "equivocations_total",
+			Help:      "Total number of equivocations by validator.",
+		}, []string{"validator"}),
 	}
 }
 
@@ -106,5 +118,7 @@ func NopConsensusMetrics() *ConsensusMetrics {
 
 		Validators:        discard.NewGauge(),
 		MissingValidators: discard.NewGauge(),
+
+		Equivocations: discard.NewCounter(),
 	}
 }
@@ -14,3 +14,10 @@ const (
 	SyncValidator = "validator"
 	SyncAll       = "all"
 )
+
+const (
+	TxPoolEvictedReason      = "reason"
+	TxPoolEvictedReplaced    = "replaced"    // replaced by the higher fee
+	TxPoolEvictedUnderpriced = "underpriced" // evicted by the higher fee when pool is full
+	TxPoolEvictedExpired     = "expired"     // expired by `TimeBounds`
+)
@@ -8,13 +8,21 @@ import (
 )
 
 type TxPoolMetrics struct {
-	Size metrics.Gauge
+	Size    metrics.Gauge
+	Evicted metrics.Gauge
 }
 
 func (m *TxPoolMetrics) AddSize(delta int) {
 	m.Size.Add(float64(delta))
 }
 
+func (m *TxPoolMetrics) AddEvicted(reason string, delta int) {
+	if delta < 1 {
+		return
+	}
+	m.Evicted.With(TxPoolEvictedReason, reason).Add(float64(delta))
+}
+
 func PromTxPoolMetrics() *TxPoolMetrics {
 	return &TxPoolMetrics{
 		Size: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
@@ -23,11 +31,18 @@ func PromTxPoolMetrics() *TxPoolMetrics {
 			Name:      "size",
 			Help:      "Size of txpool.",
 		}, []string{}),
+		Evicted: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: Namespace,
+			Subsystem: TxPoolSubsystem,
+			Name:      "evicted",
+			Help:      "Number of transactions evicted from txpool.",
+		}, []string{TxPoolEvictedReason}),
 	}
 }
 
 func NopTxPoolMetrics() *TxPoolMetrics {
 	return &TxPoolMetrics{
-		Size: discard.NewGauge(),
+		Size:    discard.NewGauge(),
+		Evicted: discard.NewGauge(),
 	}
 }
@@ -2,6 +2,7 @@ package network
 
 import (
 	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/node"
 )
 
 type ConnectionManager interface {
@@ -14,3 +15,10 @@ type ConnectionManager interface {
 	IsReady() bool
 	Discovery(DiscoveryMessage) error
 }
+
+// ValidatorUpdater is implemented by the `ConnectionManager`, which follows
+// the changes of validators at runtime; `ValidatorsUpdated` is called after
+// the validators of `node.LocalNode` are updated.
+type ValidatorUpdater interface {
+	ValidatorsUpdated(added []*node.Validator, removed []string)
+}
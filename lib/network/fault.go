@@ -0,0 +1,307 @@
+package network
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network/httputils"
+)
+
+const FaultsHandlerPattern = "/faults"
+
+// FaultRule describes the faults of the outgoing messages. The empty `Type`
+// matches every message type and the empty `Peer` matches every peer; `Peer`
+// is compared with the endpoint and the host of endpoint. `Drop`, `Duplicate`
+// and `Corrupt` are the probabilities, 0 to 1. In JSON, `Delay` is the
+// duration string, like "100ms".
+type FaultRule struct {
+	Type      common.MessageType `json:"type,omitempty"`
+	Peer      string             `json:"peer,omitempty"`
+	Drop      float64            `json:"drop,omitempty"`
+	Delay     time.Duration      `json:"-"`
+	Duplicate float64            `json:"duplicate,omitempty"`
+	Corrupt   float64            `json:"corrupt,omitempty"`
+}
+
+type faultRuleAlias FaultRule
+
+type faultRuleJSON struct {
+	faultRuleAlias
+	Delay string `json:"delay,omitempty"`
+}
+
+func (r FaultRule) MarshalJSON() ([]byte, error) {
+	j := faultRuleJSON{faultRuleAlias: faultRuleAlias(r)}
+	if r.Delay != 0 {
+		j.Delay = r.Delay.String()
+	}
+
+	return json.Marshal(j)
+}
+
+func (r *FaultRule) UnmarshalJSON(b []byte) (err error) {
+	var j faultRuleJSON
+	if err = json.Unmarshal(b, &j); err != nil {
+		return
+	}
+
+	*r = FaultRule(j.faultRuleAlias)
+	if len(j.Delay) > 0 {
+		if r.Delay, err = time.ParseDuration(j.Delay); err != nil {
+			return errors.BadRequestParameter.Clone().SetData("delay", j.Delay)
+		}
+	}
+
+	return
+}
+
+func (r FaultRule) IsWellFormed() error {
+	switch r.Type {
+	case "", common.BallotMessage, common.TransactionMessage, common.DiscoveryMessage:
+	default:
+		return errors.BadRequestParameter.Clone().SetData("type", r.Type)
+	}
+
+	for _, p := range []float64{r.Drop, r.Duplicate, r.Corrupt} {
+		if p < 0 || p > 1 {
+			return errors.BadRequestParameter.Clone().SetData("probability", p)
+		}
+	}
+	if r.Delay < 0 {
+		return errors.BadRequestParameter.Clone().SetData("delay", r.Delay)
+	}
+
+	return nil
+}
+
+func (r FaultRule) match(t common.MessageType, endpoint *common.Endpoint) bool {
+	if len(r.Type) > 0 && r.Type != t {
+		return false
+	}
+	if len(r.Peer) > 0 && r.Peer != endpoint.String() && r.Peer != endpoint.Host {
+		return false
+	}
+
+	return true
+}
+
+// faultAction is the decision for one message by the matched `FaultRule`s.
+type faultAction struct {
+	drop      bool
+	delay     time.Duration
+	duplicate bool
+	corrupt   bool
+}
+
+// FaultInjector keeps the `FaultRule`s, which can be changed at runtime.
+type FaultInjector struct {
+	sync.RWMutex
+
+	rules []FaultRule
+	rand  *rand.Rand
+}
+
+func NewFaultInjector(seed int64) *FaultInjector {
+	return &FaultInjector{
+		rules: []FaultRule{},
+		rand:  rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (f *FaultInjector) Rules() []FaultRule {
+	f.RLock()
+	defer f.RUnlock()
+
+	rules := make([]FaultRule, len(f.rules))
+	copy(rules, f.rules)
+	return rules
+}
+
+// SetRules replaces the rules; the empty rules remove all the faults.
+func (f *FaultInjector) SetRules(rules ...FaultRule) error {
+	for _, r := range rules {
+		if err := r.IsWellFormed(); err != nil {
+			return err
+		}
+	}
+
+	f.Lock()
+	defer f.Unlock()
+	f.rules = append([]FaultRule{}, rules...)
+
+	return nil
+}
+
+// decide applies every matched rule to the message.
+func (f *FaultInjector) decide(t common.MessageType, endpoint *common.Endpoint) (action faultAction) {
+	f.Lock()
+	defer f.Unlock()
+
+	for _, r := range f.rules {
+		if !r.match(t, endpoint) {
+			continue
+		}
+		if r.Drop > 0 && f.rand.Float64() < r.Drop {
+			return faultAction{drop: true}
+		}
+		if r.Delay > action.delay {
+			action.delay = r.Delay
+		}
+		if r.Duplicate > 0 && f.rand.Float64() < r.Duplicate {
+			action.duplicate = true
+		}
+		if r.Corrupt > 0 && f.rand.Float64() < r.Corrupt {
+			action.corrupt = true
+		}
+	}
+
+	return
+}
+
+// corrupt changes one letter or digit in the string value of json, so the
+// message is still valid json, but the hash or signature does not match.
+func (f *FaultInjector) corrupt(message interface{}) (interface{}, error) {
+	b, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []int
+	var inString, escaped bool
+	for i, c := range b {
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case inString && isAlphaNumeric(c):
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) < 1 {
+		return json.RawMessage(b), nil
+	}
+
+	f.Lock()
+	i := candidates[f.rand.Intn(len(candidates))]
+	f.Unlock()
+
+	if b[i] == 'a' {
+		b[i] = 'b'
+	} else {
+		b[i] = 'a'
+	}
+
+	return json.RawMessage(b), nil
+}
+
+func isAlphaNumeric(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// FaultsHandler shows the rules by `GET`, replaces the rules by `POST` with
+// the list of `FaultRule` and removes the rules by `DELETE`.
+func (f *FaultInjector) FaultsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "POST":
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			httputils.WriteJSONError(w, errors.BadRequestParameter.Clone().SetData("error", err.Error()))
+			return
+		}
+
+		var rules []FaultRule
+		if err = json.Unmarshal(body, &rules); err != nil {
+			httputils.WriteJSONError(w, errors.BadRequestParameter.Clone().SetData("error", err.Error()))
+			return
+		}
+		if err = f.SetRules(rules...); err != nil {
+			httputils.WriteJSONError(w, err)
+			return
+		}
+	case "DELETE":
+		f.SetRules()
+	}
+
+	httputils.MustWriteJSON(w, http.StatusOK, f.Rules())
+}
+
+// FaultNetwork wraps the `Network`; the messages sent by the clients of
+// `GetClient` can be dropped, delayed, duplicated or corrupted by the rules of
+// `FaultInjector`.
+type FaultNetwork struct {
+	Network
+
+	injector *FaultInjector
+}
+
+func NewFaultNetwork(n Network, injector *FaultInjector) *FaultNetwork {
+	return &FaultNetwork{Network: n, injector: injector}
+}
+
+func (n *FaultNetwork) Injector() *FaultInjector {
+	return n.injector
+}
+
+func (n *FaultNetwork) GetClient(endpoint *common.Endpoint) NetworkClient {
+	client := n.Network.GetClient(endpoint)
+	if client == nil {
+		return nil
+	}
+
+	return &FaultNetworkClient{NetworkClient: client, injector: n.injector}
+}
+
+type FaultNetworkClient struct {
+	NetworkClient
+
+	injector *FaultInjector
+}
+
+func (c *FaultNetworkClient) send(
+	t common.MessageType,
+	message interface{},
+	sendFunc func(interface{}) ([]byte, error),
+) (body []byte, err error) {
+	action := c.injector.decide(t, c.Endpoint())
+	if action.drop {
+		return
+	}
+	if action.delay > 0 {
+		time.Sleep(action.delay)
+	}
+	if action.corrupt {
+		if message, err = c.injector.corrupt(message); err != nil {
+			return
+		}
+	}
+	if action.duplicate {
+		sendFunc(message)
+	}
+
+	return sendFunc(message)
+}
+
+func (c *FaultNetworkClient) SendMessage(message interface{}) ([]byte, error) {
+	return c.send(common.TransactionMessage, message, c.NetworkClient.SendMessage)
+}
+
+func (c *FaultNetworkClient) SendTransaction(message interface{}) ([]byte, error) {
+	return c.send(common.TransactionMessage, message, c.NetworkClient.SendTransaction)
+}
+
+func (c *FaultNetworkClient) SendBallot(message interface{}) ([]byte, error) {
+	return c.send(common.BallotMessage, message, c.NetworkClient.SendBallot)
+}
+
+func (c *FaultNetworkClient) SendDiscovery(message interface{}) ([]byte, error) {
+	return c.send(common.DiscoveryMessage, message, c.NetworkClient.SendDiscovery)
+}
@@ -0,0 +1,173 @@
+package network
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+)
+
+func TestFaultNetwork(t *testing.T) {
+	_, s0, _ := CreateMemoryNetwork(nil)
+	_, s1, _ := CreateMemoryNetwork(s0)
+
+	received := make(chan common.NetworkMessage, 10)
+	go func() {
+		for message := range s1.ReceiveMessage() {
+			received <- message
+		}
+	}()
+	go s1.Start()
+
+	receive := func() (messages []common.NetworkMessage) {
+		for {
+			select {
+			case m := <-received:
+				messages = append(messages, m)
+			case <-time.After(200 * time.Millisecond):
+				return
+			}
+		}
+	}
+
+	injector := NewFaultInjector(1)
+	fn := NewFaultNetwork(s0, injector)
+	client := fn.GetClient(s1.Endpoint())
+	message := NewDummyMessage("findme")
+
+	{ // drop ballot to s1; transaction is not dropped
+		require.NoError(t, injector.SetRules(FaultRule{Type: common.BallotMessage, Peer: s1.Endpoint().String(), Drop: 1}))
+
+		_, err := client.SendBallot(message)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(receive()))
+
+		_, err = client.SendMessage(message)
+		require.NoError(t, err)
+		messages := receive()
+		require.Equal(t, 1, len(messages))
+		require.Equal(t, common.TransactionMessage, messages[0].Type)
+	}
+
+	{ // the rule for the other peer is not applied
+		require.NoError(t, injector.SetRules(FaultRule{Peer: s0.Endpoint().Host, Drop: 1}))
+
+		client.SendBallot(message)
+		require.Equal(t, 1, len(receive()))
+	}
+
+	{ // duplicate
+		require.NoError(t, injector.SetRules(FaultRule{Type: common.BallotMessage, Duplicate: 1}))
+
+		client.SendBallot(message)
+		require.Equal(t, 2, len(receive()))
+	}
+
+	{ // delay
+		require.NoError(t, injector.SetRules(FaultRule{Delay: 300 * time.Millisecond}))
+
+		done := make(chan struct{})
+		go func() {
+			client.SendDiscovery(message)
+			close(done)
+		}()
+		require.Equal(t, 0, len(receive()))
+		<-done
+		require.Equal(t, 1, len(receive()))
+	}
+
+	{ // corrupt
+		require.NoError(t, injector.SetRules(FaultRule{Corrupt: 1}))
+
+		client.SendBallot(message)
+		messages := receive()
+		require.Equal(t, 1, len(messages))
+
+		corrupted, err := DummyMessageFromString(messages[0].Data)
+		require.NoError(t, err)
+		require.False(t, message.Equal(corrupted))
+	}
+
+	{ // no rules
+		require.NoError(t, injector.SetRules())
+
+		client.SendBallot(message)
+		messages := receive()
+		require.Equal(t, 1, len(messages))
+
+		sent, err := DummyMessageFromString(messages[0].Data)
+		require.NoError(t, err)
+		require.True(t, message.Equal(sent))
+	}
+
+	{ // invalid rules
+		require.Error(t, injector.SetRules(FaultRule{Type: common.ConnectMessage}))
+		require.Error(t, injector.SetRules(FaultRule{Drop: 1.5}))
+		require.Error(t, injector.SetRules(FaultRule{Delay: -1}))
+		require.Equal(t, 0, len(injector.Rules()))
+	}
+}
+
+func TestFaultsHandler(t *testing.T) {
+	injector := NewFaultInjector(1)
+	server := httptest.NewServer(http.HandlerFunc(injector.FaultsHandler))
+	defer server.Close()
+
+	request := func(method string, body []byte) (*http.Response, []FaultRule) {
+		req, err := http.NewRequest(method, server.URL, bytes.NewReader(body))
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var rules []FaultRule
+		json.NewDecoder(resp.Body).Decode(&rules)
+		return resp, rules
+	}
+
+	rules := []FaultRule{
+		{Type: common.BallotMessage, Peer: "localhost:12345", Drop: 0.5},
+		{Delay: time.Second, Duplicate: 0.1},
+	}
+	body, _ := json.Marshal(rules)
+
+	resp, got := request("POST", body)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, rules, got)
+	require.Equal(t, rules, injector.Rules())
+
+	resp, got = request("GET", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, rules, got)
+
+	// invalid rule is not applied
+	resp, _ = request("POST", []byte(`[{"corrupt": 2}]`))
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	require.Equal(t, rules, injector.Rules())
+
+	resp, _ = request("POST", []byte(`{`))
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// delay is the duration string
+	resp, got = request("POST", []byte(`[{"delay": "100ms"}]`))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, []FaultRule{{Delay: 100 * time.Millisecond}}, got)
+	require.Equal(t, []FaultRule{{Delay: 100 * time.Millisecond}}, injector.Rules())
+
+	resp, _ = request("POST", []byte(`[{"delay": 100}]`))
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp, _ = request("POST", []byte(`[{"delay": "100"}]`))
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	require.Equal(t, []FaultRule{{Delay: 100 * time.Millisecond}}, injector.Rules())
+
+	resp, got = request("DELETE", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, 0, len(got))
+	require.Equal(t, 0, len(injector.Rules()))
+}
@@ -23,8 +23,12 @@ var (
 		errors.TooManyRequests.Code:               http.StatusTooManyRequests,
 		errors.BlockTransactionDoesNotExists.Code: http.StatusNotFound,
 		errors.BlockAccountDoesNotExists.Code:     http.StatusNotFound,
+		errors.StateRootNotFound.Code:             http.StatusNotFound,
+		errors.CommitCertificateNotFound.Code:     http.StatusNotFound,
+		errors.TransactionNotFoundInPool.Code:     http.StatusNotFound,
 		errors.TransactionPoolFull.Code:           http.StatusLocked,
 		errors.BadRequestParameter.Code:           http.StatusBadRequest,
+		errors.NotAvailableInLightMode.Code:       http.StatusNotImplemented,
 	}
 )
 
@@ -24,6 +24,7 @@ type ValidatorConnectionManager struct {
 	connected        map[ /* node.Address() */ string]bool
 	config           common.Config
 	discoveryChannel chan DiscoveryMessage
+	started          bool
 
 	log logging.Logger
 }
@@ -89,6 +90,10 @@ func (c *ValidatorConnectionManager) Start() {
 		c.startDiscovery()
 	}
 
+	c.Lock()
+	c.started = true
+	c.Unlock()
+
 	c.log.Debug("starting to connect to validators", "validators", c.localNode.GetValidators())
 	for _, v := range c.localNode.GetValidators() {
 		if v.Address() == c.localNode.Address() {
@@ -105,6 +110,10 @@ func (c *ValidatorConnectionManager) setConnected(v *node.Validator, connected b
 	c.Lock()
 	defer c.Unlock()
 
+	if c.localNode.Validator(v.Address()) != v {
+		return false
+	}
+
 	old, found := c.connected[v.Address()]
 	c.connected[v.Address()] = connected
 
@@ -156,9 +165,40 @@ func (c *ValidatorConnectionManager) countConnectedUnlocked() int {
 	return count
 }
 
+// ValidatorsUpdated starts to connect to the added validators and forgets the
+// removed validators.
+func (c *ValidatorConnectionManager) ValidatorsUpdated(added []*node.Validator, removed []string) {
+	c.Lock()
+	defer c.Unlock()
+
+	for _, address := range removed {
+		delete(c.connected, address)
+	}
+	c.connected[c.localNode.Address()] = true
+	c.policy.SetConnected(c.countConnectedUnlocked())
+
+	metrics.Consensus.SetValidators(len(c.localNode.GetValidators()))
+
+	if !c.started {
+		return
+	}
+	for _, v := range added {
+		if v.Address() == c.localNode.Address() {
+			continue
+		}
+		go c.connectingValidator(v)
+	}
+}
+
 func (c *ValidatorConnectionManager) connectingValidator(v *node.Validator) {
 	ticker := time.NewTicker(time.Second * 1)
+	defer ticker.Stop()
+
 	for _ = range ticker.C {
+		// the validator is removed or replaced by `ValidatorsUpdated`
+		if c.localNode.Validator(v.Address()) != v {
+			return
+		}
 		if v.Endpoint() == nil {
 			continue
 		}
@@ -257,6 +297,7 @@ func (c *ValidatorConnectionManager) watchForMetrics() {
 
 	ticker := time.NewTicker(time.Second * 60)
 	for _ = range ticker.C {
+		numValidators = len(c.localNode.GetValidators())
 		numConnected := c.CountConnected()
 		metrics.Consensus.SetMissingValidators(numValidators - numConnected)
 	}
@@ -24,25 +24,30 @@ type NodeInfoNode struct {
 }
 
 type NodePolicy struct {
-	NetworkID                 string        `json:"network-id"`      // network id
-	InitialBalance            common.Amount `json:"initial-balance"` // initial balance of genesis account
-	BaseReserve               common.Amount `json:"base-reserve"`    // base reserve for one account
-	BaseFee                   common.Amount `json:"base-fee"`        // base fee of operation
-	BlockTime                 time.Duration `json:"block-time"`      // block creation time
-	BlockTimeDelta            time.Duration `json:"block-time-delta"`
-	TimeoutINIT               time.Duration `json:"timeout-init"`
-	TimeoutSIGN               time.Duration `json:"timeout-sign"`
-	TimeoutACCEPT             time.Duration `json:"timeout-accept"`
-	TimeoutALLCONFIRM         time.Duration `json:"timeout-allconfirm"`
-	RateLimitRuleAPI          string        `json:"rate-limit-api"`
-	RateLimitRuleNode         string        `json:"rate-limit-node"`
-	TransactionsLimit         int           `json:"transactions-limit"`            // transactions limit in a ballot
-	OperationsLimit           int           `json:"operations-limit"`              // operations limit in a transaction
-	OperationsInBallotLimit   int           `json:"operations-in-ballot-limit"`    // operations limit in a ballot
-	GenesisBlockConfirmedTime string        `json:"genesis-block-confirmed-time"`  // confirmed time of genesis block; see `common.GenesisBlockConfirmedTime`
-	InflationRatio            string        `json:"inflation-ratio"`               // inflation ratio; see `common.InflationRatio`
-	UnfreezingPeriod          uint64        `json:"unfreezing-period"`             // unfreezing period
-	BlockHeightEndOfInflation uint64        `json:"block-height-end-of-inflation"` // block height of inflation end; see `common.BlockHeightEndOfInflation`
+	NetworkID                 string            `json:"network-id"`      // network id
+	InitialBalance            common.Amount     `json:"initial-balance"` // initial balance of genesis account
+	BaseReserve               common.Amount     `json:"base-reserve"`    // base reserve for one account
+	BaseFee                   common.Amount     `json:"base-fee"`        // base fee of operation
+	BlockTime                 time.Duration     `json:"block-time"`      // block creation time
+	BlockTimeDelta            time.Duration     `json:"block-time-delta"`
+	TimeoutINIT               time.Duration     `json:"timeout-init"`
+	TimeoutSIGN               time.Duration     `json:"timeout-sign"`
+	TimeoutACCEPT             time.Duration     `json:"timeout-accept"`
+	TimeoutALLCONFIRM         time.Duration     `json:"timeout-allconfirm"`
+	RateLimitRuleAPI          string            `json:"rate-limit-api"`
+	RateLimitRuleNode         string            `json:"rate-limit-node"`
+	TransactionsLimit         int               `json:"transactions-limit"`            // transactions limit in a ballot
+	OperationsLimit           int               `json:"operations-limit"`              // operations limit in a transaction
+	OperationsInBallotLimit   int               `json:"operations-in-ballot-limit"`    // operations limit in a ballot
+	GenesisBlockConfirmedTime string            `json:"genesis-block-confirmed-time"`  // confirmed time of genesis block; see `common.GenesisBlockConfirmedTime`
+	InflationRatio            string            `json:"inflation-ratio"`               // inflation ratio; see `common.InflationRatio`
+	UnfreezingPeriod          uint64            `json:"unfreezing-period"`             // unfreezing period
+	BlockHeightEndOfInflation uint64            `json:"block-height-end-of-inflation"` // block height of inflation end; see `common.BlockHeightEndOfInflation`
+	ProposerSelector          string            `json:"proposer-selector"`             // proposer selector; see `common.ProposerSelectorNames`
+	ValidatorWeights          map[string]uint64 `json:"validator-weights,omitempty"`   // weights of validators for weighted proposer selector
+	TxFeeValidatorShare       uint64            `json:"tx-fee-validator-share"`        // percentage of collected transaction fee for validators
+	TxFeeDistribution         string            `json:"tx-fee-distribution"`           // receivers of validator share; see `common.TxFeeDistributionNames`
+	InflationToDelegators     bool              `json:"inflation-to-delegators"`       // inflation is routed to delegators
 }
 
 type NodeBlockInfo struct {
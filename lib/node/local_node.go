@@ -143,17 +143,42 @@ func (n *LocalNode) Validator(address string) *Validator {
 	return v
 }
 
+// AddValidators adds or replaces the validators. The validators map is
+// copied on update, so the map from `GetValidators()` is safe to iterate
+// while the validators are updated.
 func (n *LocalNode) AddValidators(validators ...*Validator) error {
 	n.Lock()
 	defer n.Unlock()
 
+	updated := n.copyValidators()
 	for _, va := range validators {
-		n.validators[va.Address()] = va
+		updated[va.Address()] = va
 	}
+	n.validators = updated
 
 	return nil
 }
 
+func (n *LocalNode) RemoveValidators(addresses ...string) {
+	n.Lock()
+	defer n.Unlock()
+
+	updated := n.copyValidators()
+	for _, address := range addresses {
+		delete(updated, address)
+	}
+	n.validators = updated
+}
+
+func (n *LocalNode) copyValidators() map[string]*Validator {
+	copied := map[string]*Validator{}
+	for address, v := range n.validators {
+		copied[address] = v
+	}
+
+	return copied
+}
+
 func (n *LocalNode) ClearValidators() {
 	n.Lock()
 	defer n.Unlock()
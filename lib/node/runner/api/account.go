@@ -4,14 +4,19 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/gorilla/mux"
 
 	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/client"
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/network/httputils"
 	"boscoin.io/sebak/lib/node/runner/api/resource"
+	"boscoin.io/sebak/lib/storage/statedb"
+	"boscoin.io/sebak/lib/storage/statedb/trie"
 	"boscoin.io/sebak/lib/transaction/operation"
 )
 
@@ -19,7 +24,25 @@ func (api NetworkHandlerAPI) GetAccountHandler(w http.ResponseWriter, r *http.Re
 	vars := mux.Vars(r)
 	address := vars["id"]
 
+	height, err := parseHeightQuery(r)
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
 	readFunc := func() (payload interface{}, err error) {
+		if api.GetAccountProof != nil {
+			return api.getAccountFromProof(address, height)
+		}
+
+		if height > 0 {
+			ba, err := block.GetBlockAccountAtHeight(api.storage, address, height)
+			if err != nil {
+				return nil, err
+			}
+			return resource.NewAccount(ba), nil
+		}
+
 		found, err := block.ExistsBlockAccount(api.storage, address)
 		if err != nil {
 			return nil, err
@@ -44,6 +67,129 @@ func (api NetworkHandlerAPI) GetAccountHandler(w http.ResponseWriter, r *http.Re
 	httputils.MustWriteJSON(w, 200, payload)
 }
 
+// getAccountFromProof returns the account from the proof of the other node.
+// The proof is verified with the state root of the local block, which was
+// already verified by it's commit certificate.
+func (api NetworkHandlerAPI) getAccountFromProof(address string, height uint64) (payload interface{}, err error) {
+	var blk block.Block
+	if height < 1 {
+		blk = block.GetLatestBlock(api.storage)
+	} else if blk, err = block.GetBlockByHeight(api.storage, height); err != nil {
+		return nil, err
+	}
+	if len(blk.StateRoot) < 1 {
+		return nil, errors.StateRootNotFound
+	}
+
+	var proof client.AccountProof
+	if proof, err = api.GetAccountProof(address, blk.Height); err != nil {
+		return nil, err
+	}
+
+	if proof.Block != blk.Hash || proof.Header.StateRoot != blk.StateRoot {
+		return nil, errors.InvalidAccountProof
+	}
+	if !client.VerifyAccountProof(address, proof) {
+		return nil, errors.InvalidAccountProof
+	}
+
+	return resource.NewAccount(&proof.Account), nil
+}
+
+// GetAccountProofHandler returns the account and it's proof in the state trie
+// of the block at `height`; without `height`, the latest block is used.
+func (api NetworkHandlerAPI) GetAccountProofHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	address := vars["id"]
+
+	height, err := parseHeightQuery(r)
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	readFunc := func() (payload interface{}, err error) {
+		var blk block.Block
+		if height < 1 {
+			blk = block.GetLatestBlock(api.storage)
+		} else if blk, err = block.GetBlockByHeight(api.storage, height); err != nil {
+			return nil, err
+		}
+		if len(blk.StateRoot) < 1 {
+			return nil, errors.StateRootNotFound
+		}
+
+		root := common.BytesToHash(base58.Decode(blk.StateRoot))
+		sdb := statedb.New(root, trie.NewEthDatabase(api.storage))
+		ba, proof, err := sdb.GetAccountProof(address)
+		if err != nil {
+			return nil, err
+		}
+		payload = resource.NewAccountProof(ba, &blk, proof)
+		return payload, nil
+	}
+
+	payload, err := readFunc()
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	httputils.MustWriteJSON(w, 200, payload)
+}
+
+// GetAccountHistoryHandler returns the states of account by block height.
+func (api NetworkHandlerAPI) GetAccountHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	address := vars["id"]
+
+	p, err := NewPageQuery(r)
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	var options = p.ListOptions()
+	var firstCursor []byte
+	var cursor []byte
+
+	readFunc := func() []resource.Resource {
+		var rs []resource.Resource
+		iterFunc, closeFunc := block.GetBlockAccountHistories(api.storage, address, options)
+		for {
+			history, hasNext, c := iterFunc()
+			if !hasNext {
+				break
+			}
+			cursor = append([]byte{}, c...)
+			if len(firstCursor) == 0 {
+				firstCursor = append(firstCursor, c...)
+			}
+			rs = append(rs, resource.NewAccountHistory(history))
+		}
+		closeFunc()
+		return rs
+	}
+
+	rs := readFunc()
+	list := p.ResourceList(rs, firstCursor, cursor)
+	httputils.MustWriteJSON(w, 200, list)
+}
+
+// parseHeightQuery parses the `height` query string; without `height`, it
+// returns 0.
+func parseHeightQuery(r *http.Request) (height uint64, err error) {
+	value := r.URL.Query().Get("height")
+	if len(value) < 1 {
+		return
+	}
+
+	if height, err = strconv.ParseUint(value, 10, 64); err != nil {
+		err = errors.InvalidQueryString
+	}
+	return
+}
+
 func (api NetworkHandlerAPI) GetAccountsHandler(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 
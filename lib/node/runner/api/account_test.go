@@ -4,15 +4,24 @@ import (
 	"bufio"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/gorilla/mux"
+
 	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/client"
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/common/keypair"
 	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/network/httputils"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/storage/statedb"
+	"boscoin.io/sebak/lib/storage/statedb/trie"
+	"boscoin.io/sebak/lib/voting"
 
 	"github.com/stretchr/testify/require"
 )
@@ -208,3 +217,244 @@ func TestGetAccountsHandler(t *testing.T) {
 	}
 
 }
+
+func TestGetAccountProofHandler(t *testing.T) {
+	ts, storage := prepareAPIServer()
+	defer storage.Close()
+	defer ts.Close()
+
+	ba := block.TestMakeBlockAccount()
+	ba.MustSave(storage)
+
+	// genesis block does not have state root
+	{
+		url := strings.Replace(GetAccountProofHandlerPattern, "{id}", ba.Address, -1)
+		req, _ := http.NewRequest("GET", ts.URL+url, nil)
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+
+	sdb := statedb.New(common.Hash{}, trie.NewEthDatabase(storage))
+	sdb.SetAccount(*ba)
+	root, err := sdb.CommitTrie()
+	require.NoError(t, err)
+	require.NoError(t, sdb.CommitDB(root))
+
+	latest := block.GetLatestBlock(storage)
+	theBlock := block.NewBlock(
+		keypair.Random().Address(),
+		voting.Basis{Height: latest.Height + 1, BlockHash: latest.Hash},
+		"",
+		[]string{},
+		base58.Encode(root.Bytes()),
+		common.NowISO8601(),
+	)
+	theBlock.MustSave(storage)
+
+	{
+		url := strings.Replace(GetAccountProofHandlerPattern, "{id}", ba.Address, -1)
+		url += "?height=" + strconv.FormatUint(theBlock.Height, 10)
+		respBody := request(ts, url, false)
+		defer respBody.Close()
+		readByte, err := ioutil.ReadAll(bufio.NewReader(respBody))
+		require.NoError(t, err)
+
+		var proof client.AccountProof
+		common.MustUnmarshalJSON(readByte, &proof)
+		require.Equal(t, theBlock.Hash, proof.Block)
+		require.Equal(t, theBlock.Header, proof.Header)
+		require.Equal(t, ba.Balance, proof.Account.Balance)
+		require.True(t, client.VerifyAccountProof(ba.Address, proof))
+
+		// modified account
+		proof.Account.Balance = proof.Account.Balance.MustAdd(1)
+		require.False(t, client.VerifyAccountProof(ba.Address, proof))
+
+		// forged header; the account and proof are valid in the forged state
+		// trie, but the header does not belong to the block
+		forgedAccount := *ba
+		forgedAccount.Balance = forgedAccount.Balance.MustAdd(1)
+		forgedDB := statedb.New(common.Hash{}, trie.NewEthDatabase(storage))
+		forgedDB.SetAccount(forgedAccount)
+		forgedRoot, err := forgedDB.CommitTrie()
+		require.NoError(t, err)
+		require.NoError(t, forgedDB.CommitDB(forgedRoot))
+		forgedBA, forgedProof, err := forgedDB.GetAccountProof(ba.Address)
+		require.NoError(t, err)
+
+		forged := proof
+		forged.Account = *forgedBA
+		forged.Header.StateRoot = base58.Encode(forgedRoot.Bytes())
+		forged.Proof = forgedProof
+		require.False(t, client.VerifyAccountProof(ba.Address, forged))
+	}
+
+	{ // unknown address
+		url := strings.Replace(GetAccountProofHandlerPattern, "{id}", keypair.Random().Address(), -1)
+		req, _ := http.NewRequest("GET", ts.URL+url, nil)
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+}
+
+// TestGetAccountHandlerLightMode checks the account is served from the
+// verified proof of the other node in light mode.
+func TestGetAccountHandlerLightMode(t *testing.T) {
+	ts, st := prepareAPIServer()
+	defer st.Close()
+	defer ts.Close()
+
+	ba := block.TestMakeBlockAccount()
+	ba.MustSave(st)
+
+	sdb := statedb.New(common.Hash{}, trie.NewEthDatabase(st))
+	sdb.SetAccount(*ba)
+	root, err := sdb.CommitTrie()
+	require.NoError(t, err)
+	require.NoError(t, sdb.CommitDB(root))
+
+	latest := block.GetLatestBlock(st)
+	theBlock := block.NewBlock(
+		keypair.Random().Address(),
+		voting.Basis{Height: latest.Height + 1, BlockHash: latest.Hash},
+		"",
+		[]string{},
+		base58.Encode(root.Bytes()),
+		common.NowISO8601(),
+	)
+	theBlock.MustSave(st)
+
+	// light node has only the blocks
+	lightSt := storage.NewTestStorage()
+	defer lightSt.Close()
+	theBlock.MustSave(lightSt)
+
+	var modify func(*client.AccountProof)
+	lightHandler := NetworkHandlerAPI{storage: lightSt}
+	lightHandler.GetAccountProof = func(address string, height uint64) (proof client.AccountProof, err error) {
+		url := strings.Replace(GetAccountProofHandlerPattern, "{id}", address, -1)
+		url += "?height=" + strconv.FormatUint(height, 10)
+		respBody := request(ts, url, false)
+		defer respBody.Close()
+		readByte, err := ioutil.ReadAll(bufio.NewReader(respBody))
+		require.NoError(t, err)
+		common.MustUnmarshalJSON(readByte, &proof)
+		if modify != nil {
+			modify(&proof)
+		}
+		return
+	}
+
+	router := mux.NewRouter()
+	router.HandleFunc(GetAccountHandlerPattern, lightHandler.GetAccountHandler).Methods("GET")
+	lightServer := httptest.NewServer(router)
+	defer lightServer.Close()
+
+	getAccount := func() map[string]interface{} {
+		url := strings.Replace(GetAccountHandlerPattern, "{id}", ba.Address, -1)
+		respBody := request(lightServer, url, false)
+		defer respBody.Close()
+		readByte, err := ioutil.ReadAll(bufio.NewReader(respBody))
+		require.NoError(t, err)
+
+		recv := map[string]interface{}{}
+		common.MustUnmarshalJSON(readByte, &recv)
+		return recv
+	}
+
+	{
+		recv := getAccount()
+		require.Equal(t, ba.Address, recv["address"])
+		require.Equal(t, ba.Balance.String(), recv["balance"])
+	}
+
+	{ // modified account
+		modify = func(proof *client.AccountProof) {
+			proof.Account.Balance = proof.Account.Balance.MustAdd(1)
+		}
+		recv := getAccount()
+		require.Equal(t, http.StatusBadRequest, int(recv["status"].(float64)))
+		require.Equal(t, httputils.ProblemTypeByCode(errors.InvalidAccountProof.Code), recv["type"])
+	}
+
+	{ // proof of the other block
+		modify = func(proof *client.AccountProof) {
+			proof.Block = latest.Hash
+		}
+		recv := getAccount()
+		require.Equal(t, http.StatusBadRequest, int(recv["status"].(float64)))
+		require.Equal(t, httputils.ProblemTypeByCode(errors.InvalidAccountProof.Code), recv["type"])
+	}
+}
+
+func TestGetAccountHistoryHandler(t *testing.T) {
+	ts, storage := prepareAPIServer()
+	defer storage.Close()
+	defer ts.Close()
+
+	genesis := block.GetLatestBlock(storage)
+
+	ba := block.TestMakeBlockAccount()
+	ba.MustSave(storage)
+
+	theBlock := block.TestMakeNewBlockWithPrevBlock(genesis, []string{})
+	theBlock.MustSave(storage)
+	require.NoError(t, ba.Deposit(common.Amount(100)))
+	ba.MustSave(storage)
+
+	{ // account at genesis
+		url := strings.Replace(GetAccountHandlerPattern, "{id}", ba.Address, -1)
+		url += "?height=" + strconv.FormatUint(genesis.Height, 10)
+		respBody := request(ts, url, false)
+		defer respBody.Close()
+		readByte, err := ioutil.ReadAll(bufio.NewReader(respBody))
+		require.NoError(t, err)
+
+		var account client.Account
+		common.MustUnmarshalJSON(readByte, &account)
+		require.Equal(t, common.Amount(common.BaseReserve).String(), account.Balance)
+	}
+
+	{ // invalid height
+		url := strings.Replace(GetAccountHandlerPattern, "{id}", ba.Address, -1) + "?height=findme"
+		req, _ := http.NewRequest("GET", ts.URL+url, nil)
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+
+	{
+		url := strings.Replace(GetAccountHistoryHandlerPattern, "{id}", ba.Address, -1)
+		respBody := request(ts, url, false)
+		defer respBody.Close()
+		readByte, err := ioutil.ReadAll(bufio.NewReader(respBody))
+		require.NoError(t, err)
+
+		var page client.AccountHistoryPage
+		common.MustUnmarshalJSON(readByte, &page)
+		records := page.Embedded.Records
+		require.Equal(t, 2, len(records))
+		require.Equal(t, genesis.Height, records[0].Height)
+		require.Equal(t, common.Amount(common.BaseReserve).String(), records[0].Balance)
+		require.Equal(t, theBlock.Height, records[1].Height)
+		require.Equal(t, ba.Balance.String(), records[1].Balance)
+	}
+
+	{ // reverse with limit
+		url := strings.Replace(GetAccountHistoryHandlerPattern, "{id}", ba.Address, -1) + "?reverse=true&limit=1"
+		respBody := request(ts, url, false)
+		defer respBody.Close()
+		readByte, err := ioutil.ReadAll(bufio.NewReader(respBody))
+		require.NoError(t, err)
+
+		var page client.AccountHistoryPage
+		common.MustUnmarshalJSON(readByte, &page)
+		require.Equal(t, 1, len(page.Embedded.Records))
+		require.Equal(t, theBlock.Height, page.Embedded.Records[0].Height)
+	}
+}
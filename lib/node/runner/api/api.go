@@ -3,9 +3,12 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 
 	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/client"
 	obs "boscoin.io/sebak/lib/common/observer"
+	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/network"
 	"boscoin.io/sebak/lib/network/httputils"
 	"boscoin.io/sebak/lib/node"
@@ -24,15 +27,25 @@ const (
 	GetAccountsHandlerPattern              = "/accounts"
 	GetAccountOperationsHandlerPattern     = "/accounts/{id}/operations"
 	GetAccountFrozenAccountHandlerPattern  = "/accounts/{id}/frozen-accounts"
+	GetAccountProofHandlerPattern          = "/accounts/{id}/proof"
+	GetAccountHistoryHandlerPattern        = "/accounts/{id}/history"
 	GetFrozenAccountHandlerPattern         = "/frozen-accounts"
 	GetTransactionsHandlerPattern          = "/transactions"
 	GetTransactionByHashHandlerPattern     = "/transactions/{id}"
 	GetTransactionOperationsHandlerPattern = "/transactions/{id}/operations"
 	GetTransactionOperationHandlerPattern  = "/transactions/{id}/operations/{opindex}"
 	GetTransactionStatusHandlerPattern     = "/transactions/{id}/status"
+	GetTransactionProofHandlerPattern      = "/transactions/{id}/proof"
 	PostTransactionPattern                 = "/transactions"
+	PostTransactionSimulatePattern         = "/transactions/simulate"
+	GetTransactionPoolHandlerPattern       = "/transaction-pool"
+	GetTransactionPoolStatsHandlerPattern  = "/transaction-pool/stats"
+	GetTransactionPoolItemHandlerPattern   = "/transaction-pool/{id}"
 	GetBlocksHandlerPattern                = "/blocks"
 	GetBlockHandlerPattern                 = "/blocks/{hashOrHeight}"
+	GetFeeStatsHandlerPattern              = "/fee-stats"
+	GetValidatorDelegationsHandlerPattern  = "/validators/{id}/delegations"
+	GetValidatorStakeHandlerPattern        = "/validators/{id}/stake"
 	GetNodeInfoPattern                     = "/"
 	PostSubscribePattern                   = "/subscribe"
 )
@@ -45,6 +58,15 @@ type NetworkHandlerAPI struct {
 	version        string
 	nodeInfo       node.NodeInfo
 	GetLatestBlock func() block.Block
+	// TransactionPool is the pool of pending transactions; without it, the
+	// transaction pool API is not served.
+	TransactionPool *transaction.Pool
+	// GetAccountProof is set in light mode; the account is served from the
+	// proof of the other nodes.
+	GetAccountProof func(address string, height uint64) (client.AccountProof, error)
+	// LightMode is set when the node follows only the blocks; see
+	// `FullModeOnly`.
+	LightMode bool
 }
 
 func NewNetworkHandlerAPI(localNode *node.LocalNode, network network.Network, storage *storage.LevelDBBackend, urlPrefix string, nodeInfo node.NodeInfo) *NetworkHandlerAPI {
@@ -62,6 +84,19 @@ func (api NetworkHandlerAPI) HandlerURLPattern(pattern string) string {
 	return fmt.Sprintf("%s/%s%s", api.urlPrefix, api.version, pattern)
 }
 
+// FullModeOnly wraps the handler, which reads the transactions or the account
+// states of local storage; they are not stored in light mode, so the handler
+// returns `errors.NotAvailableInLightMode` instead of the empty result.
+func (api NetworkHandlerAPI) FullModeOnly(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if api.LightMode {
+			httputils.WriteJSONError(w, errors.NotAvailableInLightMode)
+			return
+		}
+		handler(w, r)
+	}
+}
+
 func TriggerEvent(st *storage.LevelDBBackend, transactions []*transaction.Transaction) {
 	var (
 		t     = obs.ResourceObserver.Trigger
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network/httputils"
+	"boscoin.io/sebak/lib/node/runner/api/resource"
+)
+
+// TestFullModeOnly checks the handlers, which read the transactions or the
+// account states, return the explicit error in light mode instead of the
+// empty result.
+func TestFullModeOnly(t *testing.T) {
+	st := block.InitTestBlockchain()
+	defer st.Close()
+
+	newServer := func(lightMode bool) *httptest.Server {
+		apiHandler := NetworkHandlerAPI{storage: st, LightMode: lightMode}
+		simulate := func(w http.ResponseWriter, r *http.Request) {
+			apiHandler.PostTransactionSimulateHandler(w, r, func([]byte) (*resource.TransactionSimulation, error) {
+				return nil, errors.BadRequestParameter
+			})
+		}
+
+		router := mux.NewRouter()
+		router.HandleFunc(GetAccountHistoryHandlerPattern, apiHandler.FullModeOnly(apiHandler.GetAccountHistoryHandler)).Methods("GET")
+		router.HandleFunc(GetFeeStatsHandlerPattern, apiHandler.FullModeOnly(apiHandler.GetFeeStatsHandler)).Methods("GET")
+		router.HandleFunc(PostTransactionSimulatePattern, apiHandler.FullModeOnly(simulate)).Methods("POST")
+		router.HandleFunc(GetTransactionsHandlerPattern, apiHandler.FullModeOnly(apiHandler.GetTransactionsHandler)).Methods("GET")
+		router.HandleFunc(GetTransactionByHashHandlerPattern, apiHandler.FullModeOnly(apiHandler.GetTransactionByHashHandler)).Methods("GET")
+		return httptest.NewServer(router)
+	}
+
+	address := keypair.Random().Address()
+	requests := []struct {
+		method string
+		url    string
+	}{
+		{"GET", strings.Replace(GetAccountHistoryHandlerPattern, "{id}", address, -1)},
+		{"GET", GetFeeStatsHandlerPattern},
+		{"POST", PostTransactionSimulatePattern},
+		{"GET", GetTransactionsHandlerPattern},
+		{"GET", strings.Replace(GetTransactionByHashHandlerPattern, "{id}", "showme", -1)},
+	}
+
+	do := func(ts *httptest.Server, method, url string) (int, map[string]interface{}) {
+		req, err := http.NewRequest(method, ts.URL+url, strings.NewReader("{}"))
+		require.NoError(t, err)
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		readByte, err := ioutil.ReadAll(bufio.NewReader(resp.Body))
+		require.NoError(t, err)
+		recv := map[string]interface{}{}
+		common.MustUnmarshalJSON(readByte, &recv)
+		return resp.StatusCode, recv
+	}
+
+	{ // light mode
+		ts := newServer(true)
+		defer ts.Close()
+
+		for _, r := range requests {
+			status, recv := do(ts, r.method, r.url)
+			require.Equal(t, http.StatusNotImplemented, status, r.url)
+			require.Equal(t, httputils.ProblemTypeByCode(errors.NotAvailableInLightMode.Code), recv["type"], r.url)
+		}
+	}
+
+	{ // full mode
+		ts := newServer(false)
+		defer ts.Close()
+
+		for _, r := range requests {
+			status, recv := do(ts, r.method, r.url)
+			require.NotEqual(t, http.StatusNotImplemented, status, r.url)
+			require.NotEqual(t, httputils.ProblemTypeByCode(errors.NotAvailableInLightMode.Code), recv["type"], r.url)
+		}
+	}
+}
@@ -26,6 +26,8 @@ func prepareAPIServer() (*httptest.Server, *storage.LevelDBBackend) {
 
 	router := mux.NewRouter()
 	router.HandleFunc(GetAccountHandlerPattern, apiHandler.GetAccountHandler).Methods("GET")
+	router.HandleFunc(GetAccountProofHandlerPattern, apiHandler.GetAccountProofHandler).Methods("GET")
+	router.HandleFunc(GetAccountHistoryHandlerPattern, apiHandler.GetAccountHistoryHandler).Methods("GET")
 	router.HandleFunc(GetAccountsHandlerPattern, apiHandler.GetAccountsHandler).Methods("POST")
 	router.HandleFunc(GetAccountTransactionsHandlerPattern, apiHandler.GetTransactionsByAccountHandler).Methods("GET")
 	router.HandleFunc(GetAccountOperationsHandlerPattern, apiHandler.GetOperationsByAccountHandler).Methods("GET")
@@ -33,10 +35,13 @@ func prepareAPIServer() (*httptest.Server, *storage.LevelDBBackend) {
 	router.HandleFunc(GetTransactionsHandlerPattern, apiHandler.GetTransactionsHandler).Methods("GET")
 	router.HandleFunc(GetTransactionByHashHandlerPattern, apiHandler.GetTransactionByHashHandler).Methods("GET")
 	router.HandleFunc(GetTransactionStatusHandlerPattern, apiHandler.GetTransactionStatusByHashHandler).Methods("GET")
+	router.HandleFunc(GetTransactionProofHandlerPattern, apiHandler.GetTransactionProofHandler).Methods("GET")
 	router.HandleFunc(GetTransactionOperationsHandlerPattern, apiHandler.GetOperationsByTxHandler).Methods("GET")
 	router.HandleFunc(GetBlocksHandlerPattern, apiHandler.GetBlocksHandler).Methods("GET")
 	router.HandleFunc(GetBlockHandlerPattern, apiHandler.GetBlockHandler).Methods("GET")
 	router.HandleFunc(PostSubscribePattern, apiHandler.PostSubscribeHandler).Methods("POST")
+	router.HandleFunc(GetValidatorDelegationsHandlerPattern, apiHandler.GetValidatorDelegationsHandler).Methods("GET")
+	router.HandleFunc(GetValidatorStakeHandlerPattern, apiHandler.GetValidatorStakeHandler).Methods("GET")
 	ts := httptest.NewServer(router)
 	return ts, storage
 }
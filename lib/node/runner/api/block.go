@@ -41,7 +41,17 @@ func (api NetworkHandlerAPI) GetBlockHandler(w http.ResponseWriter, r *http.Requ
 			httputils.WriteJSONError(w, err)
 			return
 		}
-		res = resource.NewBlock(&b)
+		rb := resource.NewBlock(&b)
+
+		if withCertificate, _ := strconv.ParseBool(r.URL.Query().Get("certificate")); withCertificate {
+			c, err := block.GetCommitCertificate(api.storage, b.Height)
+			if err != nil {
+				httputils.WriteJSONError(w, err)
+				return
+			}
+			rb.SetCertificate(&c)
+		}
+		res = rb
 	}
 	httputils.MustWriteJSON(w, 200, res)
 }
@@ -4,11 +4,15 @@ import (
 	"bufio"
 	"encoding/json"
 	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
 
-	"boscoin.io/sebak/lib/block"
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common/keypair"
 )
 
 func TestBlockHandler(t *testing.T) {
@@ -46,4 +50,32 @@ func TestBlockHandler(t *testing.T) {
 		require.Equal(t, res["transactions_root"], genesis.TransactionsRoot)
 	}
 
+	{ // without certificate
+		url := strings.Replace(GetBlockHandlerPattern, "{hashOrHeight}", "1", 1)
+		res := reqFunc(url + "?certificate=1")
+		require.Equal(t, http.StatusNotFound, int(res["status"].(float64)))
+	}
+
+	{ // with certificate
+		kp := keypair.Random()
+		signature, _ := keypair.MakeSignature(kp, networkID, genesis.Hash)
+		c := block.NewCommitCertificate(genesis, []block.CommitSignature{
+			{Validator: kp.Address(), Signature: base58.Encode(signature)},
+		})
+		require.NoError(t, c.Save(st))
+
+		url := strings.Replace(GetBlockHandlerPattern, "{hashOrHeight}", genesis.Hash, 1)
+		res := reqFunc(url + "?certificate=1")
+		require.Equal(t, res["hash"], genesis.Hash)
+
+		certificate := res["certificate"].(map[string]interface{})
+		require.Equal(t, genesis.Hash, certificate["block_hash"])
+		signatures := certificate["signatures"].([]interface{})
+		require.Equal(t, 1, len(signatures))
+		require.Equal(t, kp.Address(), signatures[0].(map[string]interface{})["validator"])
+
+		res = reqFunc(url)
+		_, found := res["certificate"]
+		require.False(t, found)
+	}
 }
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network/httputils"
+	"boscoin.io/sebak/lib/node/runner/api/resource"
+)
+
+// GetValidatorDelegationsHandler returns the delegations to the validator.
+func (api NetworkHandlerAPI) GetValidatorDelegationsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	address := vars["id"]
+	if _, err := keypair.Parse(address); err != nil {
+		httputils.WriteJSONError(w, errors.BadPublicAddress)
+		return
+	}
+
+	p, err := NewPageQuery(r)
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	var options = p.ListOptions()
+	var firstCursor []byte
+	var cursor []byte
+
+	readFunc := func() []resource.Resource {
+		var rs []resource.Resource
+		iterFunc, closeFunc := block.GetDelegationsByValidator(api.storage, address, options)
+		for {
+			d, hasNext, c := iterFunc()
+			if !hasNext {
+				break
+			}
+			cursor = append([]byte{}, c...)
+			if len(firstCursor) == 0 {
+				firstCursor = append(firstCursor, c...)
+			}
+			rs = append(rs, resource.NewDelegation(d))
+		}
+		closeFunc()
+		return rs
+	}
+
+	rs := readFunc()
+	list := p.ResourceList(rs, firstCursor, cursor)
+	httputils.MustWriteJSON(w, 200, list)
+}
+
+// GetValidatorStakeHandler returns the total of delegated stake to the
+// validator.
+func (api NetworkHandlerAPI) GetValidatorStakeHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	address := vars["id"]
+	if _, err := keypair.Parse(address); err != nil {
+		httputils.WriteJSONError(w, errors.BadPublicAddress)
+		return
+	}
+
+	stake, err := block.GetDelegatedStake(api.storage, address)
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	httputils.MustWriteJSON(w, 200, resource.NewDelegatedStake(stake))
+}
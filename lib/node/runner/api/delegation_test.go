@@ -0,0 +1,78 @@
+package api
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/client"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+)
+
+func TestGetValidatorDelegationsHandler(t *testing.T) {
+	ts, storage := prepareAPIServer()
+	defer storage.Close()
+	defer ts.Close()
+
+	validator := keypair.Random().Address()
+
+	var delegations []block.Delegation
+	for i := 0; i < 3; i++ {
+		d := block.NewDelegation(keypair.Random().Address(), validator, common.Amount(100*(i+1)))
+		require.NoError(t, d.Save(storage))
+		delegations = append(delegations, d)
+	}
+	// the other validator
+	require.NoError(t, block.NewDelegation(keypair.Random().Address(), keypair.Random().Address(), common.Amount(100)).Save(storage))
+
+	{
+		url := strings.Replace(GetValidatorDelegationsHandlerPattern, "{id}", validator, -1)
+		respBody := request(ts, url, false)
+		defer respBody.Close()
+		readByte, err := ioutil.ReadAll(bufio.NewReader(respBody))
+		require.NoError(t, err)
+
+		var page client.DelegationsPage
+		common.MustUnmarshalJSON(readByte, &page)
+		records := page.Embedded.Records
+		require.Equal(t, 3, len(records))
+
+		amounts := map[string]string{}
+		for _, r := range records {
+			require.Equal(t, validator, r.Validator)
+			amounts[r.Account] = r.Amount
+		}
+		for _, d := range delegations {
+			require.Equal(t, d.Amount.String(), amounts[d.Account])
+		}
+	}
+
+	{ // stake
+		url := strings.Replace(GetValidatorStakeHandlerPattern, "{id}", validator, -1)
+		respBody := request(ts, url, false)
+		defer respBody.Close()
+		readByte, err := ioutil.ReadAll(bufio.NewReader(respBody))
+		require.NoError(t, err)
+
+		var stake client.DelegatedStake
+		common.MustUnmarshalJSON(readByte, &stake)
+		require.Equal(t, validator, stake.Validator)
+		require.Equal(t, common.Amount(600).String(), stake.Amount)
+		require.Equal(t, uint64(3), stake.Delegators)
+	}
+
+	{ // invalid address
+		url := strings.Replace(GetValidatorDelegationsHandlerPattern, "{id}", "findme", -1)
+		req, _ := http.NewRequest("GET", ts.URL+url, nil)
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+}
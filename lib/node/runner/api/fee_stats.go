@@ -0,0 +1,106 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network/httputils"
+	"boscoin.io/sebak/lib/node/runner/api/resource"
+	"boscoin.io/sebak/lib/transaction"
+)
+
+// DefaultFeeStatsBlocks is the default number of the recent blocks for the fee
+// stats.
+const DefaultFeeStatsBlocks uint64 = 10
+
+// GetFeeStatsHandler returns the fees of the transactions in the recent
+// blocks, the occupancy of the transaction pool and the recommended fee per
+// operation. The number of blocks can be set by `blocks` query, up to
+// `MaxLimit`.
+func (api NetworkHandlerAPI) GetFeeStatsHandler(w http.ResponseWriter, r *http.Request) {
+	blocks := DefaultFeeStatsBlocks
+	if b := r.URL.Query().Get("blocks"); len(b) > 0 {
+		var err error
+		if blocks, err = strconv.ParseUint(b, 10, 64); err != nil || blocks < 1 {
+			httputils.WriteJSONError(w, errors.BadRequestParameter)
+			return
+		}
+		if blocks > MaxLimit {
+			httputils.WriteJSONError(w, errors.PageQueryLimitMaxExceed)
+			return
+		}
+	}
+
+	// the transactions of genesis block are not counted
+	latest := block.GetLatestBlock(api.storage)
+	stats := resource.FeeStats{
+		BaseFee: common.BaseFee,
+		MinFee:  block.NextMinFee(latest.Header, api.nodeInfo.Policy.OperationsInBallotLimit),
+	}
+
+	for height := latest.Height; height > common.GenesisBlockHeight && uint64(stats.Blocks) < blocks; height-- {
+		blk, err := block.GetBlockByHeight(api.storage, height)
+		if err != nil {
+			break
+		}
+		for _, hash := range blk.Transactions {
+			bt, err := block.GetBlockTransaction(api.storage, hash)
+			if err != nil {
+				continue
+			}
+			stats.Fees = append(stats.Fees, feePerOperation(bt.Fee, len(bt.Operations)))
+		}
+		stats.Blocks++
+	}
+	sort.Slice(stats.Fees, func(i, j int) bool { return stats.Fees[i] < stats.Fees[j] })
+
+	var entries []transaction.PoolEntry
+	if api.TransactionPool != nil {
+		entries = api.TransactionPool.Entries()
+		stats.PoolSize = len(entries)
+		stats.PoolLimit = api.TransactionPool.ClientLimit()
+	}
+	stats.RecommendedFee = recommendFee(stats, entries)
+
+	httputils.MustWriteJSON(w, 200, stats)
+}
+
+func feePerOperation(fee common.Amount, operations int) common.Amount {
+	if operations < 1 {
+		return fee
+	}
+	return fee / common.Amount(operations)
+}
+
+// recommendFee returns the fee per operation by the occupancy of pool. Under
+// the half of limit, it is the minimum fee of the next block; over the half,
+// the median of the recent fees. When the pool is full, the fee must be higher
+// than the lowest one in the pool to evict it.
+func recommendFee(stats resource.FeeStats, entries []transaction.PoolEntry) (fee common.Amount) {
+	fee = stats.MinFee
+
+	occupancy := stats.Occupancy()
+	switch {
+	case occupancy >= 1:
+		var lowest common.Amount
+		for i, entry := range entries {
+			f := feePerOperation(entry.Transaction.B.Fee, len(entry.Transaction.B.Operations))
+			if i == 0 || f < lowest {
+				lowest = f
+			}
+		}
+		if lowest+1 > fee {
+			fee = lowest + 1
+		}
+	case occupancy >= 0.5 && len(stats.Fees) > 0:
+		if median := stats.Fees[(len(stats.Fees)-1)/2]; median > fee {
+			fee = median
+		}
+	}
+
+	return
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/transaction"
+)
+
+func TestGetFeeStatsHandler(t *testing.T) {
+	st := block.InitTestBlockchain()
+	defer st.Close()
+
+	conf := common.NewTestConfig()
+	conf.TxPoolClientLimit = 2
+	pool := transaction.NewPool(conf)
+	apiHandler := NetworkHandlerAPI{storage: st, TransactionPool: pool}
+
+	router := mux.NewRouter()
+	router.HandleFunc(GetFeeStatsHandlerPattern, apiHandler.GetFeeStatsHandler).Methods("GET")
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	// fee per operation of each block: 1x, 2x, 3x and 4x of base fee
+	for i := 1; i <= 4; i++ {
+		kp := keypair.Random()
+		tx := transaction.TestMakeTransactionWithKeypair(networkID, 2, kp)
+		tx.B.Fee = common.BaseFee.MustMult(i * 2)
+		tx.Sign(kp, networkID)
+
+		blk := block.TestMakeNewBlockWithPrevBlock(block.GetLatestBlock(st), []string{tx.GetHash()})
+		blk.MustSave(st)
+		bt := block.NewBlockTransactionFromTransaction(blk.Hash, blk.Height, blk.ProposedTime, tx)
+		bt.MustSave(st)
+	}
+
+	get := func(url string) map[string]interface{} {
+		body := request(ts, url, false)
+		defer body.Close()
+		b, err := ioutil.ReadAll(body)
+		require.NoError(t, err)
+
+		result := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(b, &result))
+		return result
+	}
+
+	{ // empty pool
+		result := get(GetFeeStatsHandlerPattern)
+		require.Equal(t, common.BaseFee.String(), result["base_fee"])
+		require.Equal(t, common.BaseFee.String(), result["min_fee"])
+		require.Equal(t, float64(4), result["blocks"])
+		require.Equal(t, float64(4), result["transactions"])
+
+		fee := result["fee"].(map[string]interface{})
+		require.Equal(t, common.BaseFee.String(), fee["min"])
+		require.Equal(t, common.BaseFee.MustMult(4).String(), fee["max"])
+		require.Equal(t, common.BaseFee.MustMult(2).String(), fee["p50"])
+
+		p := result["pool"].(map[string]interface{})
+		require.Equal(t, float64(0), p["size"])
+		require.Equal(t, float64(2), p["limit"])
+		require.Equal(t, float64(0), p["occupancy"])
+		require.Equal(t, common.BaseFee.String(), result["recommended_fee"])
+	}
+
+	{ // only the latest blocks
+		result := get(GetFeeStatsHandlerPattern + "?blocks=2")
+		require.Equal(t, float64(2), result["blocks"])
+		fee := result["fee"].(map[string]interface{})
+		require.Equal(t, common.BaseFee.MustMult(3).String(), fee["min"])
+	}
+
+	{ // half of pool; median of recent fees
+		kp, tx := transaction.TestMakeTransaction(networkID, 1)
+		tx.B.Fee = common.BaseFee.MustMult(5)
+		tx.Sign(kp, networkID)
+		require.NoError(t, pool.Add(tx))
+
+		result := get(GetFeeStatsHandlerPattern)
+		require.Equal(t, 0.5, result["pool"].(map[string]interface{})["occupancy"])
+		require.Equal(t, common.BaseFee.MustMult(2).String(), result["recommended_fee"])
+	}
+
+	{ // full pool; higher than the lowest in pool
+		kp, tx := transaction.TestMakeTransaction(networkID, 2)
+		tx.B.Fee = common.BaseFee.MustMult(6) // 3x per operation
+		tx.Sign(kp, networkID)
+		require.NoError(t, pool.Add(tx))
+
+		result := get(GetFeeStatsHandlerPattern)
+		require.Equal(t, float64(1), result["pool"].(map[string]interface{})["occupancy"])
+		require.Equal(t, (common.BaseFee.MustMult(3) + 1).String(), result["recommended_fee"])
+	}
+
+	{ // invalid blocks
+		for _, q := range []string{"?blocks=0", "?blocks=-1", "?blocks=findme"} {
+			result := get(GetFeeStatsHandlerPattern + q)
+			require.Equal(t, errors.BadRequestParameter.Message, result["title"])
+		}
+		result := get(GetFeeStatsHandlerPattern + "?blocks=101")
+		require.Equal(t, errors.PageQueryLimitMaxExceed.Message, result["title"])
+	}
+}
@@ -73,7 +73,8 @@ func (api NetworkHandlerAPI) GetOperationsByAccountHandler(w http.ResponseWriter
 	if found, err := block.ExistsBlockAccount(api.storage, address); err != nil {
 		httputils.WriteJSONError(w, err)
 		return
-	} else if !found {
+	} else if !found && !block.IsMergedBlockAccount(api.storage, address) {
+		// the operations of merged account still can be found
 		httputils.WriteJSONError(w, errors.BlockAccountDoesNotExists)
 		return
 	}
@@ -3,6 +3,9 @@ package api
 import (
 	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/transaction"
 	"boscoin.io/sebak/lib/transaction/operation"
 	"bufio"
 	"fmt"
@@ -334,3 +337,50 @@ func TestGetOperationsByAccountHandlerPage(t *testing.T) {
 		}
 	}
 }
+
+func TestGetOperationsByAccountHandlerMergedAccount(t *testing.T) {
+	ts, storage := prepareAPIServer()
+	defer storage.Close()
+	defer ts.Close()
+
+	kp := keypair.Random()
+	kpTarget := keypair.Random()
+
+	url := strings.Replace(GetAccountOperationsHandlerPattern, "{id}", kp.Address(), -1)
+
+	{ // unknown account
+		respBody := request(ts, url, false)
+		defer respBody.Close()
+		readByte, err := ioutil.ReadAll(bufio.NewReader(respBody))
+		require.NoError(t, err)
+		require.Contains(t, string(readByte), errors.BlockAccountDoesNotExists.Message)
+	}
+
+	tx := transaction.TestMakeTransactionWithKeypair(networkID, 1, kp)
+	mergeOp, _ := operation.NewOperation(operation.NewAccountMerge(kpTarget.Address()))
+	tx.B.Operations = append(tx.B.Operations, mergeOp)
+	tx.Sign(kp, networkID)
+
+	theBlock := block.TestMakeNewBlockWithPrevBlock(block.GetLatestBlock(storage), []string{tx.GetHash()})
+	theBlock.MustSave(storage)
+	bt := block.NewBlockTransactionFromTransaction(theBlock.Hash, theBlock.Height, theBlock.ProposedTime, tx)
+	require.NoError(t, bt.Save(storage))
+	require.NoError(t, bt.SaveBlockOperations(storage))
+
+	// the operations of merged account can be found
+	{
+		respBody := request(ts, url, false)
+		defer respBody.Close()
+		readByte, err := ioutil.ReadAll(bufio.NewReader(respBody))
+		require.NoError(t, err)
+
+		recv := make(map[string]interface{})
+		common.MustUnmarshalJSON(readByte, &recv)
+		records := recv["_embedded"].(map[string]interface{})["records"].([]interface{})
+		require.Equal(t, 2, len(records))
+
+		last := records[1].(map[string]interface{})
+		require.Equal(t, "account-merge", last["type"])
+		require.Equal(t, kpTarget.Address(), last["target"])
+	}
+}
@@ -1,6 +1,7 @@
 package resource
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/nvellon/hal"
@@ -33,8 +34,9 @@ func (a Account) Resource() *hal.Resource {
 	accountID := a.ba.Address
 
 	r := hal.NewResource(a, a.LinkSelf())
-	r.AddLink("transactions", hal.NewLink(strings.Replace(URLAccountTransactions, "{id}", address, -1)+"{?cursor,limit,order}", hal.LinkAttr{"templated": true}))
+	r.AddLink("transactions", hal.NewLink(strings.Replace(URLAccountTransactions, "{id}", address, -1)+"{?cursor,limit,order,memo,memo_type}", hal.LinkAttr{"templated": true}))
 	r.AddLink("operations", hal.NewLink(strings.Replace(URLAccountOperations, "{id}", accountID, -1)+"{?cursor,limit,order}", hal.LinkAttr{"templated": true}))
+	r.AddLink("history", hal.NewLink(strings.Replace(URLAccountHistory, "{id}", address, -1)+"{?cursor,limit,order}", hal.LinkAttr{"templated": true}))
 	return r
 }
 
@@ -42,3 +44,77 @@ func (a Account) LinkSelf() string {
 	address := a.ba.Address
 	return strings.Replace(URLAccounts, "{id}", address, -1)
 }
+
+// AccountHistory is the state of account at the block height.
+type AccountHistory struct {
+	h block.BlockAccountHistory
+}
+
+func NewAccountHistory(h block.BlockAccountHistory) *AccountHistory {
+	return &AccountHistory{
+		h: h,
+	}
+}
+
+func (a AccountHistory) GetMap() hal.Entry {
+	return hal.Entry{
+		"address":     a.h.Account.Address,
+		"height":      a.h.Height,
+		"sequence_id": a.h.Account.SequenceID,
+		"balance":     a.h.Account.Balance,
+		"linked":      a.h.Account.Linked,
+		"removed":     a.h.Removed,
+	}
+}
+
+func (a AccountHistory) Resource() *hal.Resource {
+	r := hal.NewResource(a, a.LinkSelf())
+	r.AddLink("account", hal.NewLink(strings.Replace(URLAccounts, "{id}", a.h.Account.Address, -1)))
+	return r
+}
+
+func (a AccountHistory) LinkSelf() string {
+	return strings.Replace(URLAccounts, "{id}", a.h.Account.Address, -1) + fmt.Sprintf("?height=%d", a.h.Height)
+}
+
+// AccountProof is the merkle patricia proof of account in the state trie. The
+// proof can be verified with `state_root` of the block header; the other
+// fields of block are included to check the header with the block hash.
+type AccountProof struct {
+	ba    *block.BlockAccount
+	Block *block.Block
+	Proof [][]byte
+}
+
+func NewAccountProof(ba *block.BlockAccount, blk *block.Block, proof [][]byte) *AccountProof {
+	return &AccountProof{
+		ba:    ba,
+		Block: blk,
+		Proof: proof,
+	}
+}
+
+func (a AccountProof) GetMap() hal.Entry {
+	return hal.Entry{
+		"address":              a.ba.Address,
+		"account":              a.ba,
+		"block":                a.Block.Hash,
+		"header":               a.Block.Header,
+		"transactions":         a.Block.Transactions,
+		"proposer_transaction": a.Block.ProposerTransaction,
+		"proposer":             a.Block.Proposer,
+		"round":                a.Block.Round,
+		"proof":                a.Proof,
+	}
+}
+
+func (a AccountProof) Resource() *hal.Resource {
+	r := hal.NewResource(a, a.LinkSelf())
+	r.AddLink("account", hal.NewLink(strings.Replace(URLAccounts, "{id}", a.ba.Address, -1)))
+	r.AddLink("block", hal.NewLink(strings.Replace(URLBlocks, "{id}", a.Block.Hash, -1)))
+	return r
+}
+
+func (a AccountProof) LinkSelf() string {
+	return strings.Replace(URLAccountProof, "{id}", a.ba.Address, -1) + fmt.Sprintf("?height=%d", a.Block.Height)
+}
@@ -8,7 +8,8 @@ import (
 )
 
 type Block struct {
-	b *block.Block
+	b           *block.Block
+	certificate *block.CommitCertificate
 }
 
 func NewBlock(b *block.Block) *Block {
@@ -18,21 +19,35 @@ func NewBlock(b *block.Block) *Block {
 	return blk
 }
 
+// SetCertificate includes the commit certificate of block into the resource.
+func (blk *Block) SetCertificate(c *block.CommitCertificate) *Block {
+	blk.certificate = c
+	return blk
+}
+
 func (blk Block) GetMap() hal.Entry {
 	b := blk.b
-	return hal.Entry{
+	entry := hal.Entry{
 		"version":              b.Version,
 		"hash":                 b.Hash,
 		"height":               b.Height,
 		"prev_block_hash":      b.PrevBlockHash,
 		"transactions_root":    b.TransactionsRoot,
+		"state_root":           b.StateRoot,
 		"confirmed":            b.Confirmed,
 		"proposer":             b.Proposer,
 		"proposed_time":        b.ProposedTime,
 		"proposer_transaction": b.ProposerTransaction,
 		"round":                b.Round,
 		"transactions":         b.Transactions,
+		"min_fee":              b.MinFee.String(),
+		"ops":                  b.Ops,
 	}
+	if blk.certificate != nil {
+		entry["certificate"] = blk.certificate
+	}
+
+	return entry
 }
 
 func (blk Block) Resource() *hal.Resource {
@@ -8,12 +8,21 @@ const (
 	URLAccountTransactions   = APIPrefix + APIVersionV1 + "/accounts/{id}/transactions"
 	URLAccountOperations     = APIPrefix + APIVersionV1 + "/accounts/{id}/operations"
 	URLAccountFrozenAccounts = APIPrefix + APIVersionV1 + "/accounts/{id}/frozen-accounts"
+	URLAccountProof          = APIPrefix + APIVersionV1 + "/accounts/{id}/proof"
+	URLAccountHistory        = APIPrefix + APIVersionV1 + "/accounts/{id}/history"
 	URLFrozenAccounts        = APIPrefix + APIVersionV1 + "/frozen-accounts"
 	URLTransactions          = APIPrefix + APIVersionV1 + "/transactions"
 	URLTransactionByHash     = APIPrefix + APIVersionV1 + "/transactions/{id}"
+	URLTransactionSimulate   = APIPrefix + APIVersionV1 + "/transactions/simulate"
 	URLTransactionOperations = APIPrefix + APIVersionV1 + "/transactions/{id}/operations"
 	URLTransactionOperation  = APIPrefix + APIVersionV1 + "/transactions/{id}/operations/{opindex}"
 	URLTransactionStatus     = APIPrefix + APIVersionV1 + "/transactions/{id}/status"
+	URLTransactionProof      = APIPrefix + APIVersionV1 + "/transactions/{id}/proof"
+	URLTransactionPool       = APIPrefix + APIVersionV1 + "/transaction-pool/{id}"
+	URLTransactionPoolStats  = APIPrefix + APIVersionV1 + "/transaction-pool/stats"
 	URLOperations            = APIPrefix + APIVersionV1 + "/operations/{id}"
 	URLBlocks                = APIPrefix + APIVersionV1 + "/blocks/{id}"
+	URLFeeStats              = APIPrefix + APIVersionV1 + "/fee-stats"
+	URLValidatorDelegations  = APIPrefix + APIVersionV1 + "/validators/{id}/delegations"
+	URLValidatorStake        = APIPrefix + APIVersionV1 + "/validators/{id}/stake"
 )
@@ -0,0 +1,68 @@
+package resource
+
+import (
+	"strings"
+
+	"github.com/nvellon/hal"
+
+	"boscoin.io/sebak/lib/block"
+)
+
+// Delegation is the stake of frozen account delegated to the validator.
+type Delegation struct {
+	d block.Delegation
+}
+
+func NewDelegation(d block.Delegation) *Delegation {
+	return &Delegation{
+		d: d,
+	}
+}
+
+func (d Delegation) GetMap() hal.Entry {
+	return hal.Entry{
+		"account":   d.d.Account,
+		"validator": d.d.Validator,
+		"amount":    d.d.Amount,
+	}
+}
+
+func (d Delegation) Resource() *hal.Resource {
+	r := hal.NewResource(d, d.LinkSelf())
+	r.AddLink("account", hal.NewLink(strings.Replace(URLAccounts, "{id}", d.d.Account, -1)))
+	r.AddLink("stake", hal.NewLink(strings.Replace(URLValidatorStake, "{id}", d.d.Validator, -1)))
+	return r
+}
+
+func (d Delegation) LinkSelf() string {
+	return strings.Replace(URLValidatorDelegations, "{id}", d.d.Validator, -1)
+}
+
+// DelegatedStake is the total of delegated stake to the validator.
+type DelegatedStake struct {
+	s block.DelegatedStake
+}
+
+func NewDelegatedStake(s block.DelegatedStake) *DelegatedStake {
+	return &DelegatedStake{
+		s: s,
+	}
+}
+
+func (s DelegatedStake) GetMap() hal.Entry {
+	return hal.Entry{
+		"validator":  s.s.Validator,
+		"amount":     s.s.Amount,
+		"delegators": s.s.Delegators,
+	}
+}
+
+func (s DelegatedStake) Resource() *hal.Resource {
+	r := hal.NewResource(s, s.LinkSelf())
+	r.AddLink("delegations", hal.NewLink(strings.Replace(URLValidatorDelegations, "{id}", s.s.Validator, -1)+"{?cursor,limit,order}", hal.LinkAttr{"templated": true}))
+	return r
+}
+
+func (s DelegatedStake) LinkSelf() string {
+	return strings.Replace(URLValidatorStake, "{id}", s.s.Validator, -1)
+}
@@ -0,0 +1,50 @@
+package resource
+
+import (
+	"github.com/nvellon/hal"
+
+	"boscoin.io/sebak/lib/common"
+)
+
+// FeeStats is the fees of the transactions in the recent blocks and the
+// occupancy of the transaction pool. The fees are per operation.
+type FeeStats struct {
+	BaseFee        common.Amount
+	MinFee         common.Amount   // minimum fee of the next block
+	Blocks         int             // number of the recent blocks
+	Fees           []common.Amount // sorted
+	PoolSize       int
+	PoolLimit      int
+	RecommendedFee common.Amount
+}
+
+func (f FeeStats) Occupancy() float64 {
+	if f.PoolLimit < 1 {
+		return 0
+	}
+	return float64(f.PoolSize) / float64(f.PoolLimit)
+}
+
+func (f FeeStats) GetMap() hal.Entry {
+	return hal.Entry{
+		"base_fee":        f.BaseFee.String(),
+		"min_fee":         f.MinFee.String(),
+		"blocks":          f.Blocks,
+		"transactions":    len(f.Fees),
+		"fee":             percentilesEntry(f.Fees),
+		"recommended_fee": f.RecommendedFee.String(),
+		"pool": hal.Entry{
+			"size":      f.PoolSize,
+			"limit":     f.PoolLimit,
+			"occupancy": f.Occupancy(),
+		},
+	}
+}
+
+func (f FeeStats) Resource() *hal.Resource {
+	return hal.NewResource(f, f.LinkSelf())
+}
+
+func (f FeeStats) LinkSelf() string {
+	return URLFeeStats
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
 	"github.com/nvellon/hal"
 )
 
@@ -19,7 +20,7 @@ func NewTransaction(bt *block.BlockTransaction) *Transaction {
 }
 
 func (t Transaction) GetMap() hal.Entry {
-	return hal.Entry{
+	entry := hal.Entry{
 		"hash":            t.bt.Hash,
 		"block":           t.bt.Block,
 		"source":          t.bt.Source,
@@ -28,6 +29,11 @@ func (t Transaction) GetMap() hal.Entry {
 		"created":         t.bt.Created,
 		"operation_count": len(t.bt.Operations),
 	}
+	if t.bt.Memo != nil {
+		entry["memo"] = t.bt.Memo
+	}
+
+	return entry
 }
 func (t Transaction) Resource() *hal.Resource {
 
@@ -70,3 +76,45 @@ func (t TransactionStatus) Resource() *hal.Resource {
 func (t TransactionStatus) LinkSelf() string {
 	return strings.Replace(URLTransactionStatus, "{id}", t.Hash, -1)
 }
+
+// TransactionProof is the merkle proof of transaction in block. The proof can
+// be verified with `transactions_root` of the block header; the other fields
+// of block are included to check the header with the block hash.
+type TransactionProof struct {
+	Hash  string
+	Block *block.Block
+	Proof common.MerkleProof
+}
+
+func NewTransactionProof(hash string, blk *block.Block, proof common.MerkleProof) *TransactionProof {
+	return &TransactionProof{
+		Hash:  hash,
+		Block: blk,
+		Proof: proof,
+	}
+}
+
+func (t TransactionProof) GetMap() hal.Entry {
+	return hal.Entry{
+		"hash":                 t.Hash,
+		"block":                t.Block.Hash,
+		"header":               t.Block.Header,
+		"transactions":         t.Block.Transactions,
+		"proposer_transaction": t.Block.ProposerTransaction,
+		"proposer":             t.Block.Proposer,
+		"round":                t.Block.Round,
+		"index":                t.Proof.Index,
+		"siblings":             t.Proof.Siblings,
+	}
+}
+
+func (t TransactionProof) Resource() *hal.Resource {
+	r := hal.NewResource(t, t.LinkSelf())
+	r.AddLink("transaction", hal.NewLink(strings.Replace(URLTransactionByHash, "{id}", t.Hash, -1)))
+	r.AddLink("block", hal.NewLink(strings.Replace(URLBlocks, "{id}", t.Block.Hash, -1)))
+	return r
+}
+
+func (t TransactionProof) LinkSelf() string {
+	return strings.Replace(URLTransactionProof, "{id}", t.Hash, -1)
+}
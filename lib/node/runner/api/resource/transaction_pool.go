@@ -0,0 +1,127 @@
+package resource
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nvellon/hal"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/transaction"
+)
+
+// TransactionPoolItem is the pending transaction in `transaction.Pool`.
+type TransactionPoolItem struct {
+	entry transaction.PoolEntry
+	now   time.Time
+}
+
+func NewTransactionPoolItem(entry transaction.PoolEntry, now time.Time) *TransactionPoolItem {
+	return &TransactionPoolItem{entry: entry, now: now}
+}
+
+func (t TransactionPoolItem) GetMap() hal.Entry {
+	tx := t.entry.Transaction
+	return hal.Entry{
+		"hash":            tx.GetHash(),
+		"source":          tx.B.Source,
+		"fee":             tx.B.Fee.String(),
+		"sequence_id":     tx.B.SequenceID,
+		"created":         tx.H.Created,
+		"added":           common.FormatISO8601(t.entry.Added),
+		"age":             t.now.Sub(t.entry.Added).Seconds(),
+		"operation_count": len(tx.B.Operations),
+	}
+}
+
+func (t TransactionPoolItem) Resource() *hal.Resource {
+	r := hal.NewResource(t, t.LinkSelf())
+	r.AddLink("account", hal.NewLink(strings.Replace(URLAccounts, "{id}", t.entry.Transaction.B.Source, -1)))
+	r.AddLink("status", hal.NewLink(strings.Replace(URLTransactionStatus, "{id}", t.entry.Transaction.GetHash(), -1)))
+	return r
+}
+
+func (t TransactionPoolItem) LinkSelf() string {
+	return strings.Replace(URLTransactionPool, "{id}", t.entry.Transaction.GetHash(), -1)
+}
+
+// TransactionPoolStatsPercentiles are the percentiles of fee in
+// `TransactionPoolStats`.
+var TransactionPoolStatsPercentiles = []int{10, 25, 50, 75, 90}
+
+// TransactionPoolStats is the aggregated stats of the pending transactions.
+type TransactionPoolStats struct {
+	size       int
+	operations int
+	oldestAge  float64
+	fees       []common.Amount // sorted
+}
+
+func NewTransactionPoolStats(entries []transaction.PoolEntry, now time.Time) *TransactionPoolStats {
+	s := &TransactionPoolStats{size: len(entries)}
+	for _, entry := range entries {
+		s.operations += len(entry.Transaction.B.Operations)
+		s.fees = append(s.fees, entry.Transaction.B.Fee)
+		if age := now.Sub(entry.Added).Seconds(); age > s.oldestAge {
+			s.oldestAge = age
+		}
+	}
+	sort.Slice(s.fees, func(i, j int) bool { return s.fees[i] < s.fees[j] })
+
+	return s
+}
+
+// Percentile returns the fee of the nearest rank; without transactions, it
+// returns 0.
+func (s TransactionPoolStats) Percentile(p int) common.Amount {
+	return percentile(s.fees, p)
+}
+
+func (s TransactionPoolStats) GetMap() hal.Entry {
+	return hal.Entry{
+		"size":       s.size,
+		"operations": s.operations,
+		"oldest_age": s.oldestAge,
+		"fee":        percentilesEntry(s.fees),
+	}
+}
+
+// percentile returns the amount of the nearest rank in the sorted amounts.
+func percentile(sorted []common.Amount, p int) common.Amount {
+	if len(sorted) < 1 {
+		return 0
+	}
+
+	rank := (p*len(sorted) + 99) / 100 // ceil
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// percentilesEntry has the minimum, maximum and
+// `TransactionPoolStatsPercentiles` of the sorted amounts.
+func percentilesEntry(sorted []common.Amount) hal.Entry {
+	entry := hal.Entry{}
+	if len(sorted) < 1 {
+		return entry
+	}
+
+	entry["min"] = sorted[0].String()
+	entry["max"] = sorted[len(sorted)-1].String()
+	for _, p := range TransactionPoolStatsPercentiles {
+		entry["p"+strconv.Itoa(p)] = percentile(sorted, p).String()
+	}
+
+	return entry
+}
+
+func (s TransactionPoolStats) Resource() *hal.Resource {
+	return hal.NewResource(s, s.LinkSelf())
+}
+
+func (s TransactionPoolStats) LinkSelf() string {
+	return URLTransactionPoolStats
+}
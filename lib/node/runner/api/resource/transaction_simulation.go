@@ -0,0 +1,84 @@
+package resource
+
+import (
+	"github.com/nvellon/hal"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+// TransactionSimulation is the result of the dry-run of transaction against
+// the current state. The valid transaction has the balance changes of the
+// accounts; the invalid one has the error and the errors of each operation.
+type TransactionSimulation struct {
+	Hash           string
+	Error          *errors.Error
+	Operations     []OperationSimulation
+	BalanceChanges []BalanceChange
+}
+
+type OperationSimulation struct {
+	Type  operation.OperationType
+	Error *errors.Error
+}
+
+type BalanceChange struct {
+	Address string
+	Before  common.Amount
+	After   common.Amount
+}
+
+// Delta returns the signed difference of balance.
+func (b BalanceChange) Delta() string {
+	if b.After < b.Before {
+		return "-" + (b.Before - b.After).String()
+	}
+	return (b.After - b.Before).String()
+}
+
+func (t TransactionSimulation) GetMap() hal.Entry {
+	var operations []hal.Entry
+	for i, op := range t.Operations {
+		entry := hal.Entry{
+			"index": i,
+			"type":  op.Type,
+		}
+		if op.Error != nil {
+			entry["error"] = op.Error
+		}
+		operations = append(operations, entry)
+	}
+
+	entry := hal.Entry{
+		"hash":       t.Hash,
+		"valid":      t.Error == nil,
+		"operations": operations,
+	}
+
+	if t.Error != nil {
+		entry["error"] = t.Error
+		return entry
+	}
+
+	var changes []hal.Entry
+	for _, b := range t.BalanceChanges {
+		changes = append(changes, hal.Entry{
+			"address": b.Address,
+			"before":  b.Before.String(),
+			"after":   b.After.String(),
+			"delta":   b.Delta(),
+		})
+	}
+	entry["balance_changes"] = changes
+
+	return entry
+}
+
+func (t TransactionSimulation) Resource() *hal.Resource {
+	return hal.NewResource(t, t.LinkSelf())
+}
+
+func (t TransactionSimulation) LinkSelf() string {
+	return URLTransactionSimulate
+}
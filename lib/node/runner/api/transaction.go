@@ -12,6 +12,7 @@ import (
 	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/network/httputils"
 	"boscoin.io/sebak/lib/node/runner/api/resource"
+	"boscoin.io/sebak/lib/transaction"
 )
 
 func (api NetworkHandlerAPI) GetTransactionsHandler(w http.ResponseWriter, r *http.Request) {
@@ -76,6 +77,44 @@ func (api NetworkHandlerAPI) GetTransactionByHashHandler(w http.ResponseWriter,
 	}
 }
 
+// GetTransactionProofHandler returns the merkle proof of transaction, which is
+// verified with the `TransactionsRoot` of block header.
+func (api NetworkHandlerAPI) GetTransactionProofHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	key := vars["id"]
+
+	readFunc := func() (payload interface{}, err error) {
+		found, err := block.ExistsBlockTransaction(api.storage, key)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, errors.BlockTransactionDoesNotExists
+		}
+		bt, err := block.GetBlockTransaction(api.storage, key)
+		if err != nil {
+			return nil, err
+		}
+		blk, err := block.GetBlock(api.storage, bt.Block)
+		if err != nil {
+			return nil, err
+		}
+		proof, found := blk.TransactionProof(bt.Hash)
+		if !found {
+			return nil, errors.BlockTransactionDoesNotExists
+		}
+		payload = resource.NewTransactionProof(bt.Hash, &blk, proof)
+		return payload, nil
+	}
+
+	payload, err := readFunc()
+	if err == nil {
+		httputils.MustWriteJSON(w, 200, payload)
+	} else {
+		httputils.WriteJSONError(w, err)
+	}
+}
+
 func (api NetworkHandlerAPI) GetTransactionsByAccountHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	address := vars["id"]
@@ -86,12 +125,32 @@ func (api NetworkHandlerAPI) GetTransactionsByAccountHandler(w http.ResponseWrit
 		return
 	}
 
+	var memo *transaction.Memo
+	if value := r.URL.Query().Get("memo"); len(value) > 0 {
+		memoType := transaction.MemoText
+		if t := r.URL.Query().Get("memo_type"); len(t) > 0 {
+			memoType = transaction.MemoType(t)
+		}
+		m, err := transaction.NewMemo(memoType, value)
+		if err != nil {
+			httputils.WriteJSONError(w, errors.InvalidQueryString)
+			return
+		}
+		memo = &m
+	}
+
 	var options = p.ListOptions()
 	var firstCursor []byte
 	var cursor []byte
 	readFunc := func() []resource.Resource {
 		var txs []resource.Resource
-		iterFunc, closeFunc := block.GetBlockTransactionsByAccount(api.storage, address, options)
+		var iterFunc func() (block.BlockTransaction, bool, []byte)
+		var closeFunc func()
+		if memo != nil {
+			iterFunc, closeFunc = block.GetBlockTransactionsByAccountMemo(api.storage, address, *memo, options)
+		} else {
+			iterFunc, closeFunc = block.GetBlockTransactionsByAccount(api.storage, address, options)
+		}
 		for {
 			t, hasNext, c := iterFunc()
 			if !hasNext {
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network/httputils"
+	"boscoin.io/sebak/lib/node/runner/api/resource"
+)
+
+// GetTransactionPoolHandler lists the pending transactions of
+// `transaction.Pool` in order of the time added; the cursor is the `Order` of
+// `transaction.PoolEntry`.
+func (api NetworkHandlerAPI) GetTransactionPoolHandler(w http.ResponseWriter, r *http.Request) {
+	p, err := NewPageQuery(r, WithEncodePageCursor(false))
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	var cursor uint64
+	if p.Cursor() != nil {
+		if cursor, err = strconv.ParseUint(string(p.Cursor()), 10, 64); err != nil {
+			httputils.WriteJSONError(w, errors.BadRequestParameter)
+			return
+		}
+	}
+
+	entries := api.TransactionPool.Entries()
+	if p.Reverse() {
+		for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+			entries[i], entries[j] = entries[j], entries[i]
+		}
+	}
+
+	now := time.Now()
+	var items []resource.Resource
+	var firstCursor, lastCursor []byte
+	for _, entry := range entries {
+		if uint64(len(items)) >= p.Limit() {
+			break
+		}
+		if cursor > 0 {
+			if !p.Reverse() && entry.Order <= cursor {
+				continue
+			}
+			if p.Reverse() && entry.Order >= cursor {
+				continue
+			}
+		}
+
+		c := []byte(strconv.FormatUint(entry.Order, 10))
+		if firstCursor == nil {
+			firstCursor = c
+		}
+		lastCursor = c
+		items = append(items, resource.NewTransactionPoolItem(entry, now))
+	}
+
+	list := p.ResourceList(items, firstCursor, lastCursor)
+	httputils.MustWriteJSON(w, 200, list)
+}
+
+func (api NetworkHandlerAPI) GetTransactionPoolItemHandler(w http.ResponseWriter, r *http.Request) {
+	hash := mux.Vars(r)["id"]
+
+	entry, found := api.TransactionPool.Entry(hash)
+	if !found {
+		httputils.WriteJSONError(w, errors.TransactionNotFoundInPool)
+		return
+	}
+
+	httputils.MustWriteJSON(w, 200, resource.NewTransactionPoolItem(entry, time.Now()))
+}
+
+// GetTransactionPoolStatsHandler returns the size, the age of the oldest
+// transaction and the fee percentiles of the pending transactions.
+func (api NetworkHandlerAPI) GetTransactionPoolStatsHandler(w http.ResponseWriter, r *http.Request) {
+	stats := resource.NewTransactionPoolStats(api.TransactionPool.Entries(), time.Now())
+	httputils.MustWriteJSON(w, 200, stats)
+}
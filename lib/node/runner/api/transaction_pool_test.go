@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/transaction"
+)
+
+func TestTransactionPoolHandlers(t *testing.T) {
+	conf := common.NewTestConfig()
+	pool := transaction.NewPool(conf)
+	apiHandler := NetworkHandlerAPI{TransactionPool: pool}
+
+	router := mux.NewRouter()
+	router.HandleFunc(GetTransactionPoolHandlerPattern, apiHandler.GetTransactionPoolHandler).Methods("GET")
+	router.HandleFunc(GetTransactionPoolStatsHandlerPattern, apiHandler.GetTransactionPoolStatsHandler).Methods("GET")
+	router.HandleFunc(GetTransactionPoolItemHandlerPattern, apiHandler.GetTransactionPoolItemHandler).Methods("GET")
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	var txs []transaction.Transaction
+	for i := 1; i <= 5; i++ {
+		kp, tx := transaction.TestMakeTransaction(networkID, i)
+		tx.B.Fee = common.BaseFee.MustMult(i * 10)
+		tx.Sign(kp, networkID)
+		txs = append(txs, tx)
+	}
+	for _, tx := range txs {
+		require.NoError(t, pool.Add(tx))
+	}
+
+	get := func(url string) map[string]interface{} {
+		body := request(ts, url, false)
+		defer body.Close()
+		b, err := ioutil.ReadAll(body)
+		require.NoError(t, err)
+
+		result := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(b, &result))
+		return result
+	}
+	records := func(result map[string]interface{}) (hashes []string) {
+		for _, r := range result["_embedded"].(map[string]interface{})["records"].([]interface{}) {
+			hashes = append(hashes, r.(map[string]interface{})["hash"].(string))
+		}
+		return
+	}
+
+	{ // first page
+		result := get(GetTransactionPoolHandlerPattern + "?limit=2")
+		require.Equal(t, []string{txs[0].GetHash(), txs[1].GetHash()}, records(result))
+
+		// next page
+		next := result["_links"].(map[string]interface{})["next"].(map[string]interface{})["href"].(string)
+		result = get(next)
+		require.Equal(t, []string{txs[2].GetHash(), txs[3].GetHash()}, records(result))
+	}
+
+	{ // reverse
+		result := get(GetTransactionPoolHandlerPattern + "?limit=2&reverse=true")
+		require.Equal(t, []string{txs[4].GetHash(), txs[3].GetHash()}, records(result))
+	}
+
+	{ // item
+		result := get(strings.Replace(GetTransactionPoolItemHandlerPattern, "{id}", txs[1].GetHash(), -1))
+		require.Equal(t, txs[1].GetHash(), result["hash"])
+		require.Equal(t, txs[1].Source(), result["source"])
+		require.Equal(t, txs[1].B.Fee.String(), result["fee"])
+		require.Equal(t, float64(2), result["operation_count"])
+	}
+
+	{ // unknown item
+		resp, err := http.Get(ts.URL + strings.Replace(GetTransactionPoolItemHandlerPattern, "{id}", "unknown", -1))
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+
+	{ // stats
+		result := get(GetTransactionPoolStatsHandlerPattern)
+		require.Equal(t, float64(5), result["size"])
+		require.Equal(t, float64(15), result["operations"])
+
+		fee := result["fee"].(map[string]interface{})
+		require.Equal(t, txs[0].B.Fee.String(), fee["min"])
+		require.Equal(t, txs[4].B.Fee.String(), fee["max"])
+		require.Equal(t, txs[2].B.Fee.String(), fee["p50"])
+		require.Equal(t, txs[4].B.Fee.String(), fee["p90"])
+	}
+}
@@ -56,3 +56,32 @@ func (api NetworkHandlerAPI) PostTransactionsHandler(
 		httputils.WriteJSONError(w, err)
 	}
 }
+
+// PostTransactionSimulateHandler runs the transaction against the current state
+// by `simulate`; the transaction is not added to the pool and not broadcasted.
+// The invalid transaction is also responded with the errors of simulation.
+func (api NetworkHandlerAPI) PostTransactionSimulateHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+	simulate func([]byte) (*resource.TransactionSimulation, error),
+) {
+	defer r.Body.Close()
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	var result *resource.TransactionSimulation
+	if result, err = simulate(body); err != nil {
+		if _, ok := err.(*errors.Error); !ok {
+			err = errors.HTTPProblem.Clone().SetData("error", err.Error())
+		}
+
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	httputils.MustWriteJSON(w, 200, result)
+}
@@ -9,8 +9,12 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/client"
 	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
 	"boscoin.io/sebak/lib/node/runner/api/resource"
+	"boscoin.io/sebak/lib/transaction"
 )
 
 func TestGetTransactionByHashHandler(t *testing.T) {
@@ -91,6 +95,73 @@ func TestGetTransactionStatusByHashHandler(t *testing.T) {
 	}
 }
 
+func TestGetTransactionProofHandler(t *testing.T) {
+	ts, storage := prepareAPIServer()
+	defer storage.Close()
+	defer ts.Close()
+
+	var txs []transaction.Transaction
+	var txHashes []string
+	for i := 0; i < 3; i++ {
+		_, tx := transaction.TestMakeTransaction(networkID, 1)
+		txs = append(txs, tx)
+		txHashes = append(txHashes, tx.GetHash())
+	}
+	theBlock := block.TestMakeNewBlockWithPrevBlock(block.GetLatestBlock(storage), txHashes)
+	theBlock.MustSave(storage)
+	for _, tx := range txs {
+		bt := block.NewBlockTransactionFromTransaction(theBlock.Hash, theBlock.Height, theBlock.ProposedTime, tx)
+		bt.MustSave(storage)
+	}
+
+	{ // unknown transaction
+		respBody := request(ts, strings.Replace(GetTransactionProofHandlerPattern, "{id}", "findme", -1), false)
+		defer respBody.Close()
+		readByte, err := ioutil.ReadAll(bufio.NewReader(respBody))
+		require.NoError(t, err)
+		require.Contains(t, string(readByte), "transaction does not exists")
+	}
+
+	for _, hash := range txHashes {
+		respBody := request(ts, strings.Replace(GetTransactionProofHandlerPattern, "{id}", hash, -1), false)
+		defer respBody.Close()
+		readByte, err := ioutil.ReadAll(bufio.NewReader(respBody))
+		require.NoError(t, err)
+
+		var proof client.TransactionProof
+		common.MustUnmarshalJSON(readByte, &proof)
+		require.Equal(t, theBlock.Hash, proof.Block)
+		require.Equal(t, theBlock.Header, proof.Header)
+		require.True(t, client.VerifyTransactionProof(hash, proof))
+
+		{ // the proof can not claim the other position
+			moved := proof
+			moved.Index = (proof.Index + 1) % uint64(len(proof.Transactions)+1)
+			require.False(t, client.VerifyTransactionProof(hash, moved))
+		}
+
+		{ // the forged header with the matching root is not linked to the block hash
+			forgedTxs := []string{hash, "findme"}
+			leaves := append([]string{theBlock.ProposerTransaction}, forgedTxs...)
+			mp, _ := common.MakeMerkleProof(leaves, 1)
+
+			forged := proof
+			forged.Header.TransactionsRoot = common.MakeMerkleRoot(leaves)
+			forged.Transactions = forgedTxs
+			forged.Index = mp.Index
+			forged.Siblings = mp.Siblings
+			require.False(t, client.VerifyTransactionProof(hash, forged))
+
+			// the forged proof itself is valid for the forged root
+			require.True(t, mp.Verify(hash, forged.Header.TransactionsRoot, uint64(len(leaves))))
+		}
+
+		// the proof is not valid for the other root
+		proof.Header.TransactionsRoot = theBlock.PrevBlockHash
+		require.False(t, client.VerifyTransactionProof(hash, proof))
+	}
+}
+
 func TestGetTransactionsHandler(t *testing.T) {
 	ts, storage := prepareAPIServer()
 	defer storage.Close()
@@ -259,3 +330,108 @@ func TestGetTransactionsHandlerPage(t *testing.T) {
 		}
 	}
 }
+
+func TestGetTransactionsByAccountHandlerWithMemo(t *testing.T) {
+	ts, storage := prepareAPIServer()
+	defer storage.Close()
+	defer ts.Close()
+
+	source := keypair.Random()
+	target := keypair.Random()
+	idTarget := keypair.Random()
+
+	var txs []transaction.Transaction
+	var txHashes []string
+	for _, value := range []string{"customer-1", "customer-2", "customer-1"} {
+		tx := transaction.TestMakeTransactionWithKeypair(networkID, 1, source, target)
+		memo, err := transaction.NewMemo(transaction.MemoText, value)
+		require.NoError(t, err)
+		tx.B.Memo = &memo
+		tx.Sign(source, networkID)
+		txs = append(txs, tx)
+		txHashes = append(txHashes, tx.GetHash())
+	}
+	{ // without memo
+		tx := transaction.TestMakeTransactionWithKeypair(networkID, 1, source, target)
+		txs = append(txs, tx)
+		txHashes = append(txHashes, tx.GetHash())
+	}
+	{ // id memo with leading zeros
+		tx := transaction.TestMakeTransactionWithKeypair(networkID, 1, source, idTarget)
+		memo, err := transaction.NewMemo(transaction.MemoID, "01")
+		require.NoError(t, err)
+		tx.B.Memo = &memo
+		tx.Sign(source, networkID)
+		txs = append(txs, tx)
+		txHashes = append(txHashes, tx.GetHash())
+	}
+
+	theBlock := block.TestMakeNewBlockWithPrevBlock(block.GetLatestBlock(storage), txHashes)
+	theBlock.MustSave(storage)
+	for _, tx := range txs {
+		bt := block.NewBlockTransactionFromTransaction(theBlock.Hash, theBlock.Height, theBlock.ProposedTime, tx)
+		bt.MustSave(storage)
+		require.NoError(t, bt.SaveBlockOperations(storage))
+	}
+
+	getRecords := func(address, query string) []interface{} {
+		url := strings.Replace(GetAccountTransactionsHandlerPattern, "{id}", address, -1) + query
+		respBody := request(ts, url, false)
+		defer respBody.Close()
+		readByte, err := ioutil.ReadAll(bufio.NewReader(respBody))
+		require.NoError(t, err)
+
+		recv := make(map[string]interface{})
+		common.MustUnmarshalJSON(readByte, &recv)
+		records, _ := recv["_embedded"].(map[string]interface{})["records"].([]interface{})
+		return records
+	}
+
+	{ // without memo filter
+		records := getRecords(target.Address(), "")
+		require.Equal(t, 4, len(records))
+	}
+
+	{ // filtered by memo of target
+		records := getRecords(target.Address(), "?memo=customer-1")
+		require.Equal(t, 2, len(records))
+		for i, r := range records {
+			bt := r.(map[string]interface{})
+			require.Equal(t, txHashes[i*2], bt["hash"])
+			memo := bt["memo"].(map[string]interface{})
+			require.Equal(t, string(transaction.MemoText), memo["type"])
+			require.Equal(t, "customer-1", memo["value"])
+		}
+	}
+
+	{ // filtered by memo of source
+		records := getRecords(source.Address(), "?memo=customer-2&memo_type=text")
+		require.Equal(t, 1, len(records))
+		require.Equal(t, txHashes[1], records[0].(map[string]interface{})["hash"])
+	}
+
+	{ // same value, but different type
+		records := getRecords(target.Address(), "?memo=1&memo_type=id")
+		require.Equal(t, 0, len(records))
+	}
+
+	{ // id memo is found by it's canonical number
+		for _, value := range []string{"1", "01", "0001"} {
+			records := getRecords(idTarget.Address(), "?memo="+value+"&memo_type=id")
+			require.Equal(t, 1, len(records))
+			require.Equal(t, txHashes[4], records[0].(map[string]interface{})["hash"])
+		}
+
+		records := getRecords(idTarget.Address(), "?memo=1")
+		require.Equal(t, 0, len(records))
+	}
+
+	{ // invalid memo
+		url := strings.Replace(GetAccountTransactionsHandlerPattern, "{id}", target.Address(), -1) + "?memo=customer&memo_type=id"
+		req, _ := http.NewRequest("GET", ts.URL+url, nil)
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strconv"
 
 	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/consensus"
 	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/transaction"
 )
@@ -17,11 +18,13 @@ const GetBlocksPattern = "/blocks"
 type NodeItemDataType string
 
 const (
-	NodeItemBlock            NodeItemDataType = "block"
-	NodeItemBlockHeader      NodeItemDataType = "block-header"
-	NodeItemBlockTransaction NodeItemDataType = "block-transaction"
-	NodeItemTransaction      NodeItemDataType = "transaction"
-	NodeItemError            NodeItemDataType = "error"
+	NodeItemBlock             NodeItemDataType = "block"
+	NodeItemBlockHeader       NodeItemDataType = "block-header"
+	NodeItemBlockTransaction  NodeItemDataType = "block-transaction"
+	NodeItemTransaction       NodeItemDataType = "transaction"
+	NodeItemEvidence          NodeItemDataType = "evidence"
+	NodeItemCommitCertificate NodeItemDataType = "commit-certificate"
+	NodeItemError             NodeItemDataType = "error"
 )
 
 func (nh NetworkHandlerNode) GetBlocksHandler(w http.ResponseWriter, r *http.Request) {
@@ -119,6 +122,14 @@ func (nh NetworkHandlerNode) GetBlocksHandler(w http.ResponseWriter, r *http.Req
 			nh.renderNodeItem(w, itemType, b)
 		}
 
+		if options.Certificate {
+			if c, err := block.GetCommitCertificate(nh.storage, b.Height); err == nil {
+				nh.renderNodeItem(w, NodeItemCommitCertificate, c)
+			} else if err != errors.CommitCertificateNotFound {
+				nh.renderNodeItem(w, NodeItemError, err)
+			}
+		}
+
 		if options.Mode == GetBlocksOptionsModeFull {
 			var err error
 			var tx block.BlockTransaction
@@ -183,6 +194,14 @@ func UnmarshalNodeItemResponse(d []byte) (itemType NodeItemDataType, b interface
 		var t transaction.Transaction
 		err = unmarshal(&t)
 		b = t
+	case NodeItemEvidence:
+		var t consensus.Evidence
+		err = unmarshal(&t)
+		b = t
+	case NodeItemCommitCertificate:
+		var t block.CommitCertificate
+		err = unmarshal(&t)
+		b = t
 	case NodeItemError:
 		var t errors.Error
 		err = unmarshal(&t)
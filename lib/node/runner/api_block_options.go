@@ -28,6 +28,7 @@ type GetBlocksOptions struct {
 	HeightRange [2]uint64
 	Hashes      []string
 	Mode        GetBlocksOptionsMode
+	Certificate bool // includes `block.CommitCertificate`
 }
 
 func NewGetBlocksOptionsFromRequest(r *http.Request) (options *GetBlocksOptions, err error) {
@@ -53,6 +54,9 @@ func NewGetBlocksOptionsFromRequest(r *http.Request) (options *GetBlocksOptions,
 		if err = options.parseGetBlocksOptionsMode(r); err != nil {
 			return
 		}
+		if err = options.parseCertificate(r); err != nil {
+			return
+		}
 	}
 
 	return
@@ -162,8 +166,21 @@ func (g *GetBlocksOptions) parseGetBlocksOptionsMode(r *http.Request) error {
 	return nil
 }
 
+func (g *GetBlocksOptions) parseCertificate(r *http.Request) (err error) {
+	s := r.URL.Query().Get("certificate")
+	if len(s) < 1 {
+		return
+	}
+
+	if g.Certificate, err = strconv.ParseBool(s); err != nil {
+		return fmt.Errorf("invalid `certificate` value: %v", err)
+	}
+
+	return
+}
+
 func (g GetBlocksOptions) Template() string {
-	return "{?cursor,limit,reverse,height-range,hash,mode}"
+	return "{?cursor,limit,reverse,height-range,hash,mode,certificate}"
 }
 
 func (g GetBlocksOptions) URLValues() url.Values {
@@ -175,6 +192,9 @@ func (g GetBlocksOptions) URLValues() url.Values {
 		v["hash"] = g.Hashes
 	}
 	v.Set("mode", string(g.Mode))
+	if g.Certificate {
+		v.Set("certificate", "1")
+	}
 
 	return v
 }
@@ -189,6 +209,11 @@ func (g *GetBlocksOptions) SetHashes(h []string) *GetBlocksOptions {
 	return g
 }
 
+func (g *GetBlocksOptions) SetCertificate(c bool) *GetBlocksOptions {
+	g.Certificate = c
+	return g
+}
+
 func (g *GetBlocksOptions) SetMode(mode GetBlocksOptionsMode) *GetBlocksOptions {
 	g.Mode = mode
 	return g
@@ -0,0 +1,49 @@
+package runner
+
+import (
+	"net/http"
+	"strconv"
+
+	"boscoin.io/sebak/lib/client"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/consensus"
+	"boscoin.io/sebak/lib/errors"
+)
+
+const GetEvidencesPattern string = "/evidence"
+
+// GetEvidencesHandler returns the stored evidences of equivocation. With
+// `validator` query, only the evidences of the validator are returned.
+func (nh NetworkHandlerNode) GetEvidencesHandler(w http.ResponseWriter, r *http.Request) {
+	options, err := client.NewDefaultListOptionsFromQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, errors.InvalidQueryString.Error(), http.StatusBadRequest)
+		return
+	}
+
+	validator := r.URL.Query().Get("validator")
+	if len(validator) > 0 {
+		if _, err := keypair.Parse(validator); err != nil {
+			http.Error(w, errors.InvalidQueryString.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	var evidences []consensus.Evidence
+	iterFunc, closeFunc := consensus.GetEvidences(nh.storage, validator, options)
+	for {
+		e, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+		evidences = append(evidences, e)
+	}
+	closeFunc()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-SEBAK-RESULT-COUNT", strconv.FormatInt(int64(len(evidences)), 10))
+
+	for _, e := range evidences {
+		nh.renderNodeItem(w, NodeItemEvidence, e)
+	}
+}
@@ -0,0 +1,162 @@
+package runner
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/ballot"
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/consensus"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/voting"
+)
+
+func makeExpiredBallotMessage(nr *NodeRunner, source *node.LocalNode, proposer string) (ballot.Ballot, common.NetworkMessage) {
+	latestBlock := block.GetLatestBlock(nr.Storage())
+	basis := voting.Basis{
+		Round:     0,
+		Height:    latestBlock.Height,
+		BlockHash: latestBlock.Hash,
+		TotalTxs:  latestBlock.TotalTxs,
+		TotalOps:  latestBlock.TotalOps,
+	}
+
+	b := ballot.NewBallot(source.Address(), proposer, basis, []string{})
+	b.SetVote(ballot.StateSIGN, voting.EXP)
+	b.Sign(source.Keypair(), networkID)
+
+	data, _ := b.Serialize()
+	return *b, common.NetworkMessage{Type: common.BallotMessage, Data: data}
+}
+
+func TestBallotCheckEquivocation(t *testing.T) {
+	nr, nodes, _ := createNodeRunnerForTesting(3, common.NewTestConfig(), nil)
+
+	runChecker := func(message common.NetworkMessage) error {
+		checker := &BallotChecker{
+			DefaultChecker: common.DefaultChecker{Funcs: []common.CheckerFunc{
+				BallotUnmarshal,
+				BallotNotFromKnownValidators,
+				BallotCheckEquivocation,
+			}},
+			NodeRunner: nr,
+			Conf:       nr.Conf,
+			LocalNode:  nr.Node(),
+			Message:    message,
+			Log:        nr.Log(),
+			VotingHole: voting.NOTYET,
+		}
+		return common.RunChecker(checker, common.DefaultDeferFunc)
+	}
+
+	first, message := makeExpiredBallotMessage(nr, nodes[1], nodes[0].Address())
+	require.NoError(t, runChecker(message))
+
+	// same ballot again
+	require.NoError(t, runChecker(message))
+
+	// the ballot of the other validator
+	_, message = makeExpiredBallotMessage(nr, nodes[2], nodes[1].Address())
+	require.NoError(t, runChecker(message))
+
+	// different ballot for the same round
+	second, message := makeExpiredBallotMessage(nr, nodes[1], nodes[1].Address())
+	require.Equal(t, errors.BallotEquivocation, runChecker(message))
+
+	var evidences []consensus.Evidence
+	iterFunc, closeFunc := consensus.GetEvidences(nr.Storage(), "", nil)
+	for {
+		e, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+		evidences = append(evidences, e)
+	}
+	closeFunc()
+
+	require.Equal(t, 1, len(evidences))
+	require.Equal(t, nodes[1].Address(), evidences[0].Validator)
+	require.Equal(t, first.GetHash(), evidences[0].Ballots[0].GetHash())
+	require.Equal(t, second.GetHash(), evidences[0].Ballots[1].GetHash())
+	require.NoError(t, evidences[0].Verify(networkID))
+}
+
+func TestGetEvidencesHandler(t *testing.T) {
+	st := block.InitTestBlockchain()
+	defer st.Close()
+
+	nodeHandler := NetworkHandlerNode{storage: st}
+	router := mux.NewRouter()
+	router.HandleFunc(GetEvidencesPattern, nodeHandler.GetEvidencesHandler).Methods("GET")
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	kps := []*keypair.Full{keypair.Random(), keypair.Random()}
+	for _, kp := range kps {
+		for height := uint64(1); height < 3; height++ {
+			basis := voting.Basis{Height: height}
+			first := ballot.NewBallot(kp.Address(), kp.Address(), basis, []string{})
+			first.SetVote(ballot.StateACCEPT, voting.YES)
+			first.Sign(kp, networkID)
+			second := *first
+			second.SetVote(ballot.StateACCEPT, voting.NO)
+			second.Sign(kp, networkID)
+
+			require.NoError(t, consensus.NewEvidence(*first, second).Save(st))
+		}
+	}
+
+	request := func(query url.Values) (*http.Response, []interface{}) {
+		u, _ := url.Parse(server.URL)
+		u.Path = GetEvidencesPattern
+		u.RawQuery = query.Encode()
+
+		resp, err := http.Get(u.String())
+		require.NoError(t, err)
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return resp, nil
+		}
+
+		rbs, err := unmarshalFromNodeItemResponseBody(resp.Body)
+		require.NoError(t, err)
+		return resp, rbs[NodeItemEvidence]
+	}
+
+	{ // all
+		resp, items := request(url.Values{})
+		require.Equal(t, 4, len(items))
+		require.Equal(t, "4", resp.Header.Get("X-SEBAK-RESULT-COUNT"))
+		for _, item := range items {
+			require.NoError(t, item.(consensus.Evidence).Verify(networkID))
+		}
+	}
+
+	{ // by validator
+		_, items := request(url.Values{"validator": []string{kps[1].Address()}})
+		require.Equal(t, 2, len(items))
+		for i, item := range items {
+			e := item.(consensus.Evidence)
+			require.Equal(t, kps[1].Address(), e.Validator)
+			require.Equal(t, uint64(i+1), e.Height)
+		}
+	}
+
+	{ // with limit
+		_, items := request(url.Values{"limit": []string{"1"}})
+		require.Equal(t, 1, len(items))
+	}
+
+	{ // invalid validator
+		resp, _ := request(url.Values{"validator": []string{"showme"}})
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+}
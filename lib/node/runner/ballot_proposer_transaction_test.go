@@ -29,6 +29,9 @@ type ballotCheckerProposedTransaction struct {
 	txs      []transaction.Transaction
 	txHashes []string
 	keys     map[string]*keypair.Full
+
+	txFeeRecipients  []operation.TxFeeRecipient
+	delegatorRewards []operation.DelegatorReward
 }
 
 func (p *ballotCheckerProposedTransaction) Prepare() {
@@ -83,13 +86,35 @@ func (p *ballotCheckerProposedTransaction) MakeBallot(numberOfTxs int) (blt *bal
 	opc, _ := ballot.NewCollectTxFeeFromBallot(*blt, p.commonAccount.Address, p.txs...)
 	opi, _ := ballot.NewInflationFromBallot(*blt, p.commonAccount.Address, p.initialBalance)
 
-	ptx, err := ballot.NewProposerTransactionFromBallot(*blt, opc, opi)
+	var optionals []operation.Body
+	if len(p.txFeeRecipients) > 0 {
+		var opd operation.DistributeTxFee
+		var found bool
+		if opc, opd, found = ballot.NewDistributeTxFeeFromBallot(*blt, opc, p.config.TxFeeValidatorShare, p.txFeeRecipients); found {
+			optionals = append(optionals, opd)
+		}
+	}
+	if len(p.delegatorRewards) > 0 {
+		optionals = append(optionals, ballot.NewDelegationRewardFromBallot(*blt, p.delegatorRewards))
+	}
+
+	ptx, err := ballot.NewProposerTransactionFromBallot(*blt, opc, opi, optionals...)
 	if err != nil {
 		panic(err)
 	}
 
 	blt.SetProposerTransaction(ptx)
 	blt.SetVote(ballot.StateINIT, voting.YES)
+
+	var txs []*transaction.Transaction
+	for i := range p.txs {
+		txs = append(txs, &p.txs[i])
+	}
+	stateRoot, err := getBallotStateRoot(p.nr.Storage(), *blt, txs, p.nr.Log())
+	if err != nil {
+		panic(err)
+	}
+	blt.SetStateRoot(stateRoot)
 	blt.Sign(p.proposerNode.Keypair(), networkID)
 
 	return
@@ -951,3 +976,248 @@ func TestProposedTransactionReachedBlockHeightEndOfInflation(t *testing.T) {
 		require.Equal(t, errors.InvalidOperation, err)
 	}
 }
+
+func (p *ballotCheckerProposedTransaction) SetTxFeeDistribution(share uint64, distribution string) {
+	p.config.TxFeeValidatorShare = share
+	p.config.TxFeeDistribution = distribution
+	p.nr.Conf.TxFeeValidatorShare = share
+	p.nr.Conf.TxFeeDistribution = distribution
+}
+
+func (p *ballotCheckerProposedTransaction) CheckINITBallot(blt *ballot.Ballot) (voting.Hole, error) {
+	b, _ := blt.Serialize()
+	ballotMessage := common.NetworkMessage{Type: common.BallotMessage, Data: b}
+
+	baseChecker := &BallotChecker{
+		DefaultChecker: common.DefaultChecker{Funcs: DefaultHandleBaseBallotCheckerFuncs},
+		NodeRunner:     p.nr,
+		Conf:           p.nr.Conf,
+		LocalNode:      p.nr.Node(),
+		Message:        ballotMessage,
+		Log:            p.nr.Log(),
+		VotingHole:     voting.NOTYET,
+	}
+	if err := common.RunChecker(baseChecker, common.DefaultDeferFunc); err != nil {
+		return voting.NOTYET, err
+	}
+
+	checker := &BallotChecker{
+		DefaultChecker: common.DefaultChecker{Funcs: DefaultHandleINITBallotCheckerFuncs},
+		NodeRunner:     p.nr,
+		Conf:           p.nr.Conf,
+		LocalNode:      p.nr.Node(),
+		Message:        ballotMessage,
+		Ballot:         baseChecker.Ballot,
+		VotingHole:     voting.NOTYET,
+		Log:            p.nr.Log(),
+	}
+	err := common.RunChecker(checker, common.DefaultDeferFunc)
+
+	return checker.VotingHole, err
+}
+
+// resign replaces the proposer transaction of ballot and signs it again.
+func (p *ballotCheckerProposedTransaction) resign(blt *ballot.Ballot, ptx ballot.ProposerTransaction) {
+	ptx.H.Hash = ptx.B.MakeHashString()
+	ptx.Sign(p.proposerNode.Keypair(), networkID)
+	blt.SetProposerTransaction(ptx)
+	blt.Sign(p.proposerNode.Keypair(), networkID)
+}
+
+func TestProposedTransactionDistributeTxFeeToProposer(t *testing.T) {
+	p := &ballotCheckerProposedTransaction{}
+	p.Prepare()
+	p.SetTxFeeDistribution(30, common.TxFeeDistributionProposer)
+
+	proposerAccount := block.NewBlockAccount(p.proposerNode.Address(), common.BaseReserve)
+	proposerAccount.MustSave(p.nr.Storage())
+
+	p.txFeeRecipients = []operation.TxFeeRecipient{{Address: p.proposerNode.Address()}}
+	blt := p.MakeBallot(3)
+
+	opc, _ := blt.ProposerTransaction().CollectTxFee()
+	opd, found := blt.ProposerTransaction().DistributeTxFee()
+	require.True(t, found)
+	require.Equal(t, 1, len(opd.Recipients))
+
+	fee := common.BaseFee.MustMult(3)
+	each, rest := ballot.SplitTxFee(fee, 30, 1)
+	require.Equal(t, fee.MustMult(30)/100, each)
+	require.Equal(t, each, opd.Recipients[0].Amount)
+	require.Equal(t, rest, opc.Amount)
+
+	require.NoError(t, blt.ProposerTransaction().IsWellFormedWithBallot(*blt, p.config))
+
+	hole, err := p.CheckINITBallot(blt)
+	require.NoError(t, err)
+	require.Equal(t, voting.YES, hole)
+
+	{ // without `DistributeTxFee`
+		blt := p.MakeBallot(3)
+		ptx := blt.ProposerTransaction()
+		ptx.B.Operations = ptx.B.Operations[:2]
+		opc := ptx.B.Operations[0].B.(operation.CollectTxFee)
+		opc.Amount = fee
+		ptx.B.Operations[0].B = opc
+		p.resign(blt, ptx)
+
+		checker := &BallotChecker{NodeRunner: p.nr, Conf: p.nr.Conf, LocalNode: p.nr.Node(), Ballot: *blt, Log: p.nr.Log()}
+		require.Equal(t, errors.InvalidOperation, BallotValidateOperationBodyCollectTxFee(checker))
+	}
+
+	{ // wrong recipient
+		blt := p.MakeBallot(3)
+		ptx := blt.ProposerTransaction()
+		opd := ptx.B.Operations[2].B.(operation.DistributeTxFee)
+		opd.Recipients = []operation.TxFeeRecipient{{Address: p.nr.Node().Address(), Amount: opd.Recipients[0].Amount}}
+		ptx.B.Operations[2].B = opd
+		p.resign(blt, ptx)
+
+		checker := &BallotChecker{NodeRunner: p.nr, Conf: p.nr.Conf, LocalNode: p.nr.Node(), Ballot: *blt, Log: p.nr.Log()}
+		require.Equal(t, errors.InvalidOperation, BallotValidateOperationBodyCollectTxFee(checker))
+	}
+
+	{ // wrong amount
+		blt := p.MakeBallot(3)
+		ptx := blt.ProposerTransaction()
+		opd := ptx.B.Operations[2].B.(operation.DistributeTxFee)
+		opd.Recipients[0].Amount = opd.Recipients[0].Amount + 1
+		ptx.B.Operations[2].B = opd
+		p.resign(blt, ptx)
+
+		checker := &BallotChecker{NodeRunner: p.nr, Conf: p.nr.Conf, LocalNode: p.nr.Node(), Ballot: *blt, Log: p.nr.Log()}
+		require.Equal(t, errors.InvalidFee, BallotValidateOperationBodyCollectTxFee(checker))
+	}
+}
+
+func TestProposedTransactionDistributeTxFeeToSigners(t *testing.T) {
+	p := &ballotCheckerProposedTransaction{}
+	p.Prepare()
+	p.SetTxFeeDistribution(50, common.TxFeeDistributionSigners)
+
+	// commit certificate of the basis block
+	var signatures []block.CommitSignature
+	for _, v := range p.nr.Node().GetValidators() {
+		var n *node.LocalNode
+		if v.Address() == p.proposerNode.Address() {
+			n = p.proposerNode
+		} else {
+			n = p.nr.Node()
+		}
+		block.NewBlockAccount(n.Address(), common.BaseReserve).MustSave(p.nr.Storage())
+
+		signature, _ := n.Keypair().Sign(append(networkID, []byte(p.genesisBlock.Hash)...))
+		signatures = append(signatures, block.CommitSignature{Validator: n.Address(), Signature: base58.Encode(signature)})
+	}
+	require.NoError(t, block.NewCommitCertificate(p.genesisBlock, signatures).Save(p.nr.Storage()))
+
+	blt := p.MakeBallot(0)
+	p.txFeeRecipients = getTxFeeRecipients(p.nr.Storage(), p.nr.Conf, *blt, p.nr.Node().GetValidators(), p.nr.Policy().Threshold())
+	require.Equal(t, 2, len(p.txFeeRecipients))
+
+	blt = p.MakeBallot(3)
+
+	opc, _ := blt.ProposerTransaction().CollectTxFee()
+	opd, found := blt.ProposerTransaction().DistributeTxFee()
+	require.True(t, found)
+
+	fee := common.BaseFee.MustMult(3)
+	each, rest := ballot.SplitTxFee(fee, 50, 2)
+	require.Equal(t, fee, each.MustMult(2)+rest)
+	require.Equal(t, rest, opc.Amount)
+	for _, r := range opd.Recipients {
+		require.Equal(t, each, r.Amount)
+	}
+
+	hole, err := p.CheckINITBallot(blt)
+	require.NoError(t, err)
+	require.Equal(t, voting.YES, hole)
+
+	{ // wrong signature
+		blt := p.MakeBallot(3)
+		ptx := blt.ProposerTransaction()
+		opd := ptx.B.Operations[2].B.(operation.DistributeTxFee)
+		opd.Recipients[0].Signature = opd.Recipients[1].Signature
+		ptx.B.Operations[2].B = opd
+		p.resign(blt, ptx)
+
+		checker := &BallotChecker{NodeRunner: p.nr, Conf: p.nr.Conf, LocalNode: p.nr.Node(), Ballot: *blt, Log: p.nr.Log()}
+		require.Equal(t, errors.InvalidOperation, BallotValidateOperationBodyCollectTxFee(checker))
+	}
+
+	{ // under threshold
+		blt := p.MakeBallot(3)
+		ptx := blt.ProposerTransaction()
+		opd := ptx.B.Operations[2].B.(operation.DistributeTxFee)
+		opd.Recipients = opd.Recipients[:1]
+		ptx.B.Operations[2].B = opd
+		p.resign(blt, ptx)
+
+		checker := &BallotChecker{NodeRunner: p.nr, Conf: p.nr.Conf, LocalNode: p.nr.Node(), Ballot: *blt, Log: p.nr.Log()}
+		require.Equal(t, errors.InvalidOperation, BallotValidateOperationBodyCollectTxFee(checker))
+	}
+
+	{ // without distribution, the whole fee goes to common account
+		recipients := p.txFeeRecipients
+		p.txFeeRecipients = nil
+		blt := p.MakeBallot(3)
+		p.txFeeRecipients = recipients
+
+		_, found := blt.ProposerTransaction().DistributeTxFee()
+		require.False(t, found)
+
+		checker := &BallotChecker{NodeRunner: p.nr, Conf: p.nr.Conf, LocalNode: p.nr.Node(), Ballot: *blt, Log: p.nr.Log()}
+		require.NoError(t, BallotValidateOperationBodyCollectTxFee(checker))
+	}
+
+	{ // signer is not validator
+		blt := p.MakeBallot(3)
+		ptx := blt.ProposerTransaction()
+		opd := ptx.B.Operations[2].B.(operation.DistributeTxFee)
+
+		kp := keypair.Random()
+		block.NewBlockAccount(kp.Address(), common.BaseReserve).MustSave(p.nr.Storage())
+		signature, _ := kp.Sign(append(networkID, []byte(p.genesisBlock.Hash)...))
+		opd.Recipients[0] = operation.TxFeeRecipient{
+			Address:   kp.Address(),
+			Amount:    opd.Recipients[0].Amount,
+			Signature: base58.Encode(signature),
+		}
+		ptx.B.Operations[2].B = opd
+		p.resign(blt, ptx)
+
+		checker := &BallotChecker{NodeRunner: p.nr, Conf: p.nr.Conf, LocalNode: p.nr.Node(), Ballot: *blt, Log: p.nr.Log()}
+		require.Equal(t, errors.InvalidOperation, BallotValidateOperationBodyCollectTxFee(checker))
+	}
+
+	{ // the commit certificate of local storage has the different signatures
+		blt := p.MakeBallot(3)
+
+		require.NoError(t, block.NewCommitCertificate(p.genesisBlock, signatures[:1]).Save(p.nr.Storage()))
+		require.Equal(t, 0, len(getTxFeeRecipients(p.nr.Storage(), p.nr.Conf, *blt, p.nr.Node().GetValidators(), p.nr.Policy().Threshold())))
+
+		checker := &BallotChecker{NodeRunner: p.nr, Conf: p.nr.Conf, LocalNode: p.nr.Node(), Ballot: *blt, Log: p.nr.Log()}
+		require.NoError(t, BallotValidateOperationBodyCollectTxFee(checker))
+
+		require.NoError(t, block.NewCommitCertificate(p.genesisBlock, signatures).Save(p.nr.Storage()))
+	}
+
+	{ // the distributed fee is deposited
+		blt := p.MakeBallot(3)
+		opd, _ := blt.ProposerTransaction().DistributeTxFee()
+
+		var previous []*block.BlockAccount
+		for _, r := range opd.Recipients {
+			account, _ := block.GetBlockAccount(p.nr.Storage(), r.Address)
+			previous = append(previous, account)
+		}
+
+		_, _, err := finishBallot(p.nr, *blt, p.nr.Log())
+		require.NoError(t, err)
+
+		for i, r := range opd.Recipients {
+			account, _ := block.GetBlockAccount(p.nr.Storage(), r.Address)
+			require.Equal(t, previous[i].Balance+each, account.Balance)
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"sort"
+	"time"
 
 	"boscoin.io/sebak/lib/node/runner/api"
 
@@ -15,6 +17,7 @@ import (
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/consensus"
 	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/metrics"
 	"boscoin.io/sebak/lib/node"
 	"boscoin.io/sebak/lib/storage"
 	"boscoin.io/sebak/lib/transaction"
@@ -92,7 +95,7 @@ func BallotUnmarshal(c common.Checker, args ...interface{}) (err error) {
 }
 
 // BallotValidateOperationBodyCollectTxFee validates
-// `CollectTxFee`.
+// `CollectTxFee` and `DistributeTxFee`.
 func BallotValidateOperationBodyCollectTxFee(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*BallotChecker)
 
@@ -111,10 +114,20 @@ func BallotValidateOperationBodyCollectTxFee(c common.Checker, args ...interface
 		return
 	}
 
+	err = checkDistributeTxFee(
+		checker.NodeRunner.Storage(),
+		checker.Conf,
+		checker.Ballot,
+		opb,
+		checker.LocalNode.GetValidators(),
+		checker.NodeRunner.Policy().Threshold(),
+	)
+
 	return
 }
 
-// BallotValidateOperationBodyInflation validates `Inflation`
+// BallotValidateOperationBodyInflation validates `Inflation` and
+// `DelegationReward`.
 func BallotValidateOperationBodyInflation(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*BallotChecker)
 
@@ -155,6 +168,14 @@ func BallotValidateOperationBodyInflation(c common.Checker, args ...interface{})
 		return
 	}
 
+	err = checkDelegationReward(
+		checker.NodeRunner.Storage(),
+		checker.Conf,
+		checker.Ballot,
+		opb,
+		checker.LocalNode.GetValidators(),
+	)
+
 	return
 }
 
@@ -176,6 +197,36 @@ func BallotNotFromKnownValidators(c common.Checker, args ...interface{}) (err er
 	return
 }
 
+// BallotCheckEquivocation checks the source of incoming ballot already signed
+// the different ballot for the same round and ballot state. The found
+// evidence is stored and the ballot is rejected.
+func BallotCheckEquivocation(c common.Checker, args ...interface{}) (err error) {
+	checker := c.(*BallotChecker)
+	if checker.IsMine {
+		return
+	}
+
+	evidence, found := checker.NodeRunner.equivocationDetector.Detect(checker.Ballot)
+	if !found {
+		return
+	}
+
+	checker.Log.Error(
+		"equivocation found",
+		"validator", evidence.Validator,
+		"first", evidence.Ballots[0].GetHash(),
+		"second", evidence.Ballots[1].GetHash(),
+	)
+	metrics.Consensus.AddEquivocation(evidence.Validator)
+
+	if err = evidence.Save(checker.NodeRunner.Storage()); err != nil {
+		return
+	}
+
+	err = errors.BallotEquivocation
+	return
+}
+
 // BallotCheckSYNC performs sync by considering sync condition.
 // And to participate in the consensus,
 // update the latestblock by referring to the database.
@@ -279,6 +330,10 @@ func BallotCheckSYNC(c common.Checker, args ...interface{}) error {
 		}
 		checker.NodeRunner.SavingBlockOperations().Save(*blk)
 
+		if err = saveCommitCertificate(checker, *blk); err != nil {
+			checker.Log.Error("failed to save commit certificate", "block", blk.Hash, "error", err)
+		}
+
 		checker.NodeRunner.TransitISAACState(b.VotingBasis(), ballot.StateALLCONFIRM)
 		log.Debug("finish current ballot; latestHeight == syncHeight-1", "ballot", b.GetHash())
 		blk, _, err = finishBallot(checker.NodeRunner, b, checker.Log)
@@ -288,6 +343,10 @@ func BallotCheckSYNC(c common.Checker, args ...interface{}) error {
 		}
 		checker.NodeRunner.SavingBlockOperations().Save(*blk)
 
+		if err = saveCommitCertificate(checker, *blk); err != nil {
+			checker.Log.Error("failed to save commit certificate", "block", blk.Hash, "error", err)
+		}
+
 		checker.NodeRunner.NextHeight()
 		return nil
 	} else {
@@ -300,8 +359,11 @@ func isBallotAcceptYesOrExp(b ballot.Ballot) bool {
 	return b.State() == ballot.StateACCEPT && (b.Vote() == voting.YES || b.Vote() == voting.EXP)
 }
 
+// hasBallotValidProposer checks the proposer of ballot; if the proposer can
+// not be decided, the ballot is not valid.
 func hasBallotValidProposer(is *consensus.ISAAC, b ballot.Ballot) bool {
-	return b.Proposer() == is.SelectProposer(b.VotingBasis().Height, b.VotingBasis().Round)
+	proposer := is.SelectProposer(b.VotingBasis().Height, b.VotingBasis().Round)
+	return len(proposer) > 0 && b.Proposer() == proposer
 }
 
 // BallotCheckBasis checks the incoming ballot in
@@ -474,6 +536,7 @@ func insertMissingTransaction(nr *NodeRunner, ballot ballot.Ballot) (err error)
 	}
 
 	var receivedTransaction []transaction.Transaction
+	received := map[string]transaction.Transaction{}
 	bf := bufio.NewReader(bytes.NewReader(body))
 	for {
 		var l []byte
@@ -504,10 +567,31 @@ func insertMissingTransaction(nr *NodeRunner, ballot ballot.Ballot) (err error)
 			return
 		}
 
-		if err = ValidateTx(nr.Storage(), nr.Conf, tx); err != nil {
+		received[tx.GetHash()] = tx
+	}
+
+	// the transactions of same source are validated in order of ballot
+	minFee := block.GetNextMinFee(nr.Storage(), nr.Conf.OpsInBallotLimit)
+	pending := map[string][]transaction.Transaction{}
+	for _, hash := range ballot.Transactions() {
+		tx, found := received[hash]
+		if !found {
+			if tx, found = nr.TransactionPool.Get(hash); !found {
+				tp, err := block.GetTransactionPool(nr.Storage(), hash)
+				if err != nil {
+					continue
+				}
+				tx = tp.Transaction()
+			}
+			pending[tx.B.Source] = append(pending[tx.B.Source], tx)
+			continue
+		}
+
+		if err = ValidateTxWithPending(nr.Storage(), nr.Conf, minFee, pending[tx.B.Source], tx); err != nil {
 			return
 		}
 
+		pending[tx.B.Source] = append(pending[tx.B.Source], tx)
 		receivedTransaction = append(receivedTransaction, tx)
 	}
 
@@ -551,8 +635,12 @@ var INITBallotTransactionCheckerFuncs = []common.CheckerFunc{
 	CheckMissingTransaction,
 	BallotTransactionsOperationLimit,
 	BallotTransactionsSameSource,
+	BallotTransactionsSequenceID,
+	BallotTransactionsMergedAccount,
+	BallotTransactionsDelegate,
 	BallotTransactionsOperationBodyCollectTxFee,
 	BallotTransactionsAllValid,
+	BallotTransactionsStateRoot,
 }
 
 // INITBallotValidateTransactions validates the
@@ -658,6 +746,10 @@ func ACCEPTBallotBroadcast(c common.Checker, args ...interface{}) (err error) {
 	newBallot.SetSource(checker.LocalNode.Address())
 	newBallot.SetVote(ballot.StateACCEPT, checker.FinishedVotingHole)
 	newBallot.Sign(checker.LocalNode.Keypair(), checker.Conf.NetworkID)
+	newBallot.H.BlockSignature = ""
+	if checker.FinishedVotingHole == voting.YES {
+		signACCEPTBallotBlock(checker, &newBallot)
+	}
 
 	if !checker.NodeRunner.Consensus().HasRunningRound(checker.Ballot.VotingBasis().Index()) {
 		err = errors.New("RunningRound not found")
@@ -675,6 +767,29 @@ func ACCEPTBallotBroadcast(c common.Checker, args ...interface{}) (err error) {
 	return
 }
 
+// signACCEPTBallotBlock signs the hash of block, which will be made from the
+// ballot. The signatures are collected into `block.CommitCertificate`.
+func signACCEPTBallotBlock(checker *BallotChecker, b *ballot.Ballot) {
+	proposedTransactions, err := getProposedTransactions(
+		checker.NodeRunner.Storage(),
+		b.Transactions(),
+		checker.NodeRunner.TransactionPool,
+	)
+	if err != nil {
+		checker.Log.Error("failed to get the proposed transactions for block signature", "error", err)
+		return
+	}
+
+	prevBlock, err := block.GetBlockByHeight(checker.NodeRunner.Storage(), b.VotingBasis().Height)
+	if err != nil {
+		checker.Log.Error("failed to get the previous block for block signature", "error", err)
+		return
+	}
+
+	blk := newBlockFromBallot(*b, proposedTransactions, block.NextMinFee(prevBlock.Header, checker.Conf.OpsInBallotLimit))
+	b.SignBlock(checker.LocalNode.Keypair(), checker.Conf.NetworkID, blk.Hash)
+}
+
 // TransitStateToACCEPT changes ISAACState to ACCEPT
 func TransitStateToACCEPT(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*BallotChecker)
@@ -706,9 +821,24 @@ func FinishedBallotStore(c common.Checker, args ...interface{}) error {
 		defer checker.NodeRunner.NextHeight()
 		checker.NodeRunner.Consensus().SetLatestVotingBasis(basis)
 
-		checker.NodeRunner.TransactionPool.RemoveFromSources(checker.LatestBlockSources...)
+		// the following transactions of same source are kept in pool; the
+		// merged account can not have the following transactions
+		for _, source := range checker.LatestBlockSources {
+			if ba, err := block.GetBlockAccount(checker.NodeRunner.Storage(), source); err == nil {
+				checker.NodeRunner.TransactionPool.RemoveStale(source, ba.SequenceID)
+			} else {
+				checker.NodeRunner.TransactionPool.RemoveFromSources(source)
+			}
+		}
+		if removed := checker.NodeRunner.TransactionPool.RemoveExpired(
+			checker.NodeRunner.Consensus().LatestBlock().Height+1,
+			time.Now(),
+		); len(removed) > 0 {
+			checker.Log.Debug("expired transactions removed from pool", "removed-transactions", len(removed))
+		}
 		checker.NodeRunner.Consensus().RemoveRunningRoundsLowerOrEqualHeight(basis.Height)
 		checker.NodeRunner.RemoveSendRecordsLowerThanOrEqualHeight(basis.Height)
+		checker.NodeRunner.RemoveEquivocationRecordsLowerThanOrEqualHeight(basis.Height)
 
 		err = NewCheckerStopCloseConsensus(checker, "ballot got consensus and will be stored")
 	case voting.NO, voting.EXP:
@@ -747,11 +877,34 @@ func saveBlock(checker *BallotChecker) error {
 	}
 	checker.NodeRunner.SavingBlockOperations().Save(*blk)
 
+	if err = saveCommitCertificate(checker, *blk); err != nil {
+		checker.Log.Error("failed to save commit certificate", "block", blk.Hash, "error", err)
+	}
+
 	go api.TriggerEvent(checker.NodeRunner.Storage(), proposedTransactions)
 
 	return nil
 }
 
+// saveCommitCertificate stores the block signatures of ACCEPT ballots as
+// `block.CommitCertificate`; the invalid signatures are dropped.
+func saveCommitCertificate(checker *BallotChecker, blk block.Block) error {
+	var signatures []block.CommitSignature
+	for source, signature := range checker.NodeRunner.Consensus().BlockSignatures(checker.Ballot) {
+		s := block.CommitSignature{Validator: source, Signature: signature}
+		if err := s.Verify(checker.Conf.NetworkID, blk.Hash); err != nil {
+			checker.Log.Debug("invalid block signature", "validator", source, "block", blk.Hash, "error", err)
+			continue
+		}
+		signatures = append(signatures, s)
+	}
+	sort.Slice(signatures, func(i, j int) bool {
+		return signatures[i].Validator < signatures[j].Validator
+	})
+
+	return block.NewCommitCertificate(blk, signatures).Save(checker.NodeRunner.Storage())
+}
+
 func isValidRound(st *storage.LevelDBBackend, r voting.Basis, log logging.Logger) (bool, error) {
 	latestBlock := block.GetLatestBlock(st)
 	if latestBlock.Height != r.Height {
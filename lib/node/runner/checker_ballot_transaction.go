@@ -4,6 +4,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"boscoin.io/sebak/lib/ballot"
 	"boscoin.io/sebak/lib/block"
@@ -130,12 +131,14 @@ func BallotTransactionsOperationLimit(c common.Checker, args ...interface{}) err
 }
 
 // BallotTransactionsSameSource checks there are transactions which has same
-// source in the `Transactions`.
+// source in the `Transactions`. The transactions of same source are allowed
+// only when they are in order of consecutive sequence ID and the previous one
+// does not merge the source account.
 func BallotTransactionsSameSource(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*BallotTransactionChecker)
 
 	var validTransactions []string
-	sources := map[string]bool{}
+	sources := map[string]transaction.Transaction{} // last transaction of source
 
 	var tx transaction.Transaction
 	var found bool
@@ -146,7 +149,7 @@ func BallotTransactionsSameSource(c common.Checker, args ...interface{}) (err er
 			continue
 		}
 
-		if found := common.InStringMap(sources, tx.B.Source); found {
+		if last, found := sources[tx.B.Source]; found && !isFollowingTransaction(last, tx) {
 			if !checker.CheckTransactionsOnly {
 				err = errors.TransactionSameSourceInBallot
 				return
@@ -154,7 +157,158 @@ func BallotTransactionsSameSource(c common.Checker, args ...interface{}) (err er
 			continue
 		}
 
-		sources[tx.B.Source] = true
+		sources[tx.B.Source] = tx
+		validTransactions = append(validTransactions, hash)
+	}
+	err = nil
+	checker.setValidTransactions(validTransactions)
+
+	return
+}
+
+// isFollowingTransaction checks `tx` can follow `last` of same source.
+func isFollowingTransaction(last, tx transaction.Transaction) bool {
+	if tx.B.SequenceID != last.B.SequenceID+1 {
+		return false
+	}
+	_, merged := last.AccountMerge()
+
+	return !merged
+}
+
+// BallotTransactionsSequenceID checks the first transaction of each source in
+// the `Transactions` has the current sequence ID of the source account; the
+// following ones are checked by `BallotTransactionsSameSource`, so the
+// transactions of same source can not skip the sequence ID.
+func BallotTransactionsSequenceID(c common.Checker, args ...interface{}) (err error) {
+	checker := c.(*BallotTransactionChecker)
+
+	var validTransactions []string
+	sources := map[string]bool{} // the checked sources
+
+	var tx transaction.Transaction
+	var found bool
+	for _, hash := range checker.ValidTransactions {
+		if tx, found, err = checker.transactionCache.Get(hash); err != nil {
+			return
+		} else if !found {
+			continue
+		}
+
+		if !sources[tx.B.Source] {
+			var ba *block.BlockAccount
+			if ba, err = block.GetBlockAccount(checker.NodeRunner.Storage(), tx.B.Source); err != nil {
+				err = errors.BlockAccountDoesNotExists
+			} else if !tx.IsValidSequenceID(ba.SequenceID) {
+				err = errors.TransactionInvalidSequenceID
+			}
+			if err != nil {
+				if !checker.CheckTransactionsOnly {
+					return
+				}
+				continue
+			}
+			sources[tx.B.Source] = true
+		}
+
+		validTransactions = append(validTransactions, hash)
+	}
+	err = nil
+	checker.setValidTransactions(validTransactions)
+
+	return
+}
+
+// BallotTransactionsMergedAccount checks there are transactions which send to
+// the account merged by `operation.AccountMerge` in the same ballot.
+func BallotTransactionsMergedAccount(c common.Checker, args ...interface{}) (err error) {
+	checker := c.(*BallotTransactionChecker)
+
+	var txs []transaction.Transaction
+	merged := map[string]bool{}
+
+	var tx transaction.Transaction
+	var found bool
+	for _, hash := range checker.ValidTransactions {
+		if tx, found, err = checker.transactionCache.Get(hash); err != nil {
+			return
+		} else if !found {
+			continue
+		}
+
+		txs = append(txs, tx)
+		if _, found = tx.AccountMerge(); found {
+			merged[tx.B.Source] = true
+		}
+	}
+
+	var validTransactions []string
+	for _, tx := range txs {
+		var isMergedTarget bool
+		for _, op := range tx.B.Operations {
+			if top, ok := op.B.(operation.Targetable); ok && common.InStringMap(merged, top.TargetAddress()) {
+				isMergedTarget = true
+				break
+			}
+		}
+
+		if isMergedTarget {
+			if !checker.CheckTransactionsOnly {
+				err = errors.AccountMergedInBallot
+				return
+			}
+			continue
+		}
+
+		validTransactions = append(validTransactions, tx.GetHash())
+	}
+	err = nil
+	checker.setValidTransactions(validTransactions)
+
+	return
+}
+
+// BallotTransactionsDelegate checks the `operation.Delegate` of transactions
+// targets the validator and the validator does not exceed
+// `common.MaxDelegatorsOfValidator` with the delegations in the same ballot.
+func BallotTransactionsDelegate(c common.Checker, args ...interface{}) (err error) {
+	checker := c.(*BallotTransactionChecker)
+
+	var validTransactions []string
+	pending := map[string]uint64{} // the new delegators of validator in ballot
+
+	var tx transaction.Transaction
+	var found bool
+	for _, hash := range checker.ValidTransactions {
+		if tx, found, err = checker.transactionCache.Get(hash); err != nil {
+			return
+		} else if !found {
+			continue
+		}
+
+		counted := map[string]uint64{}
+		if err = checkDelegateToValidator(checker.NodeRunner.localNode, tx); err == nil {
+			for _, op := range tx.B.Operations {
+				opb, ok := op.B.(operation.Delegate)
+				if !ok {
+					continue
+				}
+				if err = checkDelegatorsLimit(checker.NodeRunner.Storage(), opb.Validator, pending[opb.Validator]+counted[opb.Validator]); err != nil {
+					break
+				}
+				counted[opb.Validator]++
+			}
+		}
+		if err != nil {
+			if !checker.CheckTransactionsOnly {
+				return
+			}
+			continue
+		}
+
+		for validator, n := range counted {
+			pending[validator] += n
+		}
 		validTransactions = append(validTransactions, hash)
 	}
 	err = nil
@@ -174,10 +328,18 @@ func BallotTransactionsOperationBodyCollectTxFee(c common.Checker, args ...inter
 		return
 	}
 
+	// the validator share of fee is in `DistributeTxFee`
+	collected := opb.Amount
+	if opd, found := checker.Ballot.ProposerTransaction().DistributeTxFee(); found {
+		if collected, err = collected.Add(opd.GetAmount()); err != nil {
+			return
+		}
+	}
+
 	// check the colleted transaction fee is matched with
 	// `CollectTxFee.Amount`
 	if checker.Ballot.TransactionsLength() < 1 {
-		if opb.Amount != 0 {
+		if collected != 0 {
 			err = errors.InvalidOperation
 			return
 		}
@@ -194,7 +356,7 @@ func BallotTransactionsOperationBodyCollectTxFee(c common.Checker, args ...inter
 			}
 			fee = fee.MustAdd(tx.B.Fee)
 		}
-		if opb.Amount != fee {
+		if collected != fee {
 			err = errors.InvalidFee
 			return
 		}
@@ -204,9 +366,47 @@ func BallotTransactionsOperationBodyCollectTxFee(c common.Checker, args ...inter
 }
 
 // BallotTransactionsAllValid checks all the transactions are valid or not.
+// The `transaction.TimeBounds` of transactions are checked with the height
+// and the proposed time of ballot, and the fee must not be under the minimum
+// fee of the new block, see `block.NextMinFee`.
 func BallotTransactionsAllValid(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*BallotTransactionChecker)
 
+	height := checker.Ballot.VotingBasis().Height + 1
+	var proposedTime time.Time
+
+	var prevBlock block.Block
+	if prevBlock, err = block.GetBlockByHeight(checker.NodeRunner.Storage(), checker.Ballot.VotingBasis().Height); err != nil {
+		return
+	}
+	minFee := block.NextMinFee(prevBlock.Header, checker.Conf.OpsInBallotLimit)
+
+	var validTransactions []string
+	var tx transaction.Transaction
+	var found bool
+	for _, hash := range checker.ValidTransactions {
+		if tx, found, err = checker.transactionCache.Get(hash); err != nil {
+			return
+		} else if !found {
+			continue
+		}
+		if tx.B.TimeBounds != nil {
+			if proposedTime.IsZero() {
+				if proposedTime, err = common.ParseISO8601(checker.Ballot.ProposerConfirmed()); err != nil {
+					return
+				}
+			}
+			if err := tx.B.TimeBounds.Check(height, proposedTime); err != nil {
+				continue
+			}
+		}
+		if tx.B.Fee < tx.TotalMinFee(minFee) {
+			continue
+		}
+		validTransactions = append(validTransactions, hash)
+	}
+	checker.setValidTransactions(validTransactions)
+
 	if checker.allTransactionsValid() {
 		checker.VotingHole = voting.YES
 	} else {
@@ -216,6 +416,41 @@ func BallotTransactionsAllValid(c common.Checker, args ...interface{}) (err erro
 	return
 }
 
+// BallotTransactionsStateRoot checks the state root of ballot is matched with
+// the state root after the transactions and the proposer transaction are
+// applied.
+func BallotTransactionsStateRoot(c common.Checker, args ...interface{}) (err error) {
+	checker := c.(*BallotTransactionChecker)
+
+	if checker.VotingHole == voting.NO {
+		return
+	}
+
+	var transactions []*transaction.Transaction
+	for _, hash := range checker.Transactions {
+		var tx transaction.Transaction
+		var found bool
+		if tx, found, err = checker.transactionCache.Get(hash); err != nil {
+			return
+		} else if !found {
+			err = errors.TransactionNotFound
+			return
+		}
+		transactions = append(transactions, &tx)
+	}
+
+	var stateRoot string
+	if stateRoot, err = getBallotStateRoot(checker.NodeRunner.Storage(), checker.Ballot, transactions, checker.NodeRunner.Log()); err != nil {
+		return
+	}
+	if stateRoot != checker.Ballot.StateRoot() {
+		err = errors.StateRootDoesNotMatch
+		return
+	}
+
+	return
+}
+
 //
 // Validate the entirety of a transaction
 //
@@ -236,10 +471,20 @@ func BallotTransactionsAllValid(c common.Checker, args ...interface{}) (err erro
 //   tx = Transaction to check
 //
 func ValidateTx(st *storage.LevelDBBackend, config common.Config, tx transaction.Transaction) (err error) {
+	return ValidateTxWithPending(st, config, block.GetNextMinFee(st, config.OpsInBallotLimit), nil, tx)
+}
+
+// ValidateTxWithPending validates the transaction, which follows the pending
+// transactions of same source; `pending` is in order of sequence ID. The
+// source account is validated as if the pending transactions, which have the
+// lower sequence ID than `tx`, were already applied. `minFee` is the minimum
+// fee per operation of the next block; the caller gets it once for the
+// transactions of the same block, see `block.GetNextMinFee`.
+func ValidateTxWithPending(st *storage.LevelDBBackend, config common.Config, minFee common.Amount, pending []transaction.Transaction, tx transaction.Transaction) (err error) {
 	// check, source exists
 	var ba *block.BlockAccount
-	if ba, err = block.GetBlockAccount(st, tx.B.Source); err != nil {
-		return errors.BlockAccountDoesNotExists
+	if ba, err = getPendingSourceAccount(st, pending, tx); err != nil {
+		return
 	}
 
 	// check, version is correct
@@ -254,6 +499,11 @@ func ValidateTx(st *storage.LevelDBBackend, config common.Config, tx transaction
 		return
 	}
 
+	// check, signers have enough weight for the operations
+	if err = ValidateTxSigners(ba, tx); err != nil {
+		return
+	}
+
 	totalAmount := tx.TotalAmount(true)
 
 	// check, have enough balance at sequenceID
@@ -268,6 +518,72 @@ func ValidateTx(st *storage.LevelDBBackend, config common.Config, tx transaction
 		}
 	}
 
+	// check, fee is not under the minimum fee of the next block
+	if tx.B.Fee < tx.TotalMinFee(minFee) {
+		err = errors.InvalidFee
+		return
+	}
+
+	return
+}
+
+// getPendingSourceAccount returns the source account of `tx` after the pending
+// transactions, which have the lower sequence ID than `tx`, are applied.
+func getPendingSourceAccount(st *storage.LevelDBBackend, pending []transaction.Transaction, tx transaction.Transaction) (ba *block.BlockAccount, err error) {
+	if ba, err = block.GetBlockAccount(st, tx.B.Source); err != nil {
+		return nil, errors.BlockAccountDoesNotExists
+	}
+
+	for _, p := range pending {
+		if p.B.SequenceID >= tx.B.SequenceID {
+			break
+		}
+		if _, found := p.AccountMerge(); found {
+			return nil, errors.BlockAccountDoesNotExists
+		}
+		if err = ba.Withdraw(p.TotalAmount(true)); err != nil {
+			return nil, errors.TransactionExcessAbilityToPay
+		}
+		ba.IncreaseSequenceID()
+	}
+
+	return
+}
+
+//
+// Validate the signers of transaction
+//
+// The sum of weights of signers must reach the highest threshold of it's
+// operations. The signatures themselves are already verified by
+// `transaction.CheckVerifySignature`.
+//
+// Params:
+//   source = Account from where the transaction (and ops) come from
+//   tx = Transaction to check
+//
+func ValidateTxSigners(source *block.BlockAccount, tx transaction.Transaction) (err error) {
+	// without signers, `Body.Source` is the only signer
+	if !source.IsMultisig() && len(tx.H.Signatures) < 1 {
+		return
+	}
+
+	level := operation.ThresholdLow
+	for _, op := range tx.B.Operations {
+		if l := operation.GetThresholdLevel(op.H.Type); l > level {
+			level = l
+		}
+	}
+
+	var weight uint64
+	for _, signer := range tx.Signers() {
+		weight += uint64(source.SignerWeight(signer))
+	}
+
+	if weight < uint64(source.Threshold(level)) {
+		err = errors.NotEnoughSignatureWeight
+		return
+	}
+
 	return
 }
 
@@ -288,8 +604,8 @@ func ValidateOp(st *storage.LevelDBBackend, config common.Config, source *block.
 
 	var funcIsFrozenPayable = func(source *block.BlockAccount) (err error) {
 		// Unfreezing must be done after X period from unfreezing request
-		iterFunc, closeFunc := block.GetBlockOperationsBySource(st, source.Address, nil)
-		bo, _, _ := iterFunc() //Get the first operation submitted by the source(frozen) account
+		iterFunc, closeFunc := block.GetBlockOperationsBySourceAndType(st, source.Address, operation.TypeUnfreezingRequest, nil)
+		bo, _, _ := iterFunc() //Get the unfreezing request submitted by the source(frozen) account
 		closeFunc()
 		// Before unfreezing payment, unfreezing request shoud be saved
 		if bo.Type != operation.TypeUnfreezingRequest {
@@ -314,6 +630,11 @@ func ValidateOp(st *storage.LevelDBBackend, config common.Config, source *block.
 		if exists, err := block.ExistsBlockAccount(st, casted.Target); err == nil && exists {
 			return errors.BlockAccountAlreadyExists
 		}
+		// the merged account can not be created again, the transactions of
+		// the merged account could be replayed from the sequence ID 0
+		if block.IsMergedBlockAccount(st, casted.Target) {
+			return errors.BlockAccountMerged
+		}
 
 		if source.IsFrozen() {
 			if err = funcIsFrozenPayable(source); err != nil {
@@ -353,12 +674,65 @@ func ValidateOp(st *storage.LevelDBBackend, config common.Config, source *block.
 			return errors.UnfreezingFromInvalidAccount
 		}
 		// Repeated unfreeze request shoud be blocked after unfreeze request saved
-		iterFunc, closeFunc := block.GetBlockOperationsBySource(st, source.Address, nil)
+		iterFunc, closeFunc := block.GetBlockOperationsBySourceAndType(st, source.Address, operation.TypeUnfreezingRequest, nil)
 		bo, _, _ := iterFunc()
 		closeFunc()
 		if bo.Type == operation.TypeUnfreezingRequest {
 			return errors.UnfreezingRequestAlreadyReceived
 		}
+	case operation.TypeDelegate:
+		var ok bool
+		var casted operation.Delegate
+		if casted, ok = op.B.(operation.Delegate); !ok {
+			return errors.TypeOperationBodyNotMatched
+		}
+		// Only the stake of frozen account, which is not unfreezing can be
+		// delegated
+		if !source.IsFrozen() {
+			return errors.DelegationFromInvalidAccount
+		}
+		iterFunc, closeFunc := block.GetBlockOperationsBySourceAndType(st, source.Address, operation.TypeUnfreezingRequest, nil)
+		bo, _, _ := iterFunc()
+		closeFunc()
+		if bo.Type == operation.TypeUnfreezingRequest {
+			return errors.DelegationFromInvalidAccount
+		}
+		if d, err := block.GetDelegation(st, source.Address); err == nil && d.Validator == casted.Validator {
+			return errors.DelegationAlreadyExists
+		}
+		if err = checkDelegatorsLimit(st, casted.Validator, 0); err != nil {
+			return
+		}
+	case operation.TypeAccountMerge:
+		var ok bool
+		var casted operation.AccountMerge
+		if casted, ok = op.B.(operation.AccountMerge); !ok {
+			return errors.TypeOperationBodyNotMatched
+		}
+		if source.IsFrozen() {
+			return errors.AccountMergeFromFrozenAccount
+		}
+		// The account which has the alive frozen accounts can not be merged
+		iterFunc, closeFunc := block.GetBlockOperationsByLinked(st, source.Address, nil)
+		for {
+			bo, hasNext, _ := iterFunc()
+			if !hasNext {
+				break
+			}
+			if exists, _ := block.ExistsBlockAccount(st, bo.Target); exists {
+				closeFunc()
+				return errors.AccountMergeFromLinkedAccount
+			}
+		}
+		closeFunc()
+
+		var taccount *block.BlockAccount
+		if taccount, err = block.GetBlockAccount(st, casted.Target); err != nil {
+			return errors.BlockAccountDoesNotExists
+		}
+		if taccount.IsFrozen() {
+			return errors.FrozenAccountNoDeposit
+		}
 	case operation.TypeInflationPF:
 		var ok bool
 		var inflationPF operation.InflationPF
@@ -455,6 +829,18 @@ func ValidateOp(st *storage.LevelDBBackend, config common.Config, source *block.
 			return errors.InflationPFFundingAddressMissMatched
 		}
 
+	case operation.TypeSetSigners:
+		var ok bool
+		var casted operation.SetSigners
+		if casted, ok = op.B.(operation.SetSigners); !ok {
+			return errors.TypeOperationBodyNotMatched
+		}
+		// the key of source account is set by `MasterWeight`
+		for _, s := range casted.Signers {
+			if s.Address == source.Address {
+				return errors.InvalidOperation
+			}
+		}
 	case operation.TypeCongressVoting:
 		//the CongressAddress is owned by blockchainOS. It is temporally check.
 		//TODO: When a node of BosNet is operated by anonymous then it will be removed.
@@ -487,6 +873,24 @@ func ValidateOp(st *storage.LevelDBBackend, config common.Config, source *block.
 		if _, err = block.GetBlockOperationWithIndex(st, txHash, opIndex); err != nil {
 			return err
 		}
+	case operation.TypeValidatorUpdate:
+		//the CongressAddress is owned by blockchainOS. It is temporally check.
+		//TODO: When a node of BosNet is operated by anonymous then it will be removed.
+		if source.Address != config.CongressAccountAddress {
+			return errors.CongressAddressMisMatched
+		}
+
+		var ok bool
+		var casted operation.ValidatorUpdate
+		if casted, ok = op.B.(operation.ValidatorUpdate); !ok {
+			return errors.TypeOperationBodyNotMatched
+		}
+
+		// the update must be stored before the block of it's height; the
+		// next block is `latest + 1`
+		if casted.Height <= block.GetLatestBlock(st).Height+1 {
+			return errors.ValidatorUpdateHeightNotFuture
+		}
 
 	default:
 		return errors.UnknownOperationType
@@ -13,12 +13,13 @@ package runner
 
 import (
 	"testing"
+	"time"
 
+	"boscoin.io/sebak/lib/ballot"
 	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/common/keypair"
 	"boscoin.io/sebak/lib/errors"
-	"boscoin.io/sebak/lib/storage"
 	"boscoin.io/sebak/lib/transaction"
 	"boscoin.io/sebak/lib/transaction/operation"
 	"boscoin.io/sebak/lib/voting"
@@ -31,7 +32,7 @@ func TestValidateTxPaymentMissingBlockAccount(t *testing.T) {
 	kps := keypair.Random()
 	kpt := keypair.Random()
 
-	st := storage.NewTestStorage()
+	st := block.InitTestBlockchain()
 	defer st.Close()
 
 	tx := transaction.Transaction{
@@ -63,7 +64,7 @@ func TestValidateTxPaymentMissingBlockAccount(t *testing.T) {
 	require.Equal(t, ValidateTx(st, common.Config{}, tx), errors.BlockAccountDoesNotExists)
 
 	// Now just the target
-	st1 := storage.NewTestStorage()
+	st1 := block.InitTestBlockchain()
 	defer st1.Close()
 	bat := block.BlockAccount{
 		Address: kpt.Address(),
@@ -73,7 +74,7 @@ func TestValidateTxPaymentMissingBlockAccount(t *testing.T) {
 	require.Equal(t, ValidateTx(st1, common.Config{}, tx), errors.BlockAccountDoesNotExists)
 
 	// And finally, bot
-	st2 := storage.NewTestStorage()
+	st2 := block.InitTestBlockchain()
 	defer st2.Close()
 	bas.MustSave(st2)
 	bat.MustSave(st2)
@@ -85,7 +86,7 @@ func TestValidateTxWrongSequenceID(t *testing.T) {
 	kps := keypair.Random()
 	kpt := keypair.Random()
 
-	st := storage.NewTestStorage()
+	st := block.InitTestBlockchain()
 	defer st.Close()
 	bas := block.BlockAccount{
 		Address:    kps.Address(),
@@ -129,7 +130,7 @@ func TestValidateTxOverBalance(t *testing.T) {
 	kps := keypair.Random()
 	kpt := keypair.Random()
 
-	st := storage.NewTestStorage()
+	st := block.InitTestBlockchain()
 	defer st.Close()
 	bas := block.BlockAccount{
 		Address:    kps.Address(),
@@ -183,12 +184,126 @@ func TestValidateTxOverBalance(t *testing.T) {
 	require.Nil(t, ValidateTx(st, common.Config{}, tx))
 }
 
+func TestValidateTxMinFee(t *testing.T) {
+	kps := keypair.Random()
+	kpt := keypair.Random()
+
+	st := block.InitTestBlockchain()
+	defer st.Close()
+	conf := common.NewTestConfig()
+	conf.OpsInBallotLimit = 100
+
+	bas := block.BlockAccount{
+		Address: kps.Address(),
+		Balance: common.Amount(1 * common.AmountPerCoin),
+	}
+	bat := block.BlockAccount{
+		Address: kpt.Address(),
+		Balance: common.Amount(1 * common.AmountPerCoin),
+	}
+	bas.MustSave(st)
+	bat.MustSave(st)
+
+	tx := transaction.Transaction{
+		H: transaction.Header{
+			Version: common.TransactionVersionV1,
+			Created: common.NowISO8601(),
+		},
+		B: transaction.Body{
+			Source: kps.Address(),
+			Fee:    common.BaseFee,
+			Operations: []operation.Operation{
+				operation.Operation{
+					H: operation.Header{Type: operation.TypePayment},
+					B: operation.Payment{Target: kpt.Address(), Amount: common.Amount(10000)},
+				},
+			},
+		},
+	}
+	tx.H.Hash = tx.B.MakeHashString()
+
+	// after genesis, BaseFee
+	require.NoError(t, ValidateTx(st, conf, tx))
+
+	// the full block raises the minimum fee of the next block
+	genesis := block.GetLatestBlock(st)
+	blk := block.NewBlockWithFee(
+		kps.Address(),
+		voting.Basis{Height: genesis.Height + 1, BlockHash: genesis.Hash},
+		"",
+		nil,
+		"",
+		common.NowISO8601(),
+		common.BaseFee.MustMult(8),
+		uint64(conf.OpsInBallotLimit),
+	)
+	blk.MustSave(st)
+	require.Equal(t, errors.InvalidFee, ValidateTx(st, conf, tx))
+
+	tx.B.Fee = common.BaseFee.MustMult(9)
+	tx.H.Hash = tx.B.MakeHashString()
+	require.NoError(t, ValidateTx(st, conf, tx))
+}
+
+func TestValidateTxWithPending(t *testing.T) {
+	kps := keypair.Random()
+	kpt := keypair.Random()
+
+	st := block.InitTestBlockchain()
+	defer st.Close()
+	bas := block.BlockAccount{
+		Address:    kps.Address(),
+		Balance:    common.Amount(1 * common.AmountPerCoin),
+		SequenceID: 1,
+	}
+	bat := block.BlockAccount{
+		Address: kpt.Address(),
+		Balance: common.Amount(1 * common.AmountPerCoin),
+	}
+	bas.MustSave(st)
+	bat.MustSave(st)
+
+	makeTx := func(sequenceID uint64, amount common.Amount) transaction.Transaction {
+		op, _ := operation.NewOperation(operation.NewPayment(kpt.Address(), amount))
+		tx, _ := transaction.NewTransaction(kps.Address(), sequenceID, op)
+		tx.Sign(kps, networkID)
+		return tx
+	}
+
+	half := common.Amount(common.AmountPerCoin / 2)
+	tx1 := makeTx(1, half)
+	tx2 := makeTx(2, half.MustSub(common.BaseFee*2))
+	require.Equal(t, errors.TransactionInvalidSequenceID, ValidateTx(st, common.Config{}, tx2))
+	require.NoError(t, ValidateTxWithPending(st, common.Config{}, common.BaseFee, []transaction.Transaction{tx1}, tx2))
+
+	// the pending transactions, which have the same or higher sequence id, are
+	// not applied
+	require.NoError(t, ValidateTxWithPending(st, common.Config{}, common.BaseFee, []transaction.Transaction{tx1, tx2}, tx1))
+
+	// the balance after the pending transactions is not enough
+	tx2 = makeTx(2, half)
+	require.Equal(
+		t,
+		errors.TransactionExcessAbilityToPay,
+		ValidateTxWithPending(st, common.Config{}, common.BaseFee, []transaction.Transaction{tx1}, tx2),
+	)
+
+	// the fee is under the given minimum fee
+	tx2 = makeTx(2, common.Amount(1))
+	require.NoError(t, ValidateTxWithPending(st, common.Config{}, common.BaseFee, []transaction.Transaction{tx1}, tx2))
+	require.Equal(
+		t,
+		errors.InvalidFee,
+		ValidateTxWithPending(st, common.Config{}, common.BaseFee.MustMult(2), []transaction.Transaction{tx1}, tx2),
+	)
+}
+
 // Test creating an already existing account
 func TestValidateOpCreateExistsAccount(t *testing.T) {
 	kps := keypair.Random()
 	kpt := keypair.Random()
 
-	st := storage.NewTestStorage()
+	st := block.InitTestBlockchain()
 	defer st.Close()
 
 	bas := block.BlockAccount{
@@ -222,12 +337,97 @@ func TestValidateOpCreateExistsAccount(t *testing.T) {
 	tx.H.Hash = tx.B.MakeHashString()
 	require.Equal(t, ValidateTx(st, common.Config{}, tx), errors.BlockAccountAlreadyExists)
 
-	st1 := storage.NewTestStorage()
+	st1 := block.InitTestBlockchain()
 	defer st1.Close()
 	bas.MustSave(st1)
 	require.Nil(t, ValidateTx(st1, common.Config{}, tx))
 }
 
+// Test the weights of signers of multisig account
+func TestValidateTxMultisigAccount(t *testing.T) {
+	kps := keypair.Random()
+	kpt := keypair.Random()
+	kp0 := keypair.Random()
+	kp1 := keypair.Random()
+
+	st := block.InitTestBlockchain()
+	defer st.Close()
+
+	bas := block.BlockAccount{
+		Address: kps.Address(),
+		Balance: common.Amount(1 * common.AmountPerCoin),
+	}
+	bas.SetSigners(operation.NewSetSigners(
+		1,
+		operation.Thresholds{Low: 1, Medium: 2, High: 3},
+		operation.Signer{Address: kp0.Address(), Weight: 1},
+		operation.Signer{Address: kp1.Address(), Weight: 1},
+	))
+	bat := block.BlockAccount{
+		Address: kpt.Address(),
+		Balance: common.Amount(1 * common.AmountPerCoin),
+	}
+	bas.MustSave(st)
+	bat.MustSave(st)
+
+	tx := transaction.Transaction{
+		H: transaction.Header{
+			Version: common.TransactionVersionV1,
+			Created: common.NowISO8601(),
+		},
+		B: transaction.Body{
+			Source:     kps.Address(),
+			Fee:        common.BaseFee,
+			SequenceID: 0,
+			Operations: []operation.Operation{
+				operation.Operation{
+					H: operation.Header{Type: operation.TypePayment},
+					B: operation.Payment{Target: kpt.Address(), Amount: common.Amount(10000)},
+				},
+			},
+		},
+	}
+
+	// payment needs the medium threshold
+	tx.Sign(kps, networkID)
+	require.Equal(t, errors.NotEnoughSignatureWeight, ValidateTx(st, common.Config{}, tx))
+	tx.AddSignature(kp0, networkID)
+	require.Nil(t, ValidateTx(st, common.Config{}, tx))
+
+	// unknown signer does not have weight
+	tx.H.Signature = ""
+	tx.H.Signatures = nil
+	tx.AddSignature(kp0, networkID)
+	tx.AddSignature(keypair.Random(), networkID)
+	require.Equal(t, errors.NotEnoughSignatureWeight, ValidateTx(st, common.Config{}, tx))
+
+	// `SetSigners` needs the high threshold
+	tx.B.Operations = []operation.Operation{
+		operation.Operation{
+			H: operation.Header{Type: operation.TypeSetSigners},
+			B: operation.NewSetSigners(1, operation.Thresholds{Low: 1, Medium: 1, High: 1}),
+		},
+	}
+	tx.H.Signatures = nil
+	tx.Sign(kps, networkID)
+	tx.AddSignature(kp0, networkID)
+	require.Equal(t, errors.NotEnoughSignatureWeight, ValidateTx(st, common.Config{}, tx))
+	tx.AddSignature(kp1, networkID)
+	require.Nil(t, ValidateTx(st, common.Config{}, tx))
+
+	// the key of source can not be the additional signer
+	tx.B.Operations[0].B = operation.NewSetSigners(
+		1,
+		operation.Thresholds{Low: 1, Medium: 1, High: 1},
+		operation.Signer{Address: kps.Address(), Weight: 1},
+	)
+	tx.H.Signatures = nil
+	tx.Sign(kps, networkID)
+	tx.AddSignature(kp0, networkID)
+	tx.AddSignature(kp1, networkID)
+	require.Equal(t, errors.InvalidOperation, ValidateTx(st, common.Config{}, tx))
+}
+
 func TestOpsInBalotLimit(t *testing.T) {
 	var checkerFuncs = []common.CheckerFunc{
 		IsNew,
@@ -315,3 +515,567 @@ func TestOpsInBalotLimit(t *testing.T) {
 		require.Equal(t, errors.BallotHasOverMaxOperationsInBallot, err)
 	}
 }
+
+func TestBallotTransactionsAllValidTimeBounds(t *testing.T) {
+	var checkerFuncs = []common.CheckerFunc{
+		IsNew,
+		CheckMissingTransaction,
+		BallotTransactionsAllValid,
+	}
+
+	config := common.NewTestConfig()
+	nr := createTestNodeRunner(1, config)[0]
+	latestBlock := nr.Consensus().LatestBlock()
+
+	newChecker := func(tx transaction.Transaction) *BallotTransactionChecker {
+		basis := voting.Basis{Round: 0, Height: latestBlock.Height, BlockHash: latestBlock.Hash}
+		blt := ballot.NewBallot(nr.Node().Address(), nr.Node().Address(), basis, []string{tx.GetHash()})
+		blt.Sign(nr.Node().Keypair(), networkID)
+
+		return &BallotTransactionChecker{
+			DefaultChecker:   common.DefaultChecker{Funcs: checkerFuncs},
+			NodeRunner:       nr,
+			Conf:             nr.Conf,
+			LocalNode:        nr.Node(),
+			Ballot:           *blt,
+			Transactions:     blt.Transactions(),
+			VotingHole:       voting.NOTYET,
+			transactionCache: NewTransactionCache(nr.Storage(), nr.TransactionPool),
+		}
+	}
+
+	{ // without time bounds
+		_, tx := transaction.TestMakeTransaction(networkID, 1)
+		nr.TransactionPool.Add(tx)
+
+		checker := newChecker(tx)
+		require.NoError(t, common.RunChecker(checker, common.DefaultDeferFunc))
+		require.Equal(t, voting.YES, checker.VotingHole)
+	}
+
+	{ // in the height bounds
+		kp, tx := transaction.TestMakeTransaction(networkID, 1)
+		tx.B.TimeBounds = &transaction.TimeBounds{
+			MinHeight: latestBlock.Height + 1,
+			MaxHeight: latestBlock.Height + 1,
+		}
+		tx.Sign(kp, networkID)
+		nr.TransactionPool.Add(tx)
+
+		checker := newChecker(tx)
+		require.NoError(t, common.RunChecker(checker, common.DefaultDeferFunc))
+		require.Equal(t, voting.YES, checker.VotingHole)
+	}
+
+	{ // not valid yet
+		kp, tx := transaction.TestMakeTransaction(networkID, 1)
+		tx.B.TimeBounds = &transaction.TimeBounds{MinHeight: latestBlock.Height + 2}
+		tx.Sign(kp, networkID)
+		nr.TransactionPool.Add(tx)
+
+		checker := newChecker(tx)
+		require.NoError(t, common.RunChecker(checker, common.DefaultDeferFunc))
+		require.Equal(t, voting.NO, checker.VotingHole)
+	}
+
+	{ // expired by time
+		kp, tx := transaction.TestMakeTransaction(networkID, 1)
+		tx.B.TimeBounds = &transaction.TimeBounds{
+			MaxTime: common.FormatISO8601(time.Now().Add(-time.Minute)),
+		}
+		tx.Sign(kp, networkID)
+		nr.TransactionPool.Add(tx)
+
+		checker := newChecker(tx)
+		require.NoError(t, common.RunChecker(checker, common.DefaultDeferFunc))
+		require.Equal(t, voting.NO, checker.VotingHole)
+	}
+}
+
+func TestBallotTransactionsAllValidMinFee(t *testing.T) {
+	var checkerFuncs = []common.CheckerFunc{
+		IsNew,
+		CheckMissingTransaction,
+		BallotTransactionsAllValid,
+	}
+
+	config := common.NewTestConfig()
+	config.OpsInBallotLimit = 100
+	nr := createTestNodeRunner(1, config)[0]
+
+	// the operations are at target, so the minimum fee is not changed
+	latest := block.GetLatestBlock(nr.Storage())
+	blk := block.NewBlockWithFee(
+		latest.Proposer,
+		voting.Basis{Height: latest.Height + 1, BlockHash: latest.Hash, TotalTxs: latest.TotalTxs, TotalOps: latest.TotalOps},
+		"",
+		nil,
+		latest.StateRoot,
+		common.NowISO8601(),
+		common.BaseFee.MustMult(2),
+		50,
+	)
+	blk.MustSave(nr.Storage())
+
+	newChecker := func(tx transaction.Transaction) *BallotTransactionChecker {
+		basis := voting.Basis{Round: 0, Height: blk.Height, BlockHash: blk.Hash}
+		blt := ballot.NewBallot(nr.Node().Address(), nr.Node().Address(), basis, []string{tx.GetHash()})
+		blt.Sign(nr.Node().Keypair(), networkID)
+
+		return &BallotTransactionChecker{
+			DefaultChecker:   common.DefaultChecker{Funcs: checkerFuncs},
+			NodeRunner:       nr,
+			Conf:             nr.Conf,
+			LocalNode:        nr.Node(),
+			Ballot:           *blt,
+			Transactions:     blt.Transactions(),
+			VotingHole:       voting.NOTYET,
+			transactionCache: NewTransactionCache(nr.Storage(), nr.TransactionPool),
+		}
+	}
+
+	{ // under the minimum fee of the new block
+		_, tx := transaction.TestMakeTransaction(networkID, 1)
+		nr.TransactionPool.Add(tx)
+
+		checker := newChecker(tx)
+		require.NoError(t, common.RunChecker(checker, common.DefaultDeferFunc))
+		require.Equal(t, voting.NO, checker.VotingHole)
+	}
+
+	{ // enough fee
+		kp, tx := transaction.TestMakeTransaction(networkID, 1)
+		tx.B.Fee = common.BaseFee.MustMult(2)
+		tx.Sign(kp, networkID)
+		nr.TransactionPool.Add(tx)
+
+		checker := newChecker(tx)
+		require.NoError(t, common.RunChecker(checker, common.DefaultDeferFunc))
+		require.Equal(t, voting.YES, checker.VotingHole)
+	}
+}
+
+func TestValidateTxAccountMerge(t *testing.T) {
+	kps := keypair.Random()
+	kpt := keypair.Random()
+
+	st := block.InitTestBlockchain()
+	defer st.Close()
+
+	bas := block.BlockAccount{
+		Address: kps.Address(),
+		Balance: common.Amount(1 * common.AmountPerCoin),
+	}
+	bat := block.BlockAccount{
+		Address: kpt.Address(),
+		Balance: common.Amount(1 * common.AmountPerCoin),
+	}
+	bas.MustSave(st)
+
+	mergeOp, _ := operation.NewOperation(operation.NewAccountMerge(kpt.Address()))
+	tx, _ := transaction.NewTransaction(kps.Address(), 0, mergeOp)
+	tx.Sign(kps, networkID)
+
+	// target does not exist
+	require.Equal(t, errors.BlockAccountDoesNotExists, ValidateTx(st, common.Config{}, tx))
+
+	bat.MustSave(st)
+	require.Nil(t, ValidateTx(st, common.Config{}, tx))
+
+	{ // frozen target
+		frozen := block.NewBlockAccountLinked(kpt.Address(), common.Amount(1*common.AmountPerCoin), keypair.Random().Address())
+		st1 := block.InitTestBlockchain()
+		defer st1.Close()
+		bas.MustSave(st1)
+		frozen.MustSave(st1)
+		require.Equal(t, errors.FrozenAccountNoDeposit, ValidateTx(st1, common.Config{}, tx))
+	}
+
+	{ // frozen source
+		frozen := block.NewBlockAccountLinked(kps.Address(), common.Amount(1*common.AmountPerCoin), keypair.Random().Address())
+		st1 := block.InitTestBlockchain()
+		defer st1.Close()
+		frozen.MustSave(st1)
+		bat.MustSave(st1)
+		require.Equal(t, errors.AccountMergeFromFrozenAccount, ValidateTx(st1, common.Config{}, tx))
+	}
+
+	// source has the frozen account
+	kpf := keypair.Random()
+	createOp, _ := operation.NewOperation(operation.NewCreateAccount(kpf.Address(), common.BaseReserve, kps.Address()))
+	createTx, _ := transaction.NewTransaction(kps.Address(), 0, createOp)
+	bo, err := block.NewBlockOperationFromOperation(createOp, createTx, 1, 0)
+	require.NoError(t, err)
+	require.NoError(t, bo.Save(st))
+
+	frozen := block.NewBlockAccountLinked(kpf.Address(), common.BaseReserve, kps.Address())
+	frozen.MustSave(st)
+	require.Equal(t, errors.AccountMergeFromLinkedAccount, ValidateTx(st, common.Config{}, tx))
+}
+
+func TestValidateTxCreateMergedAccount(t *testing.T) {
+	st := block.InitTestBlockchain()
+	defer st.Close()
+
+	kps := keypair.Random()
+	kpMerged := keypair.Random()
+
+	bas := block.NewBlockAccount(kps.Address(), common.Amount(1*common.AmountPerCoin))
+	bas.MustSave(st)
+
+	createOp, _ := operation.NewOperation(operation.NewCreateAccount(kpMerged.Address(), common.BaseReserve, ""))
+	tx, _ := transaction.NewTransaction(kps.Address(), bas.SequenceID, createOp)
+	tx.Sign(kps, networkID)
+	require.NoError(t, ValidateTx(st, common.Config{}, tx))
+
+	// the account was merged
+	mergeOp, _ := operation.NewOperation(operation.NewAccountMerge(kps.Address()))
+	mergeTx, _ := transaction.NewTransaction(kpMerged.Address(), 0, mergeOp)
+	bo, err := block.NewBlockOperationFromOperation(mergeOp, mergeTx, 1, 0)
+	require.NoError(t, err)
+	require.NoError(t, bo.Save(st))
+
+	require.Equal(t, errors.BlockAccountMerged, ValidateTx(st, common.Config{}, tx))
+}
+
+
+func TestBallotTransactionsMergedAccount(t *testing.T) {
+	var checkerFuncs = []common.CheckerFunc{
+		IsNew,
+		CheckMissingTransaction,
+		BallotTransactionsMergedAccount,
+	}
+
+	config := common.NewTestConfig()
+	nr := createTestNodeRunner(1, config)[0]
+	latestBlock := nr.Consensus().LatestBlock()
+
+	kpMerged := keypair.Random()
+
+	// merge
+	mergeOp, _ := operation.NewOperation(operation.NewAccountMerge(keypair.Random().Address()))
+	txMerge, _ := transaction.NewTransaction(kpMerged.Address(), 0, mergeOp)
+	txMerge.Sign(kpMerged, networkID)
+	nr.TransactionPool.Add(txMerge)
+
+	// payment to the merged account
+	txPayment := transaction.TestMakeTransactionWithKeypair(networkID, 1, keypair.Random(), kpMerged)
+	nr.TransactionPool.Add(txPayment)
+
+	// the other payment
+	_, txOther := transaction.TestMakeTransaction(networkID, 1)
+	nr.TransactionPool.Add(txOther)
+
+	newChecker := func(checkTransactionsOnly bool) *BallotTransactionChecker {
+		basis := voting.Basis{Round: 0, Height: latestBlock.Height, BlockHash: latestBlock.Hash}
+		blt := ballot.NewBallot(
+			nr.Node().Address(),
+			nr.Node().Address(),
+			basis,
+			[]string{txMerge.GetHash(), txPayment.GetHash(), txOther.GetHash()},
+		)
+		blt.Sign(nr.Node().Keypair(), networkID)
+
+		return &BallotTransactionChecker{
+			DefaultChecker:        common.DefaultChecker{Funcs: checkerFuncs},
+			NodeRunner:            nr,
+			Conf:                  nr.Conf,
+			LocalNode:             nr.Node(),
+			Ballot:                *blt,
+			Transactions:          blt.Transactions(),
+			CheckTransactionsOnly: checkTransactionsOnly,
+			VotingHole:            voting.NOTYET,
+			transactionCache:      NewTransactionCache(nr.Storage(), nr.TransactionPool),
+		}
+	}
+
+	{ // the payment to the merged account is excluded
+		checker := newChecker(true)
+		require.NoError(t, common.RunChecker(checker, common.DefaultDeferFunc))
+		require.Equal(t, []string{txMerge.GetHash(), txOther.GetHash()}, checker.ValidTransactions)
+	}
+
+	{ // the ballot is not valid
+		checker := newChecker(false)
+		require.Equal(t, errors.AccountMergedInBallot, common.RunChecker(checker, common.DefaultDeferFunc))
+	}
+}
+
+func TestBallotTransactionsSameSource(t *testing.T) {
+	var checkerFuncs = []common.CheckerFunc{
+		IsNew,
+		CheckMissingTransaction,
+		BallotTransactionsSameSource,
+	}
+
+	config := common.NewTestConfig()
+	nr := createTestNodeRunner(1, config)[0]
+	latestBlock := nr.Consensus().LatestBlock()
+
+	kp := keypair.Random()
+	makeTx := func(sequenceID uint64) transaction.Transaction {
+		tx := transaction.TestMakeTransactionWithKeypair(networkID, 1, kp)
+		tx.B.SequenceID = sequenceID
+		tx.Sign(kp, networkID)
+		return tx
+	}
+
+	tx0 := makeTx(0)
+	tx1 := makeTx(1)
+	require.NoError(t, nr.TransactionPool.Add(tx0))
+	require.NoError(t, nr.TransactionPool.Add(tx1))
+
+	// sequence id gap
+	txGap := makeTx(3)
+	_, err := block.SaveTransactionPool(nr.Storage(), txGap)
+	require.NoError(t, err)
+
+	_, txOther := transaction.TestMakeTransaction(networkID, 1)
+	require.NoError(t, nr.TransactionPool.Add(txOther))
+
+	newChecker := func(checkTransactionsOnly bool, hashes ...string) *BallotTransactionChecker {
+		basis := voting.Basis{Round: 0, Height: latestBlock.Height, BlockHash: latestBlock.Hash}
+		blt := ballot.NewBallot(nr.Node().Address(), nr.Node().Address(), basis, hashes)
+		blt.Sign(nr.Node().Keypair(), networkID)
+
+		return &BallotTransactionChecker{
+			DefaultChecker:        common.DefaultChecker{Funcs: checkerFuncs},
+			NodeRunner:            nr,
+			Conf:                  nr.Conf,
+			LocalNode:             nr.Node(),
+			Ballot:                *blt,
+			Transactions:          blt.Transactions(),
+			CheckTransactionsOnly: checkTransactionsOnly,
+			VotingHole:            voting.NOTYET,
+			transactionCache:      NewTransactionCache(nr.Storage(), nr.TransactionPool),
+		}
+	}
+
+	{ // sequential transactions are valid
+		checker := newChecker(false, tx0.GetHash(), tx1.GetHash(), txOther.GetHash())
+		require.NoError(t, common.RunChecker(checker, common.DefaultDeferFunc))
+		require.Equal(t, []string{tx0.GetHash(), tx1.GetHash(), txOther.GetHash()}, checker.ValidTransactions)
+	}
+
+	{ // the transaction after gap is excluded
+		checker := newChecker(true, tx0.GetHash(), tx1.GetHash(), txGap.GetHash(), txOther.GetHash())
+		require.NoError(t, common.RunChecker(checker, common.DefaultDeferFunc))
+		require.Equal(t, []string{tx0.GetHash(), tx1.GetHash(), txOther.GetHash()}, checker.ValidTransactions)
+	}
+
+	{ // not in order of sequence id
+		checker := newChecker(false, tx1.GetHash(), tx0.GetHash())
+		require.Equal(t, errors.TransactionSameSourceInBallot, common.RunChecker(checker, common.DefaultDeferFunc))
+	}
+}
+
+func TestBallotTransactionsSequenceID(t *testing.T) {
+	var checkerFuncs = []common.CheckerFunc{
+		IsNew,
+		CheckMissingTransaction,
+		BallotTransactionsSameSource,
+		BallotTransactionsSequenceID,
+	}
+
+	config := common.NewTestConfig()
+	nr := createTestNodeRunner(1, config)[0]
+	latestBlock := nr.Consensus().LatestBlock()
+
+	kp := keypair.Random()
+	account := block.NewBlockAccount(kp.Address(), common.Amount(1*common.AmountPerCoin))
+	account.MustSave(nr.Storage())
+
+	makeTx := func(sequenceID uint64) transaction.Transaction {
+		tx := transaction.TestMakeTransactionWithKeypair(networkID, 1, kp)
+		tx.B.SequenceID = sequenceID
+		tx.Sign(kp, networkID)
+		return tx
+	}
+
+	tx0 := makeTx(account.SequenceID)
+	tx1 := makeTx(account.SequenceID + 1)
+	require.NoError(t, nr.TransactionPool.Add(tx0))
+	require.NoError(t, nr.TransactionPool.Add(tx1))
+
+	newChecker := func(checkTransactionsOnly bool, hashes ...string) *BallotTransactionChecker {
+		basis := voting.Basis{Round: 0, Height: latestBlock.Height, BlockHash: latestBlock.Hash}
+		blt := ballot.NewBallot(nr.Node().Address(), nr.Node().Address(), basis, hashes)
+		blt.Sign(nr.Node().Keypair(), networkID)
+
+		return &BallotTransactionChecker{
+			DefaultChecker:        common.DefaultChecker{Funcs: checkerFuncs},
+			NodeRunner:            nr,
+			Conf:                  nr.Conf,
+			LocalNode:             nr.Node(),
+			Ballot:                *blt,
+			Transactions:          blt.Transactions(),
+			CheckTransactionsOnly: checkTransactionsOnly,
+			VotingHole:            voting.NOTYET,
+			transactionCache:      NewTransactionCache(nr.Storage(), nr.TransactionPool),
+		}
+	}
+
+	{ // starts from the sequence id of account
+		checker := newChecker(false, tx0.GetHash(), tx1.GetHash())
+		require.NoError(t, common.RunChecker(checker, common.DefaultDeferFunc))
+		require.Equal(t, []string{tx0.GetHash(), tx1.GetHash()}, checker.ValidTransactions)
+	}
+
+	{ // the pending transaction without the previous one
+		checker := newChecker(false, tx1.GetHash())
+		require.Equal(t, errors.TransactionInvalidSequenceID, common.RunChecker(checker, common.DefaultDeferFunc))
+	}
+
+	{ // the pending transaction is excluded from the new ballot
+		checker := newChecker(true, tx1.GetHash())
+		require.NoError(t, common.RunChecker(checker, common.DefaultDeferFunc))
+		require.Empty(t, checker.ValidTransactions)
+	}
+
+	{ // source account does not exist
+		_, txOther := transaction.TestMakeTransaction(networkID, 1)
+		require.NoError(t, nr.TransactionPool.Add(txOther))
+
+		checker := newChecker(false, txOther.GetHash())
+		require.Equal(t, errors.BlockAccountDoesNotExists, common.RunChecker(checker, common.DefaultDeferFunc))
+	}
+}
+
+func TestValidateTxDelegate(t *testing.T) {
+	st := block.InitTestBlockchain()
+	defer st.Close()
+
+	kpLinked := keypair.Random()
+	kpFrozen := keypair.Random()
+	validator := keypair.Random().Address()
+
+	block.NewBlockAccount(kpLinked.Address(), common.Amount(1*common.AmountPerCoin)).MustSave(st)
+	frozen := block.NewBlockAccountLinked(kpFrozen.Address(), common.Amount(10000*common.AmountPerCoin), kpLinked.Address())
+	frozen.MustSave(st)
+
+	delegateOp, _ := operation.NewOperation(operation.NewDelegate(validator))
+	tx, _ := transaction.NewTransaction(kpFrozen.Address(), frozen.SequenceID, delegateOp)
+	tx.Sign(kpFrozen, networkID)
+	require.Equal(t, common.Amount(0), tx.B.Fee)
+	require.NoError(t, ValidateTx(st, common.Config{}, tx))
+
+	{ // from general account
+		tx, _ := transaction.NewTransaction(kpLinked.Address(), 0, delegateOp)
+		tx.Sign(kpLinked, networkID)
+		require.Equal(t, errors.DelegationFromInvalidAccount, ValidateTx(st, common.Config{}, tx))
+	}
+
+	{ // already delegated to the validator
+		st1 := block.InitTestBlockchain()
+		defer st1.Close()
+		frozen.MustSave(st1)
+		require.NoError(t, block.NewDelegation(kpFrozen.Address(), validator, frozen.Balance).Save(st1))
+		require.Equal(t, errors.DelegationAlreadyExists, ValidateTx(st1, common.Config{}, tx))
+
+		// to the other validator
+		otherOp, _ := operation.NewOperation(operation.NewDelegate(keypair.Random().Address()))
+		tx, _ := transaction.NewTransaction(kpFrozen.Address(), frozen.SequenceID, otherOp)
+		tx.Sign(kpFrozen, networkID)
+		require.NoError(t, ValidateTx(st1, common.Config{}, tx))
+	}
+
+	{ // the validator has too many delegators
+		st2 := block.InitTestBlockchain()
+		defer st2.Close()
+		frozen.MustSave(st2)
+		for i := uint64(0); i < common.MaxDelegatorsOfValidator; i++ {
+			require.NoError(t, block.NewDelegation(keypair.Random().Address(), validator, common.Amount(1)).Save(st2))
+		}
+		require.Equal(t, errors.DelegatorsLimitExceeded, ValidateTx(st2, common.Config{}, tx))
+	}
+
+	// after unfreezing request
+	unfreezeOp, _ := operation.NewOperation(operation.NewUnfreezeRequest())
+	unfreezeTx, _ := transaction.NewTransaction(kpFrozen.Address(), frozen.SequenceID, unfreezeOp)
+	bo, err := block.NewBlockOperationFromOperation(unfreezeOp, unfreezeTx, 1, 0)
+	require.NoError(t, err)
+	require.NoError(t, bo.Save(st))
+	require.Equal(t, errors.DelegationFromInvalidAccount, ValidateTx(st, common.Config{}, tx))
+}
+
+func TestBallotTransactionsDelegate(t *testing.T) {
+	var checkerFuncs = []common.CheckerFunc{
+		IsNew,
+		CheckMissingTransaction,
+		BallotTransactionsDelegate,
+	}
+
+	config := common.NewTestConfig()
+	nr := createTestNodeRunner(1, config)[0]
+	latestBlock := nr.Consensus().LatestBlock()
+	validator := nr.Node().Address()
+
+	makeTx := func(validator string) transaction.Transaction {
+		kpLinked := keypair.Random()
+		kpFrozen := keypair.Random()
+		block.NewBlockAccount(kpLinked.Address(), common.Amount(1*common.AmountPerCoin)).MustSave(nr.Storage())
+		frozen := block.NewBlockAccountLinked(kpFrozen.Address(), common.Amount(10000*common.AmountPerCoin), kpLinked.Address())
+		frozen.MustSave(nr.Storage())
+
+		op, _ := operation.NewOperation(operation.NewDelegate(validator))
+		tx, _ := transaction.NewTransaction(kpFrozen.Address(), frozen.SequenceID, op)
+		tx.Sign(kpFrozen, networkID)
+		require.NoError(t, nr.TransactionPool.Add(tx))
+		return tx
+	}
+
+	newChecker := func(checkTransactionsOnly bool, hashes ...string) *BallotTransactionChecker {
+		basis := voting.Basis{Round: 0, Height: latestBlock.Height, BlockHash: latestBlock.Hash}
+		blt := ballot.NewBallot(nr.Node().Address(), nr.Node().Address(), basis, hashes)
+		blt.Sign(nr.Node().Keypair(), networkID)
+
+		return &BallotTransactionChecker{
+			DefaultChecker:        common.DefaultChecker{Funcs: checkerFuncs},
+			NodeRunner:            nr,
+			Conf:                  nr.Conf,
+			LocalNode:             nr.Node(),
+			Ballot:                *blt,
+			Transactions:          blt.Transactions(),
+			CheckTransactionsOnly: checkTransactionsOnly,
+			VotingHole:            voting.NOTYET,
+			transactionCache:      NewTransactionCache(nr.Storage(), nr.TransactionPool),
+		}
+	}
+
+	txToValidator := makeTx(validator)
+	txToOther := makeTx(keypair.Random().Address())
+
+	{ // to the validator
+		checker := newChecker(false, txToValidator.GetHash())
+		require.NoError(t, common.RunChecker(checker, common.DefaultDeferFunc))
+		require.Equal(t, []string{txToValidator.GetHash()}, checker.ValidTransactions)
+	}
+
+	{ // to the non-validator
+		checker := newChecker(false, txToValidator.GetHash(), txToOther.GetHash())
+		require.Equal(t, errors.DelegationToNonValidator, common.RunChecker(checker, common.DefaultDeferFunc))
+	}
+
+	{ // the delegation to the non-validator is excluded from the new ballot
+		checker := newChecker(true, txToValidator.GetHash(), txToOther.GetHash())
+		require.NoError(t, common.RunChecker(checker, common.DefaultDeferFunc))
+		require.Equal(t, []string{txToValidator.GetHash()}, checker.ValidTransactions)
+	}
+
+	// the validator can have one more delegator
+	for i := uint64(0); i < common.MaxDelegatorsOfValidator-1; i++ {
+		require.NoError(t, block.NewDelegation(keypair.Random().Address(), validator, common.Amount(1)).Save(nr.Storage()))
+	}
+	txExceeded := makeTx(validator)
+
+	{ // the delegations in the same ballot are counted
+		checker := newChecker(false, txToValidator.GetHash(), txExceeded.GetHash())
+		require.Equal(t, errors.DelegatorsLimitExceeded, common.RunChecker(checker, common.DefaultDeferFunc))
+	}
+
+	{ // the exceeded delegation is excluded from the new ballot
+		checker := newChecker(true, txToValidator.GetHash(), txExceeded.GetHash())
+		require.NoError(t, common.RunChecker(checker, common.DefaultDeferFunc))
+		require.Equal(t, []string{txToValidator.GetHash()}, checker.ValidTransactions)
+	}
+}
@@ -14,6 +14,7 @@ package runner
 
 import (
 	"math/rand"
+	"time"
 
 	logging "github.com/inconshreveable/log15"
 
@@ -40,6 +41,7 @@ type MessageChecker struct {
 	TransactionPool *transaction.Pool
 	Storage         *storage.LevelDBBackend
 	Transaction     transaction.Transaction
+	MinFee          common.Amount // the minimum fee of the next block; set by `TransactionUnmarshal`
 }
 
 // TransactionUnmarshal makes `Transaction` from
@@ -52,7 +54,8 @@ func TransactionUnmarshal(c common.Checker, args ...interface{}) (err error) {
 		return
 	}
 
-	if err = tx.IsWellFormed(checker.Conf); err != nil {
+	checker.MinFee = block.GetNextMinFee(checker.Storage, checker.Conf.OpsInBallotLimit)
+	if err = tx.IsWellFormedWithMinFee(checker.Conf, checker.MinFee); err != nil {
 		return
 	}
 
@@ -84,14 +87,12 @@ func HasTransaction(c common.Checker, args ...interface{}) (err error) {
 }
 
 // SameSource checks there are transactions which has same source in the
-// `Pool`.
+// `Pool`. The transaction, which follows the last pending one of same source
+// or replaces the existing one by the higher fee, is allowed.
 func MessageHasSameSource(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*MessageChecker)
 
-	if checker.TransactionPool.IsSameSource(checker.Transaction.Source()) {
-		err = errors.TransactionSameSourceInPool
-		return
-	}
+	err = checker.TransactionPool.CheckSource(checker.Transaction)
 
 	return
 }
@@ -100,10 +101,22 @@ func MessageHasSameSource(c common.Checker, args ...interface{}) (err error) {
 func MessageValidate(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*MessageChecker)
 
-	if err = ValidateTx(checker.Storage, checker.Conf, checker.Transaction); err != nil {
+	pending := checker.TransactionPool.PendingFromSource(checker.Transaction.Source())
+	if err = ValidateTxWithPending(checker.Storage, checker.Conf, checker.MinFee, pending, checker.Transaction); err != nil {
+		return
+	}
+	if err = checkDelegateToValidator(checker.LocalNode, checker.Transaction); err != nil {
 		return
 	}
 
+	// the expired transaction can not be included in the next block
+	if tb := checker.Transaction.B.TimeBounds; tb != nil {
+		if tb.IsExpired(block.GetLatestBlock(checker.Storage).Height+1, time.Now()) {
+			err = errors.TransactionExpired
+			return
+		}
+	}
+
 	return
 }
 
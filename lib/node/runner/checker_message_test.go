@@ -94,3 +94,53 @@ func TestMessageCheckerWithInvalidHash(t *testing.T) {
 	require.EqualError(t, err, "unexpected end of JSON input")
 	require.NotEqual(t, checker.Transaction, invalidTx)
 }
+
+func TestMessageHasSameSourceReplaceByFee(t *testing.T) {
+	nodeRunner, localNode := MakeNodeRunner()
+
+	kp, tx := transaction.TestMakeTransaction(networkID, 1)
+	require.NoError(t, nodeRunner.TransactionPool.Add(tx))
+
+	newChecker := func(tx transaction.Transaction) *MessageChecker {
+		return &MessageChecker{
+			DefaultChecker:  common.DefaultChecker{},
+			LocalNode:       localNode,
+			Consensus:       nodeRunner.Consensus(),
+			Storage:         nodeRunner.Storage(),
+			TransactionPool: nodeRunner.TransactionPool,
+			Transaction:     tx,
+			Log:             nodeRunner.Log(),
+			Conf:            nodeRunner.Conf,
+		}
+	}
+
+	{ // same fee
+		same := tx
+		same.B.Operations = append(same.B.Operations, tx.B.Operations[0])
+		same.Sign(kp, networkID)
+		require.Equal(t, errors.TransactionReplacementUnderpriced, MessageHasSameSource(newChecker(same)))
+	}
+
+	{ // following sequence id can be added
+		following := tx
+		following.B.SequenceID++
+		following.Sign(kp, networkID)
+		require.NoError(t, MessageHasSameSource(newChecker(following)))
+	}
+
+	{ // sequence id gap
+		other := tx
+		other.B.SequenceID += 2
+		other.B.Fee = tx.B.Fee * 2
+		other.Sign(kp, networkID)
+		require.Equal(t, errors.TransactionInvalidSequenceID, MessageHasSameSource(newChecker(other)))
+	}
+
+	higher := tx
+	higher.B.Fee = tx.B.Fee * 2
+	higher.Sign(kp, networkID)
+	require.NoError(t, MessageHasSameSource(newChecker(higher)))
+	require.NoError(t, PushIntoTransactionPool(newChecker(higher)))
+	require.False(t, nodeRunner.TransactionPool.Has(tx.GetHash()))
+	require.True(t, nodeRunner.TransactionPool.Has(higher.GetHash()))
+}
@@ -0,0 +1,80 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/ballot"
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/voting"
+)
+
+// TestSaveCommitCertificate checks the ACCEPT ballots carry the block
+// signatures and the signatures are stored as the commit certificate of the
+// new block.
+func TestSaveCommitCertificate(t *testing.T) {
+	conf := common.NewTestConfig()
+	nr, nodes, cm := createNodeRunnerForTesting(5, conf, nil)
+	tx, _ := GetTransaction()
+
+	proposer := nr.localNode
+	nr.TransactionPool.Add(tx)
+
+	round := uint64(0)
+	_, err := nr.proposeNewBallot(round)
+	require.NoError(t, err)
+
+	latest := nr.Consensus().LatestBlock()
+	votingBasis := voting.Basis{
+		Round:     round,
+		Height:    latest.Height,
+		BlockHash: latest.Hash,
+		TotalTxs:  latest.TotalTxs,
+	}
+
+	for _, n := range nodes[1:] {
+		b := GenerateBallot(nr.Storage(), proposer, votingBasis, tx, ballot.StateSIGN, n, conf)
+		require.NoError(t, ReceiveBallot(nr, b))
+	}
+
+	// the broadcasted ACCEPT ballot has the block signature
+	var broadcasted []ballot.Ballot
+	for _, m := range cm.Messages() {
+		if b, ok := m.(ballot.Ballot); ok && b.State() == ballot.StateACCEPT {
+			broadcasted = append(broadcasted, b)
+		}
+	}
+	require.Equal(t, 1, len(broadcasted))
+	{
+		b := broadcasted[0]
+		blk := newBlockFromBallot(b, []*transaction.Transaction{&tx}, block.GetNextMinFee(nr.Storage(), conf.OpsInBallotLimit))
+		s := block.CommitSignature{Validator: proposer.Address(), Signature: b.BlockSignature()}
+		require.NoError(t, s.Verify(networkID, blk.Hash))
+	}
+
+	base := GenerateBallot(nr.Storage(), proposer, votingBasis, tx, ballot.StateACCEPT, nodes[1], conf)
+	blockHash := newBlockFromBallot(*base, []*transaction.Transaction{&tx}, block.GetNextMinFee(nr.Storage(), conf.OpsInBallotLimit)).Hash
+	for _, n := range nodes[1:] {
+		b := *base
+		b.Sign(n.Keypair(), networkID)
+		b.SignBlock(n.Keypair(), networkID, blockHash)
+		require.NoError(t, ReceiveBallot(nr, &b))
+	}
+
+	blk := nr.Consensus().LatestBlock()
+	require.Equal(t, blockHash, blk.Hash)
+
+	c, err := block.GetCommitCertificate(nr.Storage(), blk.Height)
+	require.NoError(t, err)
+	require.Equal(t, blk.Hash, c.BlockHash)
+	require.Equal(t, 4, len(c.Signatures))
+
+	validators := map[string]bool{}
+	for address := range nr.localNode.GetValidators() {
+		validators[address] = true
+	}
+	require.NoError(t, c.Verify(networkID, blk, validators, nr.policy.Threshold()))
+}
@@ -0,0 +1,140 @@
+package runner
+
+import (
+	"math/big"
+	"sort"
+
+	"boscoin.io/sebak/lib/ballot"
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+// checkDelegateToValidator checks the `operation.Delegate` of transaction
+// targets the validator.
+func checkDelegateToValidator(localNode *node.LocalNode, tx transaction.Transaction) (err error) {
+	for _, op := range tx.B.Operations {
+		if opb, ok := op.B.(operation.Delegate); ok && !localNode.HasValidators(opb.Validator) {
+			return errors.DelegationToNonValidator
+		}
+	}
+
+	return
+}
+
+// checkDelegatorsLimit checks the validator can have the new delegator;
+// `pending` is the number of new delegators, which are not stored yet.
+func checkDelegatorsLimit(st *storage.LevelDBBackend, validator string, pending uint64) (err error) {
+	var stake block.DelegatedStake
+	if stake, err = block.GetDelegatedStake(st, validator); err != nil {
+		return
+	}
+	if stake.Delegators+pending >= common.MaxDelegatorsOfValidator {
+		return errors.DelegatorsLimitExceeded
+	}
+
+	return
+}
+
+// getDelegationRewards returns the rewards of delegators from the inflation,
+// `amount`. Each delegator gets the share pro rata to the delegated stake and
+// it is sent to the linked account of frozen account; if the linked account
+// can not receive, the share is kept in the common account. Only the
+// delegations to `validators` are rewarded, in order of validator and
+// account; the delegators of each validator are bounded by
+// `common.MaxDelegatorsOfValidator`.
+func getDelegationRewards(st *storage.LevelDBBackend, amount common.Amount, validators map[string]*node.Validator) (rewards []operation.DelegatorReward, err error) {
+	if amount < 1 {
+		return
+	}
+
+	var addresses []string
+	for address := range validators {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	var delegations []block.Delegation
+	var total common.Amount
+	for _, address := range addresses {
+		iterFunc, closeFunc := block.GetDelegationsByValidator(st, address, nil)
+		for {
+			d, hasNext, _ := iterFunc()
+			if !hasNext {
+				break
+			}
+			if total, err = total.Add(d.Amount); err != nil {
+				closeFunc()
+				return
+			}
+			delegations = append(delegations, d)
+		}
+		closeFunc()
+	}
+
+	if total < 1 {
+		return
+	}
+
+	for _, d := range delegations {
+		share := new(big.Int).Mul(new(big.Int).SetUint64(uint64(amount)), new(big.Int).SetUint64(uint64(d.Amount)))
+		share.Div(share, new(big.Int).SetUint64(uint64(total)))
+		if share.Sign() < 1 {
+			continue
+		}
+
+		var account *block.BlockAccount
+		if account, err = block.GetBlockAccount(st, d.Account); err != nil {
+			return
+		}
+		if canReceiveTxFee(st, account.Linked) != nil {
+			continue
+		}
+
+		rewards = append(rewards, operation.DelegatorReward{
+			Delegator: d.Account,
+			Target:    account.Linked,
+			Amount:    common.Amount(share.Uint64()),
+		})
+	}
+
+	return
+}
+
+// checkDelegationReward validates the `operation.DelegationReward` of the
+// proposer transaction of ballot; the rewards must be same with the rewards
+// from the delegations of local storage.
+func checkDelegationReward(st *storage.LevelDBBackend, conf common.Config, blt ballot.Ballot, opi operation.Inflation, validators map[string]*node.Validator) (err error) {
+	var expected []operation.DelegatorReward
+	if conf.InflationToDelegators {
+		if expected, err = getDelegationRewards(st, opi.Amount, validators); err != nil {
+			return
+		}
+	}
+
+	opr, found := blt.ProposerTransaction().DelegationReward()
+	if !found {
+		if len(expected) > 0 {
+			err = errors.InvalidOperation
+		}
+		return
+	}
+
+	if len(expected) != len(opr.Rewards) {
+		err = errors.InvalidOperation
+		return
+	}
+	for i, r := range expected {
+		o := opr.Rewards[i]
+		if r.Delegator != o.Delegator || r.Target != o.Target || r.Amount != o.Amount {
+			err = errors.InvalidOperation
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,57 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+func TestGetDelegationRewards(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	validator := keypair.Random().Address()
+	v, _ := node.NewValidator(validator, nil, "")
+	validators := map[string]*node.Validator{validator: v}
+
+	rewards, err := getDelegationRewards(st, common.Amount(100), validators)
+	require.NoError(t, err)
+	require.Empty(t, rewards)
+
+	var accounts []*block.BlockAccount
+	for _, amount := range []common.Amount{10000, 30000} {
+		linked := block.NewBlockAccount(keypair.Random().Address(), common.Amount(1*common.AmountPerCoin))
+		linked.MustSave(st)
+		frozen := block.NewBlockAccountLinked(keypair.Random().Address(), amount, linked.Address)
+		frozen.MustSave(st)
+		require.NoError(t, block.NewDelegation(frozen.Address, validator, frozen.Balance).Save(st))
+		accounts = append(accounts, frozen)
+	}
+
+	{ // the delegation to the removed validator is not rewarded
+		linked := block.NewBlockAccount(keypair.Random().Address(), common.Amount(1*common.AmountPerCoin))
+		linked.MustSave(st)
+		frozen := block.NewBlockAccountLinked(keypair.Random().Address(), common.Amount(40000), linked.Address)
+		frozen.MustSave(st)
+		require.NoError(t, block.NewDelegation(frozen.Address, keypair.Random().Address(), frozen.Balance).Save(st))
+	}
+
+	rewards, err = getDelegationRewards(st, common.Amount(100), validators)
+	require.NoError(t, err)
+
+	expected := map[string]operation.DelegatorReward{
+		accounts[0].Address: {Delegator: accounts[0].Address, Target: accounts[0].Linked, Amount: 25},
+		accounts[1].Address: {Delegator: accounts[1].Address, Target: accounts[1].Linked, Amount: 75},
+	}
+	require.Equal(t, 2, len(rewards))
+	for _, r := range rewards {
+		require.Equal(t, expected[r.Delegator], r)
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"boscoin.io/sebak/lib/ballot"
 	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/metrics"
 	"boscoin.io/sebak/lib/storage"
@@ -34,7 +35,7 @@ func finishBallot(nr *NodeRunner, b ballot.Ballot, log logging.Logger) (*block.B
 	}
 
 	var blk *block.Block
-	blk, err = finishBallotWithProposedTxs(bs, b, proposedTxs, log)
+	blk, err = finishBallotWithProposedTxs(bs, nr.Conf, b, proposedTxs, log)
 
 	if err != nil {
 		bs.Discard()
@@ -52,30 +53,19 @@ func finishBallot(nr *NodeRunner, b ballot.Ballot, log logging.Logger) (*block.B
 	return blk, proposedTxs, nil
 }
 
-func finishBallotWithProposedTxs(st *storage.LevelDBBackend, b ballot.Ballot, proposedTransactions []*transaction.Transaction, log logging.Logger) (*block.Block, error) {
+func finishBallotWithProposedTxs(st *storage.LevelDBBackend, conf common.Config, b ballot.Ballot, proposedTransactions []*transaction.Transaction, log logging.Logger) (*block.Block, error) {
 	var err error
 	var isValid bool
 	if isValid, err = isValidRound(st, b.VotingBasis(), log); err != nil || !isValid {
 		return nil, err
 	}
 
-	var nOps int
-	for _, tx := range proposedTransactions {
-		nOps += len(tx.B.Operations)
+	var prevBlock block.Block
+	if prevBlock, err = block.GetBlockByHeight(st, b.VotingBasis().Height); err != nil {
+		return nil, err
 	}
 
-	r := b.VotingBasis()
-	r.Height++                                      // next block
-	r.TotalTxs += uint64(len(b.Transactions()) + 1) // + 1 for ProposerTransaction
-	r.TotalOps += uint64(nOps + len(b.ProposerTransaction().B.Operations))
-
-	blk := block.NewBlock(
-		b.Proposer(),
-		r,
-		b.ProposerTransaction().GetHash(),
-		b.Transactions(),
-		b.ProposerConfirmed(),
-	)
+	blk := newBlockFromBallot(b, proposedTransactions, block.NextMinFee(prevBlock.Header, conf.OpsInBallotLimit))
 
 	if err = blk.Save(st); err != nil {
 		log.Error("failed to create new block", "block", blk.Hash, "error", err)
@@ -105,9 +95,47 @@ func finishBallotWithProposedTxs(st *storage.LevelDBBackend, b ballot.Ballot, pr
 		return nil, err
 	}
 
+	var stateRoot string
+	stateAccounts := StateAccounts(proposedTransactions, b.ProposerTransaction())
+	if stateRoot, err = UpdateStateRoot(st, prevBlock.StateRoot, stateAccounts, true); err != nil {
+		log.Error("failed to update state root", "block", blk.Hash, "error", err)
+		return nil, err
+	}
+	if stateRoot != blk.StateRoot {
+		log.Error("state root does not match", "block", blk.Hash, "expected", blk.StateRoot, "state-root", stateRoot)
+		return nil, errors.StateRootDoesNotMatch
+	}
+
 	return blk, nil
 }
 
+// newBlockFromBallot makes the next block of ballot; every validators makes the
+// same block from the same ballot, so they can sign the block hash before the
+// block is saved. `minFee` is the minimum fee of the block by the previous
+// block, see `block.NextMinFee`.
+func newBlockFromBallot(b ballot.Ballot, proposedTransactions []*transaction.Transaction, minFee common.Amount) *block.Block {
+	var nOps int
+	for _, tx := range proposedTransactions {
+		nOps += len(tx.B.Operations)
+	}
+
+	r := b.VotingBasis()
+	r.Height++                                      // next block
+	r.TotalTxs += uint64(len(b.Transactions()) + 1) // + 1 for ProposerTransaction
+	r.TotalOps += uint64(nOps + len(b.ProposerTransaction().B.Operations))
+
+	return block.NewBlockWithFee(
+		b.Proposer(),
+		r,
+		b.ProposerTransaction().GetHash(),
+		b.Transactions(),
+		b.StateRoot(),
+		b.ProposerConfirmed(),
+		minFee,
+		uint64(nOps),
+	)
+}
+
 func getProposedTransactions(st *storage.LevelDBBackend, pTxHashes []string, transactionPool *transaction.Pool) ([]*transaction.Transaction, error) {
 	proposedTransactions := make([]*transaction.Transaction, 0, len(pTxHashes))
 	var err error
@@ -150,6 +178,13 @@ func FinishTransactions(blk block.Block, transactions []*transaction.Transaction
 
 		baSource.IncreaseSequenceID()
 
+		if mop, found := tx.AccountMerge(); found {
+			if err = finishAccountMerge(st, baSource, mop, log); err != nil {
+				return
+			}
+			continue
+		}
+
 		if err = baSource.Save(st); err != nil {
 			return
 		}
@@ -188,6 +223,29 @@ func finishOperation(st *storage.LevelDBBackend, source string, op operation.Ope
 			return errors.UnknownOperationType
 		}
 		return finishInflationPF(st, source, pop, log)
+	case operation.TypeSetSigners:
+		pop, ok := op.B.(operation.SetSigners)
+		if !ok {
+			return errors.UnknownOperationType
+		}
+		return finishSetSigners(st, source, pop, log)
+	case operation.TypeAccountMerge:
+		// `AccountMerge` is finished after the fee is withdrawn, see
+		// `FinishTransactions`.
+		return
+	case operation.TypeValidatorUpdate:
+		pop, ok := op.B.(operation.ValidatorUpdate)
+		if !ok {
+			return errors.UnknownOperationType
+		}
+		// the update is applied by `NodeRunner` when it reaches the height
+		return block.NewValidatorUpdate(pop).Save(st)
+	case operation.TypeDelegate:
+		pop, ok := op.B.(operation.Delegate)
+		if !ok {
+			return errors.UnknownOperationType
+		}
+		return finishDelegate(st, source, pop, log)
 
 	default:
 		err = errors.UnknownOperationType
@@ -208,6 +266,10 @@ func finishCreateAccount(st *storage.LevelDBBackend, source string, op operation
 	} else {
 		err = nil
 	}
+	if block.IsMergedBlockAccount(st, op.TargetAddress()) {
+		err = errors.BlockAccountMerged
+		return
+	}
 
 	baTarget = block.NewBlockAccountLinked(
 		op.TargetAddress(),
@@ -244,7 +306,18 @@ func finishPayment(st *storage.LevelDBBackend, source string, op operation.Payme
 }
 
 func finishUnfreezeRequest(st *storage.LevelDBBackend, source string, opb operation.UnfreezeRequest, log logging.Logger) (err error) {
-	return
+	// the unfreezing stake is not delegated any more
+	return block.RemoveDelegation(st, source)
+}
+
+func finishDelegate(st *storage.LevelDBBackend, source string, opb operation.Delegate, log logging.Logger) (err error) {
+	var baSource *block.BlockAccount
+	if baSource, err = block.GetBlockAccount(st, source); err != nil {
+		err = errors.BlockAccountDoesNotExists
+		return
+	}
+
+	return block.NewDelegation(source, opb.Validator, baSource.Balance).Save(st)
 }
 
 func finishInflationPF(st *storage.LevelDBBackend, source string, opb operation.InflationPF, log logging.Logger) (err error) {
@@ -269,6 +342,21 @@ func finishInflationPF(st *storage.LevelDBBackend, source string, opb operation.
 	return
 }
 
+func finishSetSigners(st *storage.LevelDBBackend, source string, opb operation.SetSigners, log logging.Logger) (err error) {
+	var baSource *block.BlockAccount
+	if baSource, err = block.GetBlockAccount(st, source); err != nil {
+		err = errors.BlockAccountDoesNotExists
+		return
+	}
+
+	baSource.SetSigners(opb)
+	if err = baSource.Save(st); err != nil {
+		return
+	}
+
+	return
+}
+
 func FinishProposerTransaction(st *storage.LevelDBBackend, blk block.Block, ptx ballot.ProposerTransaction, log logging.Logger) (err error) {
 	if err = ProcessProposerTransaction(st, blk, ptx, log); err != nil {
 		return err
@@ -306,6 +394,22 @@ func ProcessProposerTransaction(st *storage.LevelDBBackend, blk block.Block, ptx
 		}
 	}
 
+	if opb, found := ptx.DistributeTxFee(); found {
+		if err = finishDistributeTxFee(st, opb, log); err != nil {
+			return
+		}
+	}
+
+	if opb, found := ptx.DelegationReward(); found {
+		var opi operation.Inflation
+		if opi, err = ptx.Inflation(); err != nil {
+			return
+		}
+		if err = finishDelegationReward(st, opi.TargetAddress(), opb, log); err != nil {
+			return
+		}
+	}
+
 	return
 }
 
@@ -330,6 +434,59 @@ func finishCollectTxFee(st *storage.LevelDBBackend, opb operation.CollectTxFee,
 	return
 }
 
+func finishDistributeTxFee(st *storage.LevelDBBackend, opb operation.DistributeTxFee, log logging.Logger) (err error) {
+	for _, r := range opb.Recipients {
+		var account *block.BlockAccount
+		if account, err = block.GetBlockAccount(st, r.Address); err != nil {
+			return
+		}
+
+		if err = account.Deposit(r.Amount); err != nil {
+			return
+		}
+
+		if err = account.Save(st); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// finishDelegationReward sends the rewards from the common account, which
+// received the inflation.
+func finishDelegationReward(st *storage.LevelDBBackend, commonAddress string, opb operation.DelegationReward, log logging.Logger) (err error) {
+	var commonAccount *block.BlockAccount
+	if commonAccount, err = block.GetBlockAccount(st, commonAddress); err != nil {
+		return
+	}
+
+	if err = commonAccount.Withdraw(opb.GetAmount()); err != nil {
+		return
+	}
+
+	if err = commonAccount.Save(st); err != nil {
+		return
+	}
+
+	for _, r := range opb.Rewards {
+		var account *block.BlockAccount
+		if account, err = block.GetBlockAccount(st, r.Target); err != nil {
+			return
+		}
+
+		if err = account.Deposit(r.Amount); err != nil {
+			return
+		}
+
+		if err = account.Save(st); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
 func finishInflation(st *storage.LevelDBBackend, opb operation.Inflation, log logging.Logger) (err error) {
 	if opb.Amount < 1 {
 		return
@@ -350,3 +507,28 @@ func finishInflation(st *storage.LevelDBBackend, opb operation.Inflation, log lo
 
 	return
 }
+
+// finishAccountMerge moves the remaining balance of source account to the
+// target and removes the source account.
+func finishAccountMerge(st *storage.LevelDBBackend, source *block.BlockAccount, op operation.AccountMerge, log logging.Logger) (err error) {
+	var target *block.BlockAccount
+	if target, err = block.GetBlockAccount(st, op.TargetAddress()); err != nil {
+		err = errors.BlockAccountDoesNotExists
+		return
+	}
+
+	if err = target.Deposit(source.Balance); err != nil {
+		return
+	}
+	if err = target.Save(st); err != nil {
+		return
+	}
+
+	if err = source.Remove(st); err != nil {
+		return
+	}
+
+	log.Debug("account merged", "source", source.Address, "target", target.Address, "amount", source.Balance)
+
+	return
+}
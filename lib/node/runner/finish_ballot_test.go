@@ -13,6 +13,7 @@ import (
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/common/keypair"
 	"boscoin.io/sebak/lib/consensus"
+	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/network"
 	"boscoin.io/sebak/lib/node"
 	"boscoin.io/sebak/lib/storage"
@@ -142,6 +143,7 @@ func testFinishBallot(withBatch bool, numberOfTransactions, numberOfOperations i
 
 		blt.SetProposerTransaction(ptx)
 		blt.SetVote(ballot.StateINIT, voting.YES)
+		setTestBallotStateRoot(nr.Storage(), blt, txs...)
 		blt.Sign(proposerNode.Keypair(), conf.NetworkID)
 	}
 
@@ -163,3 +165,143 @@ func TestFinishBallot(t *testing.T) {
 	err = testFinishBallot(true, 100, 100)
 	require.NoError(t, err)
 }
+
+func TestFinishTransactionsAccountMerge(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	kps := keypair.Random()
+	kpt := keypair.Random()
+
+	bas := block.NewBlockAccount(kps.Address(), common.Amount(1*common.AmountPerCoin))
+	bas.MustSave(st)
+	bas.IncreaseSequenceID()
+	bas.MustSave(st)
+	bat := block.NewBlockAccount(kpt.Address(), common.Amount(1*common.AmountPerCoin))
+	bat.MustSave(st)
+
+	mergeOp, _ := operation.NewOperation(operation.NewAccountMerge(kpt.Address()))
+	tx, _ := transaction.NewTransaction(kps.Address(), bas.SequenceID, mergeOp)
+	tx.Sign(kps, networkID)
+
+	blk := block.TestMakeNewBlock([]string{tx.GetHash()})
+	require.NoError(t, FinishTransactions(blk, []*transaction.Transaction{&tx}, st))
+
+	// source account and it's sequence ids are removed
+	exists, err := block.ExistsBlockAccount(st, kps.Address())
+	require.NoError(t, err)
+	require.False(t, exists)
+	for _, sequenceID := range []uint64{0, 1, 2} {
+		_, err = block.GetBlockAccountSequenceID(st, kps.Address(), sequenceID)
+		require.Error(t, err)
+	}
+
+	// the remaining balance except fee is moved to target
+	target, err := block.GetBlockAccount(st, kpt.Address())
+	require.NoError(t, err)
+	require.Equal(t, bat.Balance.MustAdd(bas.Balance).MustSub(tx.B.Fee), target.Balance)
+
+	// the removed account is not listed
+	iterFunc, closeFunc := block.GetBlockAccountsByCreated(st, nil)
+	defer closeFunc()
+	var addresses []string
+	for {
+		ba, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+		addresses = append(addresses, ba.Address)
+	}
+	require.Equal(t, []string{kpt.Address()}, addresses)
+}
+
+// TestFinishTransactionsAccountMergeHistory checks the merged account does
+// not exist at the later heights.
+func TestFinishTransactionsAccountMergeHistory(t *testing.T) {
+	st := block.InitTestBlockchain()
+	defer st.Close()
+
+	genesis := block.GetLatestBlock(st)
+
+	kps := keypair.Random()
+	kpt := keypair.Random()
+
+	bas := block.NewBlockAccount(kps.Address(), common.Amount(1*common.AmountPerCoin))
+	bas.MustSave(st)
+	bat := block.NewBlockAccount(kpt.Address(), common.Amount(1*common.AmountPerCoin))
+	bat.MustSave(st)
+
+	mergeOp, _ := operation.NewOperation(operation.NewAccountMerge(kpt.Address()))
+	tx, _ := transaction.NewTransaction(kps.Address(), bas.SequenceID, mergeOp)
+	tx.Sign(kps, networkID)
+
+	blk := block.TestMakeNewBlockWithPrevBlock(genesis, []string{tx.GetHash()})
+	blk.MustSave(st)
+	require.NoError(t, FinishTransactions(blk, []*transaction.Transaction{&tx}, st))
+
+	nextBlk := block.TestMakeNewBlockWithPrevBlock(blk, []string{})
+	nextBlk.MustSave(st)
+
+	{ // before merged
+		ba, err := block.GetBlockAccountAtHeight(st, kps.Address(), genesis.Height)
+		require.NoError(t, err)
+		require.Equal(t, bas.Balance, ba.Balance)
+	}
+
+	for _, height := range []uint64{blk.Height, nextBlk.Height} {
+		_, err := block.GetBlockAccountAtHeight(st, kps.Address(), height)
+		require.Equal(t, errors.BlockAccountDoesNotExists, err)
+	}
+
+	// the target is not removed
+	ba, err := block.GetBlockAccountAtHeight(st, kpt.Address(), nextBlk.Height)
+	require.NoError(t, err)
+	require.Equal(t, bat.Balance.MustAdd(bas.Balance).MustSub(tx.B.Fee), ba.Balance)
+}
+
+func TestFinishTransactionsDelegate(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	kpf := keypair.Random()
+	validator := keypair.Random().Address()
+
+	frozen := block.NewBlockAccountLinked(kpf.Address(), common.Amount(10000*common.AmountPerCoin), keypair.Random().Address())
+	frozen.MustSave(st)
+
+	delegateOp, _ := operation.NewOperation(operation.NewDelegate(validator))
+	tx, _ := transaction.NewTransaction(kpf.Address(), frozen.SequenceID, delegateOp)
+	tx.Sign(kpf, networkID)
+
+	blk := block.TestMakeNewBlock([]string{tx.GetHash()})
+	require.NoError(t, FinishTransactions(blk, []*transaction.Transaction{&tx}, st))
+
+	d, err := block.GetDelegation(st, kpf.Address())
+	require.NoError(t, err)
+	// the delegation follows the balance after the fee
+	balance := frozen.Balance.MustSub(tx.B.Fee)
+	require.Equal(t, block.NewDelegation(kpf.Address(), validator, balance), d)
+
+	stake, err := block.GetDelegatedStake(st, validator)
+	require.NoError(t, err)
+	require.Equal(t, balance, stake.Amount)
+	require.Equal(t, uint64(1), stake.Delegators)
+
+	// unfreezing request removes the delegation
+	frozen, _ = block.GetBlockAccount(st, kpf.Address())
+	unfreezeOp, _ := operation.NewOperation(operation.NewUnfreezeRequest())
+	tx, _ = transaction.NewTransaction(kpf.Address(), frozen.SequenceID, unfreezeOp)
+	tx.Sign(kpf, networkID)
+
+	blk = block.TestMakeNewBlock([]string{tx.GetHash()})
+	blk.Height++
+	require.NoError(t, FinishTransactions(blk, []*transaction.Transaction{&tx}, st))
+
+	_, err = block.GetDelegation(st, kpf.Address())
+	require.Error(t, err)
+
+	stake, err = block.GetDelegatedStake(st, validator)
+	require.NoError(t, err)
+	require.Equal(t, common.Amount(0), stake.Amount)
+	require.Equal(t, uint64(0), stake.Delegators)
+}
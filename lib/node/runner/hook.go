@@ -0,0 +1,38 @@
+package runner
+
+import (
+	"time"
+
+	"boscoin.io/sebak/lib/common"
+)
+
+// Hook observes the asynchronous works of `NodeRunner`, like the state
+// transitions and the ballots broadcasted to itself, so the simulation can
+// wait until they are done. By default, `NodeRunner` uses `NopHook`.
+type Hook interface {
+	// Begin is called when the asynchronous work is posted and End is called
+	// when it is done.
+	Begin()
+	End()
+
+	// TransitPosted is called when the state transition is posted to
+	// `ISAACStateManager` and TransitReceived is called when it is received;
+	// the received transition is done by End.
+	TransitPosted()
+	TransitReceived()
+
+	// Sleep sleeps by the clock in `ISAACStateManager`; while sleeping, the
+	// posted transitions are not received.
+	Sleep(clock common.Clock, d time.Duration)
+}
+
+// NopHook is the `Hook`, which does nothing.
+type NopHook struct{}
+
+func (NopHook) Begin()           {}
+func (NopHook) End()             {}
+func (NopHook) TransitPosted()   {}
+func (NopHook) TransitReceived() {}
+func (NopHook) Sleep(clock common.Clock, d time.Duration) {
+	clock.Sleep(d)
+}
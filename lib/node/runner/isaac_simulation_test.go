@@ -48,38 +48,38 @@ func TestISAACSimulationProposer(t *testing.T) {
 	}
 	require.True(t, nr.TransactionPool.Has(tx.GetHash()))
 
-	ballotSIGN1 := GenerateBallot(proposer, votingBasis, tx, ballot.StateSIGN, nodes[1], conf)
+	ballotSIGN1 := GenerateBallot(nr.Storage(), proposer, votingBasis, tx, ballot.StateSIGN, nodes[1], conf)
 	err = ReceiveBallot(nr, ballotSIGN1)
 	require.NoError(t, err)
 
-	ballotSIGN2 := GenerateBallot(proposer, votingBasis, tx, ballot.StateSIGN, nodes[2], conf)
+	ballotSIGN2 := GenerateBallot(nr.Storage(), proposer, votingBasis, tx, ballot.StateSIGN, nodes[2], conf)
 	err = ReceiveBallot(nr, ballotSIGN2)
 	require.NoError(t, err)
 
-	ballotSIGN3 := GenerateBallot(proposer, votingBasis, tx, ballot.StateSIGN, nodes[3], conf)
+	ballotSIGN3 := GenerateBallot(nr.Storage(), proposer, votingBasis, tx, ballot.StateSIGN, nodes[3], conf)
 	err = ReceiveBallot(nr, ballotSIGN3)
 	require.NoError(t, err)
 
-	ballotSIGN4 := GenerateBallot(proposer, votingBasis, tx, ballot.StateSIGN, nodes[4], conf)
+	ballotSIGN4 := GenerateBallot(nr.Storage(), proposer, votingBasis, tx, ballot.StateSIGN, nodes[4], conf)
 	err = ReceiveBallot(nr, ballotSIGN4)
 	require.NoError(t, err)
 
 	rr := nr.Consensus().RunningRounds[votingBasis.Index()]
 	require.Equal(t, 4, len(rr.Voted[proposer.Address()].GetResult(ballot.StateSIGN)))
 
-	ballotACCEPT0 := GenerateBallot(proposer, votingBasis, tx, ballot.StateACCEPT, nodes[0], conf)
+	ballotACCEPT0 := GenerateBallot(nr.Storage(), proposer, votingBasis, tx, ballot.StateACCEPT, nodes[0], conf)
 	err = ReceiveBallot(nr, ballotACCEPT0)
 	require.NoError(t, err)
 
-	ballotACCEPT1 := GenerateBallot(proposer, votingBasis, tx, ballot.StateACCEPT, nodes[1], conf)
+	ballotACCEPT1 := GenerateBallot(nr.Storage(), proposer, votingBasis, tx, ballot.StateACCEPT, nodes[1], conf)
 	err = ReceiveBallot(nr, ballotACCEPT1)
 	require.NoError(t, err)
 
-	ballotACCEPT2 := GenerateBallot(proposer, votingBasis, tx, ballot.StateACCEPT, nodes[2], conf)
+	ballotACCEPT2 := GenerateBallot(nr.Storage(), proposer, votingBasis, tx, ballot.StateACCEPT, nodes[2], conf)
 	err = ReceiveBallot(nr, ballotACCEPT2)
 	require.NoError(t, err)
 
-	ballotACCEPT3 := GenerateBallot(proposer, votingBasis, tx, ballot.StateACCEPT, nodes[3], conf)
+	ballotACCEPT3 := GenerateBallot(nr.Storage(), proposer, votingBasis, tx, ballot.StateACCEPT, nodes[3], conf)
 	err = ReceiveBallot(nr, ballotACCEPT3)
 	require.NoError(t, err)
 
@@ -25,6 +25,7 @@ type ISAACStateManager struct {
 	blockTimeBuffer        time.Duration              // the time to wait to adjust the block creation time.
 	transitSignal          func(consensus.ISAACState) // the function is called when the ISAACState is changed.
 	firstProposedBlockTime time.Time                  // the time at which the first consensus block was saved(height 2). It is used for calculating `blockTimeBuffer`.
+	clock                  common.Clock               // the timeouts are measured by clock
 
 	Conf common.Config
 }
@@ -41,6 +42,7 @@ func NewISAACStateManager(nr *NodeRunner, conf common.Config) *ISAACStateManager
 		stop:            make(chan struct{}),
 		blockTimeBuffer: 2 * time.Second,
 		transitSignal:   func(consensus.ISAACState) {},
+		clock:           common.SystemClock{},
 
 		Conf: conf,
 	}
@@ -81,8 +83,8 @@ func (sm *ISAACStateManager) setBlockTimeBuffer() {
 	sm.blockTimeBuffer = calculateBlockTimeBuffer(
 		b.Height,
 		sm.Conf.BlockTime,
-		time.Now().Sub(sm.firstProposedBlockTime),
-		time.Now().Sub(ballotProposedTime),
+		sm.clock.Now().Sub(sm.firstProposedBlockTime),
+		sm.clock.Now().Sub(ballotProposedTime),
 		sm.Conf.BlockTimeDelta,
 	)
 	sm.nr.Log().Debug(
@@ -91,7 +93,7 @@ func (sm *ISAACStateManager) setBlockTimeBuffer() {
 		"firstProposedBlockTime", sm.firstProposedBlockTime,
 		"height", b.Height,
 		"proposedTime", b.ProposedTime,
-		"now", time.Now(),
+		"now", sm.clock.Now(),
 	)
 
 	return
@@ -137,6 +139,13 @@ func calculateBlockTimeBuffer(height uint64, goal, sinceGenesis, untilNow, delta
 	return blockTimeBuffer
 }
 
+// SetClock replaces the clock; it must be called before `Start()`.
+func (sm *ISAACStateManager) SetClock(clock common.Clock) {
+	sm.Lock()
+	defer sm.Unlock()
+	sm.clock = clock
+}
+
 func (sm *ISAACStateManager) SetTransitSignal(f func(consensus.ISAACState)) {
 	sm.Lock()
 	defer sm.Unlock()
@@ -167,6 +176,7 @@ func (sm *ISAACStateManager) TransitISAACState(height uint64, round uint64, ball
 			"current", current,
 			"target", target,
 		)
+		sm.nr.hook.TransitPosted()
 		go func(t consensus.ISAACState) {
 			sm.stateTransit <- t
 		}(target)
@@ -193,11 +203,11 @@ func (sm *ISAACStateManager) Start() {
 	sm.nr.localNode.SetConsensus()
 	sm.nr.Log().Debug("begin ISAACStateManager.Start()", "ISAACState", sm.State())
 	go func() {
-		timer := time.NewTimer(time.Duration(1 * time.Hour))
+		timer := sm.clock.NewTimer(time.Duration(1 * time.Hour))
 		begin := time.Now() // measure for block interval time
 		for {
 			select {
-			case <-timer.C:
+			case <-timer.C():
 				sm.nr.Log().Debug("timeout", "ISAACState", sm.State())
 				switch sm.State().BallotState {
 				case ballot.StateINIT:
@@ -210,6 +220,7 @@ func (sm *ISAACStateManager) Start() {
 							sm.nr.Log().Debug("break; BallotSendRecord().Sent(sm.State) == true", "ISAACState", sm.State())
 							break
 						}
+						sm.nr.hook.Begin()
 						go sm.broadcastExpiredBallot(sm.State().Round, ballot.StateSIGN)
 					}
 				case ballot.StateACCEPT:
@@ -218,17 +229,22 @@ func (sm *ISAACStateManager) Start() {
 							sm.nr.Log().Debug("break; BallotSendRecord().Sent(sm.State) == true", "ISAACState", sm.State())
 							break
 						}
+						sm.nr.hook.Begin()
 						go sm.broadcastExpiredBallot(sm.State().Round, ballot.StateACCEPT)
 					}
 				case ballot.StateALLCONFIRM:
 					sm.nr.Log().Error("timeout", "ISAACState", sm.State())
 					sm.NextRound()
 				}
+				sm.nr.hook.End()
 
 			case state := <-sm.stateTransit:
+				sm.nr.hook.TransitReceived()
+
 				current := sm.State()
 				if !current.IsLater(state) {
 					sm.nr.Log().Debug("break; target is before than or equal to current", "current", current, "target", state)
+					sm.nr.hook.End()
 					break
 				}
 
@@ -243,6 +259,7 @@ func (sm *ISAACStateManager) Start() {
 				}
 				sm.setState(state)
 				sm.transitSignal(state)
+				sm.nr.hook.End()
 
 			case <-sm.stop:
 				return
@@ -252,6 +269,8 @@ func (sm *ISAACStateManager) Start() {
 }
 
 func (sm *ISAACStateManager) broadcastExpiredBallot(round uint64, state ballot.State) {
+	defer sm.nr.hook.End()
+
 	sm.nr.Log().Debug("begin ISAACStateManager.broadcastExpiredBallot", "round", round, "ballotState", state)
 
 	b := sm.nr.consensus.LatestBlock()
@@ -280,7 +299,7 @@ func (sm *ISAACStateManager) broadcastExpiredBallot(round uint64, state ballot.S
 	sm.nr.BroadcastBallot(*newExpiredBallot)
 }
 
-func (sm *ISAACStateManager) resetTimer(timer *time.Timer, state ballot.State) {
+func (sm *ISAACStateManager) resetTimer(timer common.Timer, state ballot.State) {
 	switch state {
 	case ballot.StateINIT:
 		timer.Reset(sm.Conf.TimeoutINIT)
@@ -296,7 +315,7 @@ func (sm *ISAACStateManager) resetTimer(timer *time.Timer, state ballot.State) {
 // In proposeOrWait,
 // if nr.localNode is proposer, it proposes new ballot,
 // but if not, it waits for receiving ballot from the other proposer.
-func (sm *ISAACStateManager) proposeOrWait(timer *time.Timer, round uint64) {
+func (sm *ISAACStateManager) proposeOrWait(timer common.Timer, round uint64) {
 	timer.Reset(time.Duration(1 * time.Hour))
 	sm.setBlockTimeBuffer()
 	height := sm.nr.consensus.LatestBlock().Height
@@ -304,7 +323,7 @@ func (sm *ISAACStateManager) proposeOrWait(timer *time.Timer, round uint64) {
 	log.Debug("selected proposer", "proposer", proposer)
 
 	if proposer == sm.nr.localNode.Address() {
-		time.Sleep(sm.blockTimeBuffer)
+		sm.nr.hook.Sleep(sm.clock, sm.blockTimeBuffer)
 		if _, err := sm.nr.proposeNewBallot(round); err == nil {
 			log.Debug("propose new ballot", "proposer", proposer, "round", round, "ballotState", ballot.StateSIGN)
 		} else {
@@ -327,6 +346,7 @@ func (sm *ISAACStateManager) setState(state consensus.ISAACState) {
 	defer sm.Unlock()
 	sm.nr.Log().Debug("begin ISAACStateManager.setState()", "state", state)
 	sm.state = state
+	sm.nr.writeWALState(state)
 
 	return
 }
@@ -336,6 +356,7 @@ func (sm *ISAACStateManager) setBallotState(ballotState ballot.State) {
 	defer sm.Unlock()
 	sm.nr.Log().Debug("begin ISAACStateManager.setBallotState()", "state", sm.state)
 	sm.state.BallotState = ballotState
+	sm.nr.writeWALState(sm.state)
 
 	return
 }
@@ -0,0 +1,66 @@
+package runner
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/client"
+	"boscoin.io/sebak/lib/errors"
+)
+
+// getAccountProofFromValidators asks the validators the account proof at
+// `height`; in light mode, the node does not have the account state, so the
+// account is served from the proof after it is verified. The invalid proof is
+// ignored and the next validator is asked; the account does not exist only
+// when no validator gives the valid proof.
+func (nr *NodeRunner) getAccountProofFromValidators(address string, height uint64) (proof client.AccountProof, err error) {
+	var blk block.Block
+	if blk, err = block.GetBlockByHeight(nr.storage, height); err != nil {
+		return
+	}
+
+	var notFound, invalid bool
+	for _, v := range nr.localNode.GetValidators() {
+		if v.Address() == nr.localNode.Address() || v.Endpoint() == nil {
+			continue
+		}
+
+		u := url.URL(*v.Endpoint())
+		u.RawQuery = ""
+
+		c := client.NewClient(u.String())
+		if proof, err = c.LoadAccountProof(
+			address,
+			client.Q{Key: client.QueryHeight, Value: strconv.FormatUint(height, 10)},
+		); err != nil {
+			if e, ok := err.(client.Error); ok && e.Problem.Status == http.StatusNotFound {
+				notFound = true
+			}
+			nr.log.Debug("failed to get account proof", "validator", v.Address(), "address", address, "error", err)
+			continue
+		}
+
+		if proof.Block != blk.Hash || proof.Header.StateRoot != blk.StateRoot || !client.VerifyAccountProof(address, proof) {
+			invalid = true
+			nr.log.Debug("invalid account proof", "validator", v.Address(), "address", address)
+			continue
+		}
+
+		err = nil
+		return
+	}
+
+	proof = client.AccountProof{}
+	switch {
+	case notFound:
+		err = errors.BlockAccountDoesNotExists
+	case invalid:
+		err = errors.InvalidAccountProof
+	default:
+		err = errors.NodeNotFound
+	}
+
+	return
+}
@@ -0,0 +1,91 @@
+package runner
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network/httputils"
+	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/node/runner/api/resource"
+	"boscoin.io/sebak/lib/storage/statedb"
+	"boscoin.io/sebak/lib/storage/statedb/trie"
+	"boscoin.io/sebak/lib/voting"
+)
+
+// TestGetAccountProofFromValidators checks the account proof is found even if
+// the other validators do not know the account.
+func TestGetAccountProofFromValidators(t *testing.T) {
+	conf := common.NewTestConfig()
+	nr := createTestNodeRunner(1, conf)[0]
+	defer nr.Storage().Close()
+
+	ba := block.TestMakeBlockAccount()
+
+	sdb := statedb.New(common.Hash{}, trie.NewEthDatabase(nr.Storage()))
+	sdb.SetAccount(*ba)
+	root, err := sdb.CommitTrie()
+	require.NoError(t, err)
+	require.NoError(t, sdb.CommitDB(root))
+
+	latest := block.GetLatestBlock(nr.Storage())
+	theBlock := block.NewBlock(
+		keypair.Random().Address(),
+		voting.Basis{Height: latest.Height + 1, BlockHash: latest.Hash},
+		"",
+		[]string{},
+		base58.Encode(root.Bytes()),
+		common.NowISO8601(),
+	)
+	theBlock.MustSave(nr.Storage())
+
+	notFoundServer := func() *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			httputils.WriteJSONError(w, errors.BlockAccountDoesNotExists)
+		}))
+	}
+	proofServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ba, proof, err := sdb.GetAccountProof(ba.Address)
+		if err != nil {
+			httputils.WriteJSONError(w, err)
+			return
+		}
+		httputils.MustWriteJSON(w, 200, resource.NewAccountProof(ba, theBlock, proof))
+	}))
+	defer proofServer.Close()
+
+	addValidator := func(ts *httptest.Server) {
+		endpoint, err := common.NewEndpointFromString(ts.URL)
+		require.NoError(t, err)
+		v, err := node.NewValidator(keypair.Random().Address(), endpoint, "")
+		require.NoError(t, err)
+		nr.localNode.AddValidators(v)
+	}
+
+	for i := 0; i < 3; i++ {
+		ts := notFoundServer()
+		defer ts.Close()
+		addValidator(ts)
+	}
+
+	{ // every validator does not know the account
+		_, err := nr.getAccountProofFromValidators(ba.Address, theBlock.Height)
+		require.Equal(t, errors.BlockAccountDoesNotExists, err)
+	}
+
+	addValidator(proofServer)
+
+	{ // the proof of the other validator is used
+		proof, err := nr.getAccountProofFromValidators(ba.Address, theBlock.Height)
+		require.NoError(t, err)
+		require.Equal(t, theBlock.Hash, proof.Block)
+		require.Equal(t, ba.Balance, proof.Account.Balance)
+	}
+}
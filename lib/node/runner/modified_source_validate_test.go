@@ -61,7 +61,7 @@ func TestModifiedSourceValidate(t *testing.T) {
 	nr.TransactionPool.Add(tx1)
 	require.Equal(t, 1, nr.TransactionPool.Len())
 
-	tx2, _ := GetPaymentTransaction(kpNewAccount2, kpNewAccount1.Address(), uint64(1), uint64(100000000000))
+	tx2, _ := GetPaymentTransaction(kpNewAccount2, kpNewAccount1.Address(), uint64(0), uint64(100000000000))
 
 	b4, _ := MakeConsensusAndBlock(t, tx2, nr, nodes, proposer)
 	ba, _ = block.GetBlockAccount(st, kpNewAccount2.Address())
@@ -28,12 +28,14 @@ import (
 	"boscoin.io/sebak/lib/node/runner/api"
 	"boscoin.io/sebak/lib/storage"
 	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
 	"boscoin.io/sebak/lib/voting"
 )
 
 var DefaultHandleBaseBallotCheckerFuncs = []common.CheckerFunc{
 	BallotUnmarshal,
 	BallotNotFromKnownValidators,
+	BallotCheckEquivocation,
 	BallotCheckSYNC,
 	BallotCheckBasis,
 }
@@ -89,6 +91,16 @@ type NodeRunner struct {
 	isaacStateManager *ISAACStateManager
 	ballotSendRecord  *consensus.BallotSendRecord
 
+	equivocationDetector *consensus.EquivocationDetector
+
+	validatorsLock   sync.Mutex
+	validatorsHeight uint64 // the height until which the validator updates are applied
+
+	wal      *consensus.WAL
+	walState *consensus.ISAACState // the last state of current height in WAL
+
+	hook Hook // observes the asynchronous works; see `SetHook`
+
 	handleBaseBallotCheckerFuncs   []common.CheckerFunc
 	handleINITBallotCheckerFuncs   []common.CheckerFunc
 	handleSIGNBallotCheckerFuncs   []common.CheckerFunc
@@ -121,9 +133,11 @@ func NewNodeRunner(
 		TransactionPool: tp,
 		storage:         storage,
 		log:             log.New(logging.Ctx{"node": localNode.Alias()}),
+		hook:            NopHook{},
 		Conf:            conf,
 	}
 	nr.ballotSendRecord = consensus.NewBallotSendRecord(localNode.Alias())
+	nr.equivocationDetector = consensus.NewEquivocationDetector()
 
 	nr.localNode.SetBooting()
 
@@ -132,14 +146,25 @@ func NewNodeRunner(
 	nr.policy.SetValidators(len(nr.localNode.GetValidators()))
 
 	nr.connectionManager = c.ConnectionManager()
+	nr.updateValidators(nr.consensus.LatestBlock().Height + 1)
+
+	if len(nr.Conf.ConsensusWALPath) > 0 {
+		if err = nr.replayWAL(); err != nil {
+			nr.log.Error("failed to replay consensus WAL", "error", err)
+			return
+		}
+	}
 	nr.savingBlockOperations = NewSavingBlockOperations(
 		nr.Storage(),
 		nr.Log(),
 	)
 
-	if err = nr.savingBlockOperations.Check(); err != nil {
-		nr.log.Error("failed to check BlockOperations", "error", err)
-		return
+	// in light mode, the transactions of block are not stored
+	if !nr.Conf.LightMode {
+		if err = nr.savingBlockOperations.Check(); err != nil {
+			nr.log.Error("failed to check BlockOperations", "error", err)
+			return
+		}
 	}
 
 	nr.SetHandleBaseBallotCheckerFuncs(DefaultHandleBaseBallotCheckerFuncs...)
@@ -157,6 +182,8 @@ func NewNodeRunner(
 		nr.log.Debug("common account found", "address", nr.Conf.CommonAccountAddress)
 	}
 
+	nr.restoreTransactionPool()
+
 	nr.nodeInfo = NewNodeInfo(nr)
 	if conf.JSONRPCEndpoint != nil {
 		nr.jsonrpcServer = newJSONRPCServer(conf.JSONRPCEndpoint, nr.storage)
@@ -275,6 +302,8 @@ func (nr *NodeRunner) Ready() {
 	nr.network.AddHandler(nodeHandler.HandlerURLPattern(GetTransactionPattern), nodeHandler.GetNodeTransactionsHandler).
 		Methods("GET", "POST").
 		MatcherFunc(common.PostAndJSONMatcher)
+	nr.network.AddHandler(nodeHandler.HandlerURLPattern(GetEvidencesPattern), nodeHandler.GetEvidencesHandler).
+		Methods("GET")
 
 	nr.network.AddHandler(network.UrlPathPrefixMetric, promhttp.Handler().ServeHTTP)
 
@@ -287,52 +316,76 @@ func (nr *NodeRunner) Ready() {
 		nr.nodeInfo,
 	)
 	apiHandler.GetLatestBlock = nr.Consensus().LatestBlock
+	apiHandler.TransactionPool = nr.TransactionPool
+	if nr.Conf.LightMode {
+		apiHandler.GetAccountProof = nr.getAccountProofFromValidators
+		apiHandler.LightMode = true
+	}
 
 	nr.network.AddHandler(
 		apiHandler.HandlerURLPattern(api.GetAccountHandlerPattern),
 		baCache.WrapHandlerFunc(apiHandler.GetAccountHandler),
 	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetAccountProofHandlerPattern),
+		baCache.WrapHandlerFunc(apiHandler.GetAccountProofHandler),
+	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetAccountHistoryHandlerPattern),
+		apiHandler.FullModeOnly(listCache.WrapHandlerFunc(apiHandler.GetAccountHistoryHandler)),
+	).Methods("GET", "OPTIONS")
 	nr.network.AddHandler(
 		apiHandler.HandlerURLPattern(api.GetAccountsHandlerPattern),
-		baCache.WrapHandlerFunc(apiHandler.GetAccountsHandler),
+		apiHandler.FullModeOnly(baCache.WrapHandlerFunc(apiHandler.GetAccountsHandler)),
 	).Methods("POST", "OPTIONS").MatcherFunc(common.PostAndJSONMatcher)
 	nr.network.AddHandler(
 		apiHandler.HandlerURLPattern(api.GetAccountTransactionsHandlerPattern),
-		listCache.WrapHandlerFunc(apiHandler.GetTransactionsByAccountHandler),
+		apiHandler.FullModeOnly(listCache.WrapHandlerFunc(apiHandler.GetTransactionsByAccountHandler)),
 	).Methods("GET", "OPTIONS")
 	nr.network.AddHandler(
 		apiHandler.HandlerURLPattern(api.GetTransactionOperationHandlerPattern),
-		listCache.WrapHandlerFunc(apiHandler.GetOperationsByTxHashOpIndexHandler),
+		apiHandler.FullModeOnly(listCache.WrapHandlerFunc(apiHandler.GetOperationsByTxHashOpIndexHandler)),
 	).Methods("GET", "OPTIONS")
 	nr.network.AddHandler(
 		apiHandler.HandlerURLPattern(api.GetAccountOperationsHandlerPattern),
-		listCache.WrapHandlerFunc(apiHandler.GetOperationsByAccountHandler),
+		apiHandler.FullModeOnly(listCache.WrapHandlerFunc(apiHandler.GetOperationsByAccountHandler)),
 	).Methods("GET", "OPTIONS")
 	nr.network.AddHandler(
 		apiHandler.HandlerURLPattern(api.GetFrozenAccountHandlerPattern),
-		apiHandler.GetFrozenAccountsHandler,
+		apiHandler.FullModeOnly(apiHandler.GetFrozenAccountsHandler),
 	).Methods("GET")
 	nr.network.AddHandler(
 		apiHandler.HandlerURLPattern(api.GetAccountFrozenAccountHandlerPattern),
-		apiHandler.GetFrozenAccountsByAccountHandler,
+		apiHandler.FullModeOnly(apiHandler.GetFrozenAccountsByAccountHandler),
 	).Methods("GET")
 	nr.network.AddHandler(
 		apiHandler.HandlerURLPattern(api.GetTransactionByHashHandlerPattern),
-		cache.WrapHandlerFunc(apiHandler.GetTransactionByHashHandler),
+		apiHandler.FullModeOnly(cache.WrapHandlerFunc(apiHandler.GetTransactionByHashHandler)),
 	).Methods("GET", "OPTIONS")
 	nr.network.AddHandler(
 		apiHandler.HandlerURLPattern(api.GetTransactionOperationsHandlerPattern),
-		listCache.WrapHandlerFunc(apiHandler.GetOperationsByTxHandler),
+		apiHandler.FullModeOnly(listCache.WrapHandlerFunc(apiHandler.GetOperationsByTxHandler)),
 	).Methods("GET", "OPTIONS")
 	nr.network.AddHandler(
 		apiHandler.HandlerURLPattern(api.GetTransactionStatusHandlerPattern),
-		listCache.WrapHandlerFunc(apiHandler.GetTransactionStatusByHashHandler),
+		apiHandler.FullModeOnly(listCache.WrapHandlerFunc(apiHandler.GetTransactionStatusByHashHandler)),
+	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetTransactionProofHandlerPattern),
+		apiHandler.FullModeOnly(cache.WrapHandlerFunc(apiHandler.GetTransactionProofHandler)),
 	).Methods("GET", "OPTIONS")
 	nr.network.AddHandler(
 		apiHandler.HandlerURLPattern(api.PostSubscribePattern),
 		listCache.WrapHandlerFunc(apiHandler.PostSubscribeHandler),
 	).Methods("POST", "OPTIONS")
 
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.PostTransactionSimulatePattern),
+		apiHandler.FullModeOnly(func(w http.ResponseWriter, r *http.Request) {
+			apiHandler.PostTransactionSimulateHandler(w, r, nodeHandler.SimulateTransaction)
+		}),
+	).Methods("POST", "OPTIONS").MatcherFunc(common.PostAndJSONMatcher)
+
 	TransactionsHandler := func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == "POST" {
 
@@ -355,9 +408,39 @@ func (nr *NodeRunner) Ready() {
 
 	nr.network.AddHandler(
 		apiHandler.HandlerURLPattern(api.GetTransactionsHandlerPattern),
-		TransactionsHandler,
+		apiHandler.FullModeOnly(TransactionsHandler),
 	).Methods("GET", "POST", "OPTIONS").MatcherFunc(common.PostAndJSONMatcher)
 
+	// the pending transactions change every moment, so they are not cached;
+	// `GetTransactionPoolStatsHandlerPattern` must be added before
+	// `GetTransactionPoolItemHandlerPattern`.
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetTransactionPoolHandlerPattern),
+		apiHandler.GetTransactionPoolHandler,
+	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetTransactionPoolStatsHandlerPattern),
+		apiHandler.GetTransactionPoolStatsHandler,
+	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetTransactionPoolItemHandlerPattern),
+		apiHandler.GetTransactionPoolItemHandler,
+	).Methods("GET", "OPTIONS")
+
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetFeeStatsHandlerPattern),
+		apiHandler.FullModeOnly(apiHandler.GetFeeStatsHandler),
+	).Methods("GET", "OPTIONS")
+
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetValidatorDelegationsHandlerPattern),
+		apiHandler.FullModeOnly(listCache.WrapHandlerFunc(apiHandler.GetValidatorDelegationsHandler)),
+	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetValidatorStakeHandlerPattern),
+		apiHandler.FullModeOnly(apiHandler.GetValidatorStakeHandler),
+	).Methods("GET", "OPTIONS")
+
 	nr.network.AddHandler(
 		apiHandler.HandlerURLPattern(api.GetBlocksHandlerPattern),
 		listCache.WrapHandlerFunc(apiHandler.GetBlocksHandler),
@@ -376,6 +459,14 @@ func (nr *NodeRunner) Ready() {
 		nr.network.AddHandler(network.UrlPathPrefixDebug+"/pprof/*", pprof.Index)
 	}
 
+	// fault injection
+	if fn, ok := nr.network.(*network.FaultNetwork); ok {
+		nr.network.AddHandler(
+			network.UrlPathPrefixDebug+network.FaultsHandlerPattern,
+			fn.Injector().FaultsHandler,
+		).Methods("GET", "POST", "DELETE")
+	}
+
 	nr.network.Ready()
 
 	nr.network.AddHandler(api.GetNodeInfoPattern, apiHandler.GetNodeInfoHandler).Methods("GET", "OPTIONS")
@@ -387,8 +478,14 @@ func (nr *NodeRunner) Start() (err error) {
 
 	go nr.handleMessages()
 	go nr.ConnectValidators()
-	go nr.InitRound()
-	go nr.savingBlockOperations.Start()
+	nr.hook.Begin()
+	go func() {
+		defer nr.hook.End()
+		nr.InitRound()
+	}()
+	if !nr.Conf.LightMode {
+		go nr.savingBlockOperations.Start()
+	}
 
 	if nr.jsonrpcServer != nil {
 		go func() {
@@ -413,6 +510,11 @@ func (nr *NodeRunner) Stop() {
 	if nr.jsonrpcServer != nil {
 		nr.jsonrpcServer.Stop()
 	}
+	if nr.wal != nil {
+		if err := nr.wal.Close(); err != nil {
+			nr.log.Error("failed to close WAL", "path", nr.wal.Path(), "error", err)
+		}
+	}
 }
 
 func (nr *NodeRunner) Node() *node.LocalNode {
@@ -451,6 +553,27 @@ func (nr *NodeRunner) SavingBlockOperations() *SavingBlockOperations {
 	return nr.savingBlockOperations
 }
 
+// SetClock sets the clock of `ISAACStateManager`; it must be called before
+// `Start()`.
+func (nr *NodeRunner) SetClock(clock common.Clock) {
+	nr.isaacStateManager.SetClock(clock)
+}
+
+// SetHook sets the `Hook`; it must be called before `Start()`.
+func (nr *NodeRunner) SetHook(hook Hook) {
+	nr.hook = hook
+}
+
+// HandleMessage handles the message synchronously like it is received from
+// the network.
+func (nr *NodeRunner) HandleMessage(message common.NetworkMessage) {
+	nr.handleMessage(message)
+}
+
+func (nr *NodeRunner) ISAACState() consensus.ISAACState {
+	return nr.isaacStateManager.State()
+}
+
 func (nr *NodeRunner) BallotSendRecord() *consensus.BallotSendRecord {
 	return nr.ballotSendRecord
 }
@@ -515,6 +638,10 @@ func (nr *NodeRunner) handleMessage(message common.NetworkMessage) {
 
 func (nr *NodeRunner) handleBallotMessage(message common.NetworkMessage) (err error) {
 	nr.log.Debug("got ballot message")
+
+	// the new blocks can be stored by sync
+	nr.updateValidators(nr.consensus.LatestBlock().Height + 1)
+
 	baseChecker := &BallotChecker{
 		DefaultChecker:     common.DefaultChecker{Funcs: nr.handleBaseBallotCheckerFuncs},
 		NodeRunner:         nr,
@@ -603,6 +730,12 @@ func (nr *NodeRunner) waitForConnectingEnoughNodes() {
 
 func (nr *NodeRunner) startStateManager() {
 	nr.isaacStateManager.Start()
+
+	// continue the round of WAL instead of starting from round 0
+	if state := nr.walState; state != nil && state.Height == nr.consensus.LatestBlock().Height {
+		nr.isaacStateManager.TransitISAACState(state.Height, state.Round, ballot.StateINIT)
+		return
+	}
 	nr.isaacStateManager.NextHeight()
 	return
 }
@@ -617,16 +750,25 @@ func (nr *NodeRunner) TransitISAACState(basis voting.Basis, ballotState ballot.S
 }
 
 func (nr *NodeRunner) NextHeight() {
+	nr.updateValidators(nr.consensus.LatestBlock().Height + 1)
 	nr.isaacStateManager.NextHeight()
 }
 
 func (nr *NodeRunner) RemoveSendRecordsLowerThanOrEqualHeight(height uint64) {
 	nr.ballotSendRecord.RemoveLowerThanOrEqualHeight(height)
+	nr.truncateWAL(height)
+}
+
+func (nr *NodeRunner) RemoveEquivocationRecordsLowerThanOrEqualHeight(height uint64) {
+	nr.equivocationDetector.RemoveLowerThanOrEqualHeight(height)
 }
 
 var NewBallotTransactionCheckerFuncs = []common.CheckerFunc{
 	IsNew,
 	BallotTransactionsSameSource,
+	BallotTransactionsSequenceID,
+	BallotTransactionsMergedAccount,
+	BallotTransactionsDelegate,
 }
 
 func (nr *NodeRunner) proposeNewBallot(round uint64) (ballot.Ballot, error) {
@@ -674,6 +816,15 @@ func (nr *NodeRunner) proposeNewBallot(round uint64) (ballot.Ballot, error) {
 	var validTransactions []transaction.Transaction
 	var validTransactionHashes []string
 	var ops int
+	// the time bounds are checked with the confirmed time of ballot like the
+	// other validators, see `BallotTransactionsAllValid`
+	confirmed := common.NowISO8601()
+	now, err := common.ParseISO8601(confirmed)
+	if err != nil {
+		return ballot.Ballot{}, err
+	}
+	minFee := block.NextMinFee(b.Header, nr.Conf.OpsInBallotLimit)
+	skipped := map[string]bool{} // the following transactions of skipped source are also skipped
 	for _, hash := range transactionsChecker.ValidTransactions {
 		var tx transaction.Transaction
 		var found bool
@@ -684,7 +835,28 @@ func (nr *NodeRunner) proposeNewBallot(round uint64) (ballot.Ballot, error) {
 			return ballot.Ballot{}, errors.TransactionNotFound
 		}
 
+		if skipped[tx.B.Source] {
+			continue
+		}
+
+		// the transaction out of it's time bounds will be rejected by the
+		// other validators
+		if tx.B.TimeBounds != nil {
+			if err = tx.B.TimeBounds.Check(b.Height+1, now); err != nil {
+				skipped[tx.B.Source] = true
+				continue
+			}
+		}
+
+		// the transaction under the minimum fee is kept in pool until the
+		// minimum fee goes down
+		if tx.B.Fee < tx.TotalMinFee(minFee) {
+			skipped[tx.B.Source] = true
+			continue
+		}
+
 		if ops+len(tx.B.Operations) > nr.Conf.OpsInBallotLimit {
+			skipped[tx.B.Source] = true
 			continue
 		}
 
@@ -699,6 +871,7 @@ func (nr *NodeRunner) proposeNewBallot(round uint64) (ballot.Ballot, error) {
 
 	proposerAddr := nr.consensus.SelectProposer(b.Height, round)
 	blt := ballot.NewBallot(nr.localNode.Address(), proposerAddr, basis, validTransactionHashes)
+	blt.SetProposerConfirmed(confirmed)
 	blt.SetVote(ballot.StateINIT, voting.YES)
 
 	opc, err := ballot.NewCollectTxFeeFromBallot(*blt, nr.Conf.CommonAccountAddress, validTransactions...)
@@ -711,12 +884,44 @@ func (nr *NodeRunner) proposeNewBallot(round uint64) (ballot.Ballot, error) {
 		return ballot.Ballot{}, err
 	}
 
-	ptx, err := ballot.NewProposerTransactionFromBallot(*blt, opc, opi)
+	var optionals []operation.Body
+	recipients := getTxFeeRecipients(nr.Storage(), nr.Conf, *blt, nr.localNode.GetValidators(), nr.Policy().Threshold())
+	if len(recipients) > 0 {
+		var opd operation.DistributeTxFee
+		var found bool
+		if opc, opd, found = ballot.NewDistributeTxFeeFromBallot(*blt, opc, nr.Conf.TxFeeValidatorShare, recipients); found {
+			optionals = append(optionals, opd)
+		}
+	}
+
+	if nr.Conf.InflationToDelegators {
+		rewards, err := getDelegationRewards(nr.Storage(), opi.Amount, nr.localNode.GetValidators())
+		if err != nil {
+			return ballot.Ballot{}, err
+		}
+		if len(rewards) > 0 {
+			optionals = append(optionals, ballot.NewDelegationRewardFromBallot(*blt, rewards))
+		}
+	}
+
+	ptx, err := ballot.NewProposerTransactionFromBallot(*blt, opc, opi, optionals...)
 	if err != nil {
 		return ballot.Ballot{}, err
 	}
 
 	blt.SetProposerTransaction(ptx)
+
+	proposedTransactions := make([]*transaction.Transaction, len(validTransactions))
+	for i := range validTransactions {
+		proposedTransactions[i] = &validTransactions[i]
+	}
+	// the ballot without state root will be rejected by the other validators
+	stateRoot, err := getBallotStateRoot(nr.Storage(), *blt, proposedTransactions, nr.log)
+	if err != nil {
+		nr.log.Error("failed to get state root of new ballot", "basis", basis, "error", err)
+		return ballot.Ballot{}, err
+	}
+	blt.SetStateRoot(stateRoot)
 	blt.Sign(nr.localNode.Keypair(), nr.Conf.NetworkID)
 
 	nr.log.Debug(
@@ -737,6 +942,12 @@ func (nr *NodeRunner) NodeInfo() node.NodeInfo {
 }
 
 func (nr *NodeRunner) BroadcastBallot(b ballot.Ballot) {
+	// the node, which is removed from the validators, does not vote
+	if !nr.localNode.HasValidators(nr.localNode.Address()) {
+		nr.Log().Debug("return; local node is not validator", "ballot", b)
+		return
+	}
+
 	if nr.Node().State() == node.StateBOOTING && !nr.connectionManager.IsReady() {
 		nr.waitForConnectingEnoughNodes()
 	}
@@ -755,6 +966,12 @@ func (nr *NodeRunner) BroadcastBallot(b ballot.Ballot) {
 		return
 	}
 
+	// the ballot is written to WAL before it is sent
+	if err := nr.writeWALBallot(b); err != nil {
+		nr.Log().Error("failed to write ballot to WAL; ballot is not broadcasted", "ballot", b, "error", err)
+		return
+	}
+
 	nr.Log().Debug(
 		"broadcast ballot include itself",
 		"ballot", b,
@@ -762,7 +979,9 @@ func (nr *NodeRunner) BroadcastBallot(b ballot.Ballot) {
 
 	nr.ballotSendRecord.SetSent(state)
 
+	nr.hook.Begin()
 	go func() {
+		defer nr.hook.End()
 		encoded, _ := b.Serialize()
 		nr.Network().MessageBroker().Receive(common.NewNetworkMessage(common.BallotMessage, encoded))
 	}()
@@ -16,7 +16,9 @@ import (
 	"boscoin.io/sebak/lib/consensus"
 	"boscoin.io/sebak/lib/network"
 	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/storage"
 	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
 	"boscoin.io/sebak/lib/voting"
 )
 
@@ -267,7 +269,7 @@ func TestExpiredBallotCheckProposer(t *testing.T) {
 	}
 
 	// The createNodeRunnerForTesting has FixedSelector{localNode.Address()} so the proposer is always nr(nodes[0]).
-	validBallot := GenerateEmptyTxBallot(nr.localNode, basis, ballot.StateSIGN, nodes[1], conf)
+	validBallot := GenerateEmptyTxBallot(nr.Storage(), nr.localNode, basis, ballot.StateSIGN, nodes[1], conf)
 	validBallot.SetVote(ballot.StateSIGN, voting.EXP)
 
 	checker := &BallotChecker{
@@ -288,7 +290,7 @@ func TestExpiredBallotCheckProposer(t *testing.T) {
 
 	// The createNodeRunnerForTesting has FixedSelector{localNode.Address()} so the proposer is always nr(nodes[0]).
 	// The invalidBallot has nodes[1] as a proposer so it is invalid.
-	invalidBallot := GenerateEmptyTxBallot(nodes[1], basis, ballot.StateSIGN, nodes[1], common.NewTestConfig())
+	invalidBallot := GenerateEmptyTxBallot(nr.Storage(), nodes[1], basis, ballot.StateSIGN, nodes[1], common.NewTestConfig())
 	invalidBallot.SetVote(ballot.StateSIGN, voting.EXP)
 
 	checker = &BallotChecker{
@@ -309,6 +311,17 @@ func TestExpiredBallotCheckProposer(t *testing.T) {
 	require.Equal(t, voting.NO, checker.VotingHole)
 }
 
+// saveTransactionAccounts stores the source and the target accounts of `tx`,
+// so the state root of the proposed ballot can be made.
+func saveTransactionAccounts(st *storage.LevelDBBackend, tx transaction.Transaction) {
+	block.NewBlockAccount(tx.B.Source, tx.TotalAmount(true).MustAdd(common.BaseReserve)).MustSave(st)
+	for _, op := range tx.B.Operations {
+		if top, ok := op.B.(operation.Targetable); ok {
+			block.NewBlockAccount(top.TargetAddress(), common.BaseReserve).MustSave(st)
+		}
+	}
+}
+
 // NodeRunner must propose new ballot by common.Config.OpsInBallotLimit.
 func TestProposedBallotByOpsInBallotLimit(t *testing.T) {
 	{ // limit=100 tx0=50, tx1=50; tx0 and tx1 will be in ballot
@@ -322,8 +335,10 @@ func TestProposedBallotByOpsInBallotLimit(t *testing.T) {
 
 		_, tx0 := transaction.TestMakeTransaction(networkID, 50)
 		txs = append(txs, tx0.GetHash())
+		saveTransactionAccounts(nr.Storage(), tx0)
 		nr.TransactionPool.Add(tx0)
 		_, tx1 := transaction.TestMakeTransaction(networkID, 50)
+		saveTransactionAccounts(nr.Storage(), tx1)
 		nr.TransactionPool.Add(tx1)
 		txs = append(txs, tx1.GetHash())
 
@@ -355,8 +370,10 @@ func TestProposedBallotByOpsInBallotLimit(t *testing.T) {
 
 		_, tx0 := transaction.TestMakeTransaction(networkID, 50)
 		txs = append(txs, tx0.GetHash())
+		saveTransactionAccounts(nr.Storage(), tx0)
 		nr.TransactionPool.Add(tx0)
 		_, tx1 := transaction.TestMakeTransaction(networkID, 51)
+		saveTransactionAccounts(nr.Storage(), tx1)
 		nr.TransactionPool.Add(tx1)
 		txs = append(txs, tx1.GetHash())
 
@@ -388,11 +405,14 @@ func TestProposedBallotByOpsInBallotLimit(t *testing.T) {
 
 		_, tx0 := transaction.TestMakeTransaction(networkID, 50)
 		txs = append(txs, tx0.GetHash())
+		saveTransactionAccounts(nr.Storage(), tx0)
 		nr.TransactionPool.Add(tx0)
 		_, tx1 := transaction.TestMakeTransaction(networkID, 51)
+		saveTransactionAccounts(nr.Storage(), tx1)
 		nr.TransactionPool.Add(tx1)
 		txs = append(txs, tx1.GetHash())
 		_, tx2 := transaction.TestMakeTransaction(networkID, 10)
+		saveTransactionAccounts(nr.Storage(), tx2)
 		nr.TransactionPool.Add(tx2)
 		txs = append(txs, tx2.GetHash())
 
@@ -426,14 +446,18 @@ func TestProposedBallotByOpsInBallotLimit(t *testing.T) {
 
 		_, tx0 := transaction.TestMakeTransaction(networkID, 50)
 		txs = append(txs, tx0.GetHash())
+		saveTransactionAccounts(nr.Storage(), tx0)
 		nr.TransactionPool.Add(tx0)
 		_, tx1 := transaction.TestMakeTransaction(networkID, 51)
+		saveTransactionAccounts(nr.Storage(), tx1)
 		nr.TransactionPool.Add(tx1)
 		txs = append(txs, tx1.GetHash())
 		_, tx2 := transaction.TestMakeTransaction(networkID, 10)
+		saveTransactionAccounts(nr.Storage(), tx2)
 		nr.TransactionPool.Add(tx2)
 		txs = append(txs, tx2.GetHash())
 		_, tx3 := transaction.TestMakeTransaction(networkID, 40)
+		saveTransactionAccounts(nr.Storage(), tx3)
 		nr.TransactionPool.Add(tx3)
 		txs = append(txs, tx3.GetHash())
 
@@ -458,3 +482,90 @@ func TestProposedBallotByOpsInBallotLimit(t *testing.T) {
 		require.True(t, found)
 	}
 }
+
+// NodeRunner must not propose the transactions under the minimum fee of the
+// next block; they are kept in pool.
+func TestProposedBallotByMinFee(t *testing.T) {
+	config := common.NewTestConfig()
+	config.OpsInBallotLimit = 100
+	nr, _, _ := createNodeRunnerForTesting(1, config, nil)
+
+	// the operations are at target, so the minimum fee is not changed
+	latest := block.GetLatestBlock(nr.Storage())
+	blk := block.NewBlockWithFee(
+		latest.Proposer,
+		voting.Basis{Height: latest.Height + 1, BlockHash: latest.Hash, TotalTxs: latest.TotalTxs, TotalOps: latest.TotalOps},
+		"",
+		nil,
+		latest.StateRoot,
+		common.NowISO8601(),
+		common.BaseFee.MustMult(2),
+		50,
+	)
+	blk.MustSave(nr.Storage())
+	require.Equal(t, common.BaseFee.MustMult(2), block.NextMinFee(blk.Header, config.OpsInBallotLimit))
+
+	_, tx0 := transaction.TestMakeTransaction(networkID, 1)
+	saveTransactionAccounts(nr.Storage(), tx0)
+	require.NoError(t, nr.TransactionPool.Add(tx0))
+	kp, tx1 := transaction.TestMakeTransaction(networkID, 1)
+	tx1.B.Fee = common.BaseFee.MustMult(2)
+	tx1.Sign(kp, networkID)
+	saveTransactionAccounts(nr.Storage(), tx1)
+	require.NoError(t, nr.TransactionPool.Add(tx1))
+
+	blt, err := nr.proposeNewBallot(0)
+	require.NoError(t, err)
+	require.Equal(t, []string{tx1.GetHash()}, blt.Transactions())
+	require.True(t, nr.TransactionPool.Has(tx0.GetHash()))
+}
+
+// NodeRunner must not propose the ballot without state root; it will be
+// rejected by the other validators.
+func TestProposedBallotWithoutStateRoot(t *testing.T) {
+	nr, _, _ := createNodeRunnerForTesting(1, common.NewTestConfig(), nil)
+
+	// the target of payment does not exist
+	_, tx := transaction.TestMakeTransaction(networkID, 1)
+	block.NewBlockAccount(tx.B.Source, tx.TotalAmount(true).MustAdd(common.BaseReserve)).MustSave(nr.Storage())
+	require.NoError(t, nr.TransactionPool.Add(tx))
+
+	_, err := nr.proposeNewBallot(0)
+	require.Error(t, err)
+}
+
+func TestProposedBallotDistributeTxFee(t *testing.T) {
+	config := common.NewTestConfig()
+	config.TxFeeValidatorShare = 20
+	config.TxFeeDistribution = common.TxFeeDistributionProposer
+	nr, _, _ := createNodeRunnerForTesting(1, config, nil)
+
+	_, tx := transaction.TestMakeTransaction(networkID, 1)
+	saveTransactionAccounts(nr.Storage(), tx)
+	require.NoError(t, nr.TransactionPool.Add(tx))
+
+	{ // without the account of proposer, all the fee goes to common account
+		blt, err := nr.proposeNewBallot(0)
+		require.NoError(t, err)
+		require.Equal(t, 1, blt.TransactionsLength())
+
+		_, found := blt.ProposerTransaction().DistributeTxFee()
+		require.False(t, found)
+		opc, _ := blt.ProposerTransaction().CollectTxFee()
+		require.Equal(t, tx.B.Fee, opc.Amount)
+	}
+
+	block.NewBlockAccount(nr.Node().Address(), common.BaseReserve).MustSave(nr.Storage())
+
+	blt, err := nr.proposeNewBallot(1)
+	require.NoError(t, err)
+
+	opd, found := blt.ProposerTransaction().DistributeTxFee()
+	require.True(t, found)
+	require.Equal(t, 1, len(opd.Recipients))
+	require.Equal(t, nr.Node().Address(), opd.Recipients[0].Address)
+	require.Equal(t, tx.B.Fee.MustMult(20)/100, opd.Recipients[0].Amount)
+
+	opc, _ := blt.ProposerTransaction().CollectTxFee()
+	require.Equal(t, tx.B.Fee, opc.Amount+opd.Recipients[0].Amount)
+}
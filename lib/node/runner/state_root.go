@@ -0,0 +1,136 @@
+package runner
+
+import (
+	"sort"
+
+	"github.com/btcsuite/btcutil/base58"
+	logging "github.com/inconshreveable/log15"
+
+	"boscoin.io/sebak/lib/ballot"
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/storage/statedb"
+	"boscoin.io/sebak/lib/storage/statedb/trie"
+	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+// StateAccounts returns the addresses of accounts, which can be changed by
+// the transactions and the proposer transaction of block.
+func StateAccounts(transactions []*transaction.Transaction, ptx ballot.ProposerTransaction) []string {
+	addresses := map[string]bool{}
+	addOperations := func(ops []operation.Operation) {
+		for _, op := range ops {
+			switch opb := op.B.(type) {
+			case operation.Targetable:
+				addresses[opb.TargetAddress()] = true
+			case operation.InflationPF:
+				addresses[opb.FundingAddress] = true
+			case operation.DistributeTxFee:
+				for _, r := range opb.Recipients {
+					addresses[r.Address] = true
+				}
+			case operation.DelegationReward:
+				for _, r := range opb.Rewards {
+					addresses[r.Target] = true
+				}
+			}
+		}
+	}
+
+	for _, tx := range transactions {
+		addresses[tx.B.Source] = true
+		addOperations(tx.B.Operations)
+	}
+	addOperations(ptx.B.Operations)
+
+	var sorted []string
+	for address := range addresses {
+		sorted = append(sorted, address)
+	}
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+// UpdateStateRoot applies the current state of accounts in `st` to the state
+// trie of `prevRoot` and returns the new state root. If `prevRoot` is empty,
+// all the stored accounts are put into the new trie. With `commit`, the trie
+// nodes are stored into `st`.
+func UpdateStateRoot(st *storage.LevelDBBackend, prevRoot string, addresses []string, commit bool) (root string, err error) {
+	var rootHash common.Hash
+	if len(prevRoot) > 0 {
+		rootHash = common.BytesToHash(base58.Decode(prevRoot))
+	} else {
+		// NOTE `GetBlockAccountsByCreated` does not see the accounts in batch,
+		// but they are in `addresses`.
+		iterFunc, closeFunc := block.GetBlockAccountsByCreated(st, nil)
+		for {
+			ba, hasNext, _ := iterFunc()
+			if !hasNext {
+				break
+			}
+			addresses = append(addresses, ba.Address)
+		}
+		closeFunc()
+	}
+
+	sdb := statedb.New(rootHash, trie.NewEthDatabase(st))
+	for _, address := range addresses {
+		var ba *block.BlockAccount
+		if ba, err = block.GetBlockAccount(st, address); err == errors.StorageRecordDoesNotExist {
+			if err = sdb.RemoveAccount(address); err != nil {
+				return
+			}
+			continue
+		} else if err != nil {
+			return
+		}
+		sdb.SetAccount(*ba)
+	}
+
+	if rootHash, err = sdb.CommitTrie(); err != nil {
+		return
+	}
+	if commit {
+		if err = sdb.CommitDB(rootHash); err != nil {
+			return
+		}
+	}
+
+	root = base58.Encode(rootHash.Bytes())
+	return
+}
+
+// getBallotStateRoot returns the state root after the transactions and the
+// proposer transaction of ballot are applied. The changes are discarded.
+func getBallotStateRoot(st *storage.LevelDBBackend, b ballot.Ballot, transactions []*transaction.Transaction, log logging.Logger) (root string, err error) {
+	var bs *storage.LevelDBBackend
+	if bs, err = st.OpenBatch(); err != nil {
+		return
+	}
+	defer bs.Discard()
+
+	var prevBlock block.Block
+	if prevBlock, err = block.GetBlockByHeight(bs, b.VotingBasis().Height); err != nil {
+		return
+	}
+
+	// the state root does not depend on the hash of block
+	blk := block.Block{
+		Header: block.Header{
+			Height:       prevBlock.Height + 1,
+			ProposedTime: b.ProposerConfirmed(),
+		},
+	}
+	if err = FinishTransactions(blk, transactions, bs); err != nil {
+		return
+	}
+	if err = ProcessProposerTransaction(bs, blk, b.ProposerTransaction(), log); err != nil {
+		return
+	}
+
+	return UpdateStateRoot(bs, prevBlock.StateRoot, StateAccounts(transactions, b.ProposerTransaction()), false)
+}
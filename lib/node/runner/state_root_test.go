@@ -0,0 +1,96 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/voting"
+)
+
+func TestUpdateStateRoot(t *testing.T) {
+	st := block.InitTestBlockchain()
+	defer st.Close()
+
+	root, err := UpdateStateRoot(st, "", nil, true)
+	require.NoError(t, err)
+	require.NotEmpty(t, root)
+
+	genesisAccount, err := block.GetBlockAccount(st, block.GenesisKP.Address())
+	require.NoError(t, err)
+	genesisAccount.Balance = genesisAccount.Balance.MustSub(common.BaseFee)
+	genesisAccount.MustSave(st)
+
+	newRoot, err := UpdateStateRoot(st, root, []string{genesisAccount.Address}, true)
+	require.NoError(t, err)
+	require.NotEqual(t, root, newRoot)
+
+	// the state root from the previous root is same with the state root
+	// from all the accounts
+	allRoot, err := UpdateStateRoot(st, "", nil, false)
+	require.NoError(t, err)
+	require.Equal(t, newRoot, allRoot)
+
+	// removed account
+	newAccount := block.NewBlockAccount(keypair.Random().Address(), common.BaseReserve)
+	newAccount.MustSave(st)
+
+	addedRoot, err := UpdateStateRoot(st, newRoot, []string{newAccount.Address}, true)
+	require.NoError(t, err)
+	require.NotEqual(t, newRoot, addedRoot)
+
+	require.NoError(t, newAccount.Remove(st))
+	removedRoot, err := UpdateStateRoot(st, addedRoot, []string{newAccount.Address}, false)
+	require.NoError(t, err)
+	require.Equal(t, newRoot, removedRoot)
+}
+
+func TestStateRootInBlockHeader(t *testing.T) {
+	nr, nodes, _ := createNodeRunnerForTesting(3, common.NewTestConfig(), nil)
+
+	tx, _, _ := GetCreateAccountTransaction(uint64(0), uint64(1000000))
+
+	blk, err := MakeConsensusAndBlock(t, tx, nr, nodes, nr.localNode)
+	require.NoError(t, err)
+	require.NotEmpty(t, blk.StateRoot)
+
+	stateRoot, err := UpdateStateRoot(nr.Storage(), "", nil, false)
+	require.NoError(t, err)
+	require.Equal(t, stateRoot, blk.StateRoot)
+}
+
+func TestBallotTransactionsStateRoot(t *testing.T) {
+	p := &ballotCheckerProposedTransaction{}
+	p.Prepare()
+
+	blt := p.MakeBallot(3)
+
+	checkBallot := func() voting.Hole {
+		transactionsChecker := &BallotTransactionChecker{
+			DefaultChecker:   common.DefaultChecker{Funcs: INITBallotTransactionCheckerFuncs},
+			NodeRunner:       p.nr,
+			Conf:             p.nr.Conf,
+			LocalNode:        p.nr.Node(),
+			Ballot:           *blt,
+			Transactions:     blt.Transactions(),
+			VotingHole:       voting.NOTYET,
+			transactionCache: NewTransactionCache(p.nr.Storage(), p.nr.TransactionPool),
+		}
+		if err := common.RunChecker(transactionsChecker, common.DefaultDeferFunc); err != nil {
+			if _, ok := err.(common.CheckerErrorStop); !ok {
+				return voting.NO
+			}
+		}
+		return transactionsChecker.VotingHole
+	}
+
+	require.Equal(t, voting.YES, checkBallot())
+
+	// with wrong state root
+	blt.SetStateRoot(common.MakeMerkleRoot(nil))
+	blt.Sign(p.proposerNode.Keypair(), networkID)
+	require.Equal(t, voting.NO, checkBallot())
+}
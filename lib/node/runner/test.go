@@ -12,6 +12,7 @@ import (
 	"boscoin.io/sebak/lib/consensus"
 	"boscoin.io/sebak/lib/network"
 	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/storage"
 	"boscoin.io/sebak/lib/transaction"
 	"boscoin.io/sebak/lib/voting"
 )
@@ -76,7 +77,7 @@ func GetPaymentTransaction(kpSource *keypair.Full, target string, sequenceID uin
 	}
 }
 
-func GenerateBallot(proposer *node.LocalNode, basis voting.Basis, tx transaction.Transaction, ballotState ballot.State, sender *node.LocalNode, conf common.Config) *ballot.Ballot {
+func GenerateBallot(st *storage.LevelDBBackend, proposer *node.LocalNode, basis voting.Basis, tx transaction.Transaction, ballotState ballot.State, sender *node.LocalNode, conf common.Config) *ballot.Ballot {
 	b := ballot.NewBallot(sender.Address(), proposer.Address(), basis, []string{tx.GetHash()})
 	b.SetVote(ballot.StateINIT, voting.YES)
 
@@ -84,6 +85,7 @@ func GenerateBallot(proposer *node.LocalNode, basis voting.Basis, tx transaction
 	opc, _ := ballot.NewCollectTxFeeFromBallot(*b, block.CommonKP.Address(), tx)
 	ptx, _ := ballot.NewProposerTransactionFromBallot(*b, opc, opi)
 	b.SetProposerTransaction(ptx)
+	setTestBallotStateRoot(st, b, tx)
 	b.Sign(proposer.Keypair(), networkID)
 
 	b.SetVote(ballotState, voting.YES)
@@ -96,7 +98,7 @@ func GenerateBallot(proposer *node.LocalNode, basis voting.Basis, tx transaction
 	return b
 }
 
-func GenerateEmptyTxBallot(proposer *node.LocalNode, basis voting.Basis, ballotState ballot.State, sender *node.LocalNode, conf common.Config) *ballot.Ballot {
+func GenerateEmptyTxBallot(st *storage.LevelDBBackend, proposer *node.LocalNode, basis voting.Basis, ballotState ballot.State, sender *node.LocalNode, conf common.Config) *ballot.Ballot {
 	b := ballot.NewBallot(sender.Address(), proposer.Address(), basis, []string{})
 	b.SetVote(ballot.StateINIT, voting.YES)
 
@@ -104,6 +106,7 @@ func GenerateEmptyTxBallot(proposer *node.LocalNode, basis voting.Basis, ballotS
 	opc, _ := ballot.NewCollectTxFeeFromBallot(*b, block.CommonKP.Address())
 	ptx, _ := ballot.NewProposerTransactionFromBallot(*b, opc, opi)
 	b.SetProposerTransaction(ptx)
+	setTestBallotStateRoot(st, b)
 	b.Sign(proposer.Keypair(), networkID)
 
 	b.SetVote(ballotState, voting.YES)
@@ -116,6 +119,21 @@ func GenerateEmptyTxBallot(proposer *node.LocalNode, basis voting.Basis, ballotS
 	return b
 }
 
+// setTestBallotStateRoot sets the state root of ballot, which is calculated
+// with the given transactions.
+func setTestBallotStateRoot(st *storage.LevelDBBackend, b *ballot.Ballot, txs ...transaction.Transaction) {
+	var transactions []*transaction.Transaction
+	for i := range txs {
+		transactions = append(transactions, &txs[i])
+	}
+
+	stateRoot, err := getBallotStateRoot(st, *b, transactions, log)
+	if err != nil {
+		panic(err)
+	}
+	b.SetStateRoot(stateRoot)
+}
+
 func ReceiveBallot(nodeRunner *NodeRunner, ballot *ballot.Ballot) error {
 	data, err := ballot.Serialize()
 	if err != nil {
@@ -185,22 +203,22 @@ func MakeConsensusAndBlock(t *testing.T, tx transaction.Transaction, nr *NodeRun
 
 	// Check that the transaction is in RunningRounds
 
-	ballotSIGN1 := GenerateBallot(proposer, basis, tx, ballot.StateSIGN, nodes[1], conf)
+	ballotSIGN1 := GenerateBallot(nr.Storage(), proposer, basis, tx, ballot.StateSIGN, nodes[1], conf)
 	err = ReceiveBallot(nr, ballotSIGN1)
 	require.NoError(t, err)
 
-	ballotSIGN2 := GenerateBallot(proposer, basis, tx, ballot.StateSIGN, nodes[2], conf)
+	ballotSIGN2 := GenerateBallot(nr.Storage(), proposer, basis, tx, ballot.StateSIGN, nodes[2], conf)
 	err = ReceiveBallot(nr, ballotSIGN2)
 	require.NoError(t, err)
 
 	rr := nr.Consensus().RunningRounds[basis.Index()]
 	require.Equal(t, 2, len(rr.Voted[proposer.Address()].GetResult(ballot.StateSIGN)))
 
-	ballotACCEPT1 := GenerateBallot(proposer, basis, tx, ballot.StateACCEPT, nodes[1], conf)
+	ballotACCEPT1 := GenerateBallot(nr.Storage(), proposer, basis, tx, ballot.StateACCEPT, nodes[1], conf)
 	err = ReceiveBallot(nr, ballotACCEPT1)
 	require.NoError(t, err)
 
-	ballotACCEPT2 := GenerateBallot(proposer, basis, tx, ballot.StateACCEPT, nodes[2], conf)
+	ballotACCEPT2 := GenerateBallot(nr.Storage(), proposer, basis, tx, ballot.StateACCEPT, nodes[2], conf)
 	err = ReceiveBallot(nr, ballotACCEPT2)
 
 	blk := nr.Consensus().LatestBlock()
@@ -0,0 +1,63 @@
+package runner
+
+import (
+	"time"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/transaction"
+)
+
+// restoreTransactionPool restores the pending transactions from the
+// `transaction.PoolJournal`; the transactions are validated again by the
+// current state of accounts and the stale or invalid ones are dropped from
+// the journal.
+func (nr *NodeRunner) restoreTransactionPool() {
+	journal := transaction.NewPoolJournal(nr.storage)
+
+	var restored, dropped int
+	minFee := block.GetNextMinFee(nr.storage, nr.Conf.OpsInBallotLimit)
+	for _, tx := range journal.Transactions() {
+		err := nr.validateJournaledTransaction(tx, minFee)
+		if err == nil {
+			err = nr.TransactionPool.Add(tx)
+		}
+		if err != nil {
+			nr.log.Info("journaled transaction dropped", "transaction", tx.GetHash(), "error", err)
+			journal.Remove(tx.GetHash())
+			dropped++
+			continue
+		}
+		restored++
+	}
+
+	nr.TransactionPool.SetJournal(journal)
+	nr.log.Debug("transaction pool restored", "restored", restored, "dropped", dropped)
+}
+
+func (nr *NodeRunner) validateJournaledTransaction(tx transaction.Transaction, minFee common.Amount) (err error) {
+	if err = tx.IsWellFormedWithMinFee(nr.Conf, minFee); err != nil {
+		return
+	}
+
+	var exists bool
+	if exists, err = block.ExistsBlockTransaction(nr.storage, tx.GetHash()); err != nil {
+		return
+	} else if exists {
+		return errors.NewButKnownMessage
+	}
+
+	pending := nr.TransactionPool.PendingFromSource(tx.Source())
+	if err = ValidateTxWithPending(nr.storage, nr.Conf, minFee, pending, tx); err != nil {
+		return
+	}
+
+	if tb := tx.B.TimeBounds; tb != nil {
+		if tb.IsExpired(nr.consensus.LatestBlock().Height+1, time.Now()) {
+			return errors.TransactionExpired
+		}
+	}
+
+	return
+}
@@ -0,0 +1,49 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/transaction"
+)
+
+func TestRestoreTransactionPool(t *testing.T) {
+	nr, _ := MakeNodeRunner()
+
+	genesisAccount, err := block.GetBlockAccount(nr.Storage(), block.GenesisKP.Address())
+	require.NoError(t, err)
+
+	// sequential transactions of same source
+	tx0, _, _ := GetCreateAccountTransaction(genesisAccount.SequenceID, uint64(common.BaseReserve))
+	tx1, _, _ := GetCreateAccountTransaction(genesisAccount.SequenceID+1, uint64(common.BaseReserve))
+	require.NoError(t, nr.TransactionPool.Add(tx0))
+	require.NoError(t, nr.TransactionPool.Add(tx1))
+
+	// the transaction of unknown source is journaled, but it is not valid
+	_, invalid := transaction.TestMakeTransaction(networkID, 1)
+	journal := transaction.NewPoolJournal(nr.Storage())
+	require.NoError(t, journal.Add(invalid))
+	require.Equal(t, 3, len(journal.Transactions()))
+
+	// restart with the empty pool
+	restarted, err := NewNodeRunner(nr.Node(), nr.policy, nr.network, nr.consensus, nr.Storage(), transaction.NewPool(nr.Conf), nr.Conf)
+	require.NoError(t, err)
+
+	require.Equal(t, 2, restarted.TransactionPool.Len())
+	require.Equal(
+		t,
+		[]transaction.Transaction{tx0, tx1},
+		restarted.TransactionPool.PendingFromSource(block.GenesisKP.Address()),
+	)
+
+	// the invalid transaction is dropped from the journal
+	require.Equal(t, []transaction.Transaction{tx0, tx1}, journal.Transactions())
+
+	// the removed transaction is also removed from the journal
+	restarted.TransactionPool.RemoveStale(block.GenesisKP.Address(), genesisAccount.SequenceID+1)
+	require.Equal(t, []transaction.Transaction{tx1}, journal.Transactions())
+
+}
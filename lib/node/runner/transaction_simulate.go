@@ -0,0 +1,137 @@
+package runner
+
+import (
+	"boscoin.io/sebak/lib/ballot"
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/node/runner/api/resource"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction"
+)
+
+// SimulateTransactionCheckerFuncs is `HandleTransactionCheckerFuncs` without
+// adding the transaction to the pool and broadcasting it.
+var SimulateTransactionCheckerFuncs = []common.CheckerFunc{
+	TransactionUnmarshal,
+	HasTransaction,
+	MessageHasSameSource,
+	MessageValidate,
+}
+
+// SimulateTransaction runs the transaction against the current state. The
+// malformed transaction returns error; for the well-formed transaction, the
+// result has the errors of transaction and it's operations, or the balance
+// changes when the transaction is valid.
+func (api NetworkHandlerNode) SimulateTransaction(body []byte) (*resource.TransactionSimulation, error) {
+	message := common.NetworkMessage{Type: common.TransactionMessage, Data: body}
+	checker := &MessageChecker{
+		DefaultChecker:  common.DefaultChecker{Funcs: SimulateTransactionCheckerFuncs},
+		Consensus:       api.consensus,
+		TransactionPool: api.transactionPool,
+		Storage:         api.storage,
+		LocalNode:       api.localNode,
+		NetworkID:       api.conf.NetworkID,
+		Message:         message,
+		Log:             log,
+		Conf:            api.conf,
+	}
+
+	err := common.RunChecker(checker, common.DefaultDeferFunc)
+	tx := checker.Transaction
+	if tx.IsEmpty() {
+		if err == nil {
+			err = errors.InvalidMessage
+		}
+		return nil, err
+	}
+
+	result := &resource.TransactionSimulation{Hash: tx.GetHash()}
+
+	pending := api.transactionPool.PendingFromSource(tx.B.Source)
+	source, sourceErr := getPendingSourceAccount(api.storage, pending, tx)
+	for _, op := range tx.B.Operations {
+		o := resource.OperationSimulation{Type: op.H.Type}
+		if sourceErr == nil {
+			o.Error = simulationError(ValidateOp(api.storage, api.conf, source, op))
+		}
+		result.Operations = append(result.Operations, o)
+	}
+
+	if err != nil {
+		result.Error = simulationError(err)
+		return result, nil
+	}
+
+	if result.BalanceChanges, err = simulateBalanceChanges(api.storage, pending, tx); err != nil {
+		result.Error = simulationError(err)
+	}
+
+	return result, nil
+}
+
+// simulateBalanceChanges applies the transaction after the pending
+// transactions of same source; the changes are discarded.
+func simulateBalanceChanges(st *storage.LevelDBBackend, pending []transaction.Transaction, tx transaction.Transaction) (changes []resource.BalanceChange, err error) {
+	var bs *storage.LevelDBBackend
+	if bs, err = st.OpenBatch(); err != nil {
+		return
+	}
+	defer bs.Discard()
+
+	latest := block.GetLatestBlock(bs)
+	blk := block.Block{
+		Header: block.Header{
+			Height:       latest.Height + 1,
+			ProposedTime: common.NowISO8601(),
+		},
+	}
+
+	var previous []*transaction.Transaction
+	for i := range pending {
+		if pending[i].B.SequenceID < tx.B.SequenceID {
+			previous = append(previous, &pending[i])
+		}
+	}
+	if err = FinishTransactions(blk, previous, bs); err != nil {
+		return
+	}
+
+	balance := func(address string) common.Amount {
+		if ba, err := block.GetBlockAccount(bs, address); err == nil {
+			return ba.Balance
+		}
+		return 0
+	}
+
+	addresses := StateAccounts([]*transaction.Transaction{&tx}, ballot.ProposerTransaction{})
+	before := map[string]common.Amount{}
+	for _, address := range addresses {
+		before[address] = balance(address)
+	}
+
+	if err = FinishTransactions(blk, []*transaction.Transaction{&tx}, bs); err != nil {
+		return
+	}
+
+	for _, address := range addresses {
+		changes = append(changes, resource.BalanceChange{
+			Address: address,
+			Before:  before[address],
+			After:   balance(address),
+		})
+	}
+
+	return
+}
+
+func simulationError(err error) *errors.Error {
+	if err == nil {
+		return nil
+	}
+	if e, ok := err.(*errors.Error); ok {
+		return e
+	}
+
+	return errors.HTTPProblem.Clone().SetData("error", err.Error())
+}
@@ -0,0 +1,81 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network"
+)
+
+func TestSimulateTransaction(t *testing.T) {
+	nr, localNode := MakeNodeRunner()
+	nodeHandler := NewNetworkHandlerNode(
+		localNode,
+		nr.Network(),
+		nr.Storage(),
+		nr.Consensus(),
+		nr.TransactionPool,
+		network.UrlPathPrefixNode,
+		nr.Conf,
+	)
+
+	genesisAccount, err := block.GetBlockAccount(nr.Storage(), block.GenesisKP.Address())
+	require.NoError(t, err)
+
+	{ // valid
+		amount := uint64(common.BaseReserve)
+		tx, body, kpNewAccount := GetCreateAccountTransaction(genesisAccount.SequenceID, amount)
+
+		result, err := nodeHandler.SimulateTransaction(body)
+		require.NoError(t, err)
+		require.Nil(t, result.Error)
+		require.Equal(t, tx.GetHash(), result.Hash)
+		require.Equal(t, 1, len(result.Operations))
+		require.Nil(t, result.Operations[0].Error)
+
+		changes := map[string]string{}
+		for _, c := range result.BalanceChanges {
+			changes[c.Address] = c.Delta()
+		}
+		require.Equal(t, common.Amount(amount).String(), changes[kpNewAccount.Address()])
+		require.Equal(
+			t,
+			"-"+common.Amount(amount).MustAdd(tx.B.Fee).String(),
+			changes[block.GenesisKP.Address()],
+		)
+
+		// nothing is changed
+		require.Equal(t, 0, nr.TransactionPool.Len())
+		_, err = block.GetBlockAccount(nr.Storage(), kpNewAccount.Address())
+		require.Equal(t, errors.StorageRecordDoesNotExist, err)
+	}
+
+	{ // the target does not exist
+		_, body := GetPaymentTransaction(block.GenesisKP, keypair.Random().Address(), genesisAccount.SequenceID, 100)
+
+		result, err := nodeHandler.SimulateTransaction(body)
+		require.NoError(t, err)
+		require.Equal(t, errors.BlockAccountDoesNotExists.Code, result.Error.Code)
+		require.Equal(t, errors.BlockAccountDoesNotExists.Code, result.Operations[0].Error.Code)
+		require.Nil(t, result.BalanceChanges)
+	}
+
+	{ // wrong sequence id
+		_, body, _ := GetCreateAccountTransaction(genesisAccount.SequenceID+1, uint64(common.BaseReserve))
+
+		result, err := nodeHandler.SimulateTransaction(body)
+		require.NoError(t, err)
+		require.Equal(t, errors.TransactionInvalidSequenceID.Code, result.Error.Code)
+		require.Nil(t, result.Operations[0].Error)
+	}
+
+	{ // malformed
+		_, err := nodeHandler.SimulateTransaction([]byte(`{}`))
+		require.Error(t, err)
+	}
+}
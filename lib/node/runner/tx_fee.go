@@ -0,0 +1,183 @@
+package runner
+
+import (
+	"boscoin.io/sebak/lib/ballot"
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+// canReceiveTxFee checks the account exists and is not frozen.
+func canReceiveTxFee(st *storage.LevelDBBackend, address string) error {
+	account, err := block.GetBlockAccount(st, address)
+	if err != nil {
+		return errors.BlockAccountDoesNotExists
+	}
+	if account.IsFrozen() {
+		return errors.FrozenAccountNoDeposit
+	}
+
+	return nil
+}
+
+// getTxFeeRecipients returns the recipients of the validator share of
+// transaction fee for the new ballot. With
+// `common.TxFeeDistributionSigners`, the recipients are the validators, which
+// signed the commit certificate of the basis block in local storage; if the
+// valid signatures do not reach `threshold`, nothing is returned and the whole
+// fee goes to the common account.
+func getTxFeeRecipients(
+	st *storage.LevelDBBackend,
+	conf common.Config,
+	blt ballot.Ballot,
+	validators map[string]*node.Validator,
+	threshold int,
+) (recipients []operation.TxFeeRecipient) {
+	if conf.TxFeeValidatorShare < 1 {
+		return
+	}
+
+	if conf.TxFeeDistribution != common.TxFeeDistributionSigners {
+		if canReceiveTxFee(st, blt.Proposer()) != nil {
+			return
+		}
+		return []operation.TxFeeRecipient{{Address: blt.Proposer()}}
+	}
+
+	rd := blt.VotingBasis()
+	c, err := block.GetCommitCertificate(st, rd.Height)
+	if err != nil || c.BlockHash != rd.BlockHash {
+		return
+	}
+
+	for _, s := range c.Signatures {
+		if _, found := validators[s.Validator]; !found {
+			continue
+		}
+		if s.Verify(conf.NetworkID, rd.BlockHash) != nil {
+			continue
+		}
+		if canReceiveTxFee(st, s.Validator) != nil {
+			continue
+		}
+		recipients = append(recipients, operation.TxFeeRecipient{Address: s.Validator, Signature: s.Signature})
+	}
+
+	if threshold < 1 || len(recipients) < threshold {
+		return nil
+	}
+
+	return
+}
+
+// checkTxFeeSigners checks the recipients of `operation.DistributeTxFee`
+// with `common.TxFeeDistributionSigners` by themselves, not by the commit
+// certificate of local storage, because the validators keep the different
+// signatures of the same block; every recipient must be the validator with
+// the valid commit signature of the basis block and the number of recipients
+// must reach `threshold`.
+func checkTxFeeSigners(
+	st *storage.LevelDBBackend,
+	conf common.Config,
+	blt ballot.Ballot,
+	supplied []operation.TxFeeRecipient,
+	validators map[string]*node.Validator,
+	threshold int,
+) (recipients []operation.TxFeeRecipient, err error) {
+	rd := blt.VotingBasis()
+	for _, r := range supplied {
+		if _, found := validators[r.Address]; !found {
+			err = errors.InvalidOperation
+			return
+		}
+		s := block.CommitSignature{Validator: r.Address, Signature: r.Signature}
+		if s.Verify(conf.NetworkID, rd.BlockHash) != nil {
+			err = errors.InvalidOperation
+			return
+		}
+		if err = canReceiveTxFee(st, r.Address); err != nil {
+			return
+		}
+		recipients = append(recipients, operation.TxFeeRecipient{Address: r.Address, Signature: r.Signature})
+	}
+
+	if threshold < 1 || len(recipients) < threshold {
+		err = errors.InvalidOperation
+		return
+	}
+
+	return
+}
+
+// checkDistributeTxFee validates the `operation.DistributeTxFee` of the
+// proposer transaction of ballot against the `CollectTxFee`. With
+// `common.TxFeeDistributionSigners`, the recipients are checked by
+// `checkTxFeeSigners` and the proposer can omit the distribution, then the
+// whole fee goes to the common account; otherwise the recipient must be same
+// with the one from `getTxFeeRecipients`. The amounts are recalculated from
+// the recipients; the collected fee of transactions is `opc.Amount` plus the
+// distributed amount, which is checked by
+// `BallotTransactionsOperationBodyCollectTxFee`.
+func checkDistributeTxFee(
+	st *storage.LevelDBBackend,
+	conf common.Config,
+	blt ballot.Ballot,
+	opc operation.CollectTxFee,
+	validators map[string]*node.Validator,
+	threshold int,
+) (err error) {
+	opd, found := blt.ProposerTransaction().DistributeTxFee()
+
+	collected := opc
+	if found {
+		if collected.Amount, err = opc.Amount.Add(opd.GetAmount()); err != nil {
+			return
+		}
+	}
+
+	var recipients []operation.TxFeeRecipient
+	if conf.TxFeeDistribution == common.TxFeeDistributionSigners {
+		if !found {
+			return
+		}
+		if recipients, err = checkTxFeeSigners(st, conf, blt, opd.Recipients, validators, threshold); err != nil {
+			return
+		}
+	} else {
+		recipients = getTxFeeRecipients(st, conf, blt, validators, threshold)
+	}
+
+	expectedOpc, expected, expectedFound := ballot.NewDistributeTxFeeFromBallot(blt, collected, conf.TxFeeValidatorShare, recipients)
+	if found != expectedFound {
+		err = errors.InvalidOperation
+		return
+	}
+	if !found {
+		return
+	}
+
+	if len(expected.Recipients) != len(opd.Recipients) {
+		err = errors.InvalidOperation
+		return
+	}
+	for i, r := range opd.Recipients {
+		e := expected.Recipients[i]
+		if r.Address != e.Address || r.Signature != e.Signature {
+			err = errors.InvalidOperation
+			return
+		}
+		if r.Amount != e.Amount {
+			err = errors.InvalidFee
+			return
+		}
+	}
+	if opc.Amount != expectedOpc.Amount {
+		err = errors.InvalidFee
+		return
+	}
+
+	return
+}
@@ -135,6 +135,11 @@ func NewNodeInfo(nr *NodeRunner) node.NodeInfo {
 		InflationRatio:            common.InflationRatioString,
 		UnfreezingPeriod:          common.UnfreezingPeriod,
 		BlockHeightEndOfInflation: common.BlockHeightEndOfInflation,
+		ProposerSelector:          nr.Conf.ProposerSelector,
+		ValidatorWeights:          nr.Conf.ValidatorWeights,
+		TxFeeValidatorShare:       nr.Conf.TxFeeValidatorShare,
+		TxFeeDistribution:         nr.Conf.TxFeeDistribution,
+		InflationToDelegators:     nr.Conf.InflationToDelegators,
 	}
 
 	return node.NodeInfo{
@@ -0,0 +1,80 @@
+package runner
+
+import (
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+// updateValidators applies the scheduled `block.ValidatorUpdate`s until
+// `height`. It is called before the node starts the consensus of `height`,
+// so the validators, the voting threshold and the proposer selection switch
+// to the new validator set at the same height. If the local node is removed,
+// it stops voting, see `BroadcastBallot`, but it keeps following the blocks
+// of the validators; when it is added again, it votes again.
+func (nr *NodeRunner) updateValidators(height uint64) {
+	nr.validatorsLock.Lock()
+	defer nr.validatorsLock.Unlock()
+
+	if height <= nr.validatorsHeight {
+		return
+	}
+
+	updates := block.GetValidatorUpdates(nr.storage, nr.validatorsHeight+1, height)
+	nr.validatorsHeight = height
+	if len(updates) < 1 {
+		return
+	}
+
+	var added []*node.Validator
+	var removed []string
+	for _, u := range updates {
+		switch u.Action {
+		case operation.ValidatorUpdateAdd:
+			var endpoint *common.Endpoint
+			if len(u.Endpoint) > 0 {
+				endpoint, _ = common.NewEndpointFromString(u.Endpoint)
+			}
+			if u.Address == nr.localNode.Address() {
+				endpoint = nr.localNode.Endpoint()
+			}
+
+			v, err := node.NewValidator(u.Address, endpoint, "")
+			if err != nil {
+				nr.log.Error("failed to add validator", "update", u, "error", err)
+				continue
+			}
+			nr.localNode.AddValidators(v)
+			added = append(added, v)
+		case operation.ValidatorUpdateRemove:
+			if !nr.localNode.HasValidators(u.Address) {
+				continue
+			}
+			// the validators can not be empty
+			if len(nr.localNode.GetValidators()) < 2 {
+				nr.log.Error("failed to remove the last validator", "update", u)
+				continue
+			}
+			nr.localNode.RemoveValidators(u.Address)
+			removed = append(removed, u.Address)
+			if u.Address == nr.localNode.Address() {
+				nr.log.Warn("local node is removed from the validators; it stops voting", "height", height)
+			}
+		}
+	}
+
+	nr.policy.SetValidators(len(nr.localNode.GetValidators()))
+	if updater, ok := nr.connectionManager.(network.ValidatorUpdater); ok {
+		updater.ValidatorsUpdated(added, removed)
+	}
+
+	nr.log.Debug(
+		"validators updated",
+		"height", height,
+		"added", added,
+		"removed", removed,
+		"validators", len(nr.localNode.GetValidators()),
+	)
+}
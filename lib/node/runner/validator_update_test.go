@@ -0,0 +1,120 @@
+package runner
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/ballot"
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/consensus"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
+	"boscoin.io/sebak/lib/voting"
+)
+
+func TestValidateTxValidatorUpdate(t *testing.T) {
+	st := block.InitTestBlockchain()
+	defer st.Close()
+
+	kpCongress := keypair.Random()
+	congress := block.NewBlockAccount(kpCongress.Address(), common.Amount(1*common.AmountPerCoin))
+	congress.MustSave(st)
+
+	conf := common.NewTestConfig()
+	conf.CongressAccountAddress = kpCongress.Address()
+
+	latest := block.GetLatestBlock(st)
+	newTx := func(height uint64) transaction.Transaction {
+		op, _ := operation.NewOperation(operation.NewValidatorUpdate(height, operation.ValidatorUpdateAdd, keypair.Random().Address(), ""))
+		tx, _ := transaction.NewTransaction(kpCongress.Address(), 0, op)
+		tx.Sign(kpCongress, networkID)
+		return tx
+	}
+
+	require.NoError(t, ValidateTx(st, conf, newTx(latest.Height+2)))
+
+	// the next block is too late to be scheduled
+	require.Equal(t, errors.ValidatorUpdateHeightNotFuture, ValidateTx(st, conf, newTx(latest.Height+1)))
+
+	{ // not from congress account
+		conf := common.NewTestConfig()
+		conf.CongressAccountAddress = keypair.Random().Address()
+		require.Equal(t, errors.CongressAddressMisMatched, ValidateTx(st, conf, newTx(latest.Height+2)))
+	}
+}
+
+func TestUpdateValidators(t *testing.T) {
+	conf := common.NewTestConfig()
+	nr, nodes, cm := createNodeRunnerForTesting(3, conf, nil)
+
+	latest := nr.Consensus().LatestBlock()
+	height := latest.Height + 2
+
+	kpNew := keypair.Random()
+	addOp, _ := operation.NewOperation(operation.NewValidatorUpdate(height, operation.ValidatorUpdateAdd, kpNew.Address(), "https://localhost:12345"))
+	removeOp, _ := operation.NewOperation(operation.NewValidatorUpdate(height, operation.ValidatorUpdateRemove, nodes[2].Address(), ""))
+
+	kpCongress := keypair.Random()
+	congress := block.NewBlockAccount(kpCongress.Address(), common.Amount(1*common.AmountPerCoin))
+	congress.MustSave(nr.Storage())
+
+	tx, _ := transaction.NewTransaction(kpCongress.Address(), 0, addOp, removeOp)
+	tx.Sign(kpCongress, networkID)
+
+	blk := block.TestMakeNewBlock([]string{tx.GetHash()})
+	require.NoError(t, FinishTransactions(blk, []*transaction.Transaction{&tx}, nr.Storage()))
+
+	expected := []string{nodes[0].Address(), nodes[1].Address(), nodes[2].Address()}
+	allValidators := func() []string {
+		validators := cm.AllValidators()
+		sort.Strings(validators)
+		return validators
+	}
+	sort.Strings(expected)
+
+	// not yet
+	nr.updateValidators(height - 1)
+	require.Equal(t, expected, allValidators())
+	require.Equal(t, 3, nr.Policy().Validators())
+
+	nr.updateValidators(height)
+	expected = []string{nodes[0].Address(), nodes[1].Address(), kpNew.Address()}
+	sort.Strings(expected)
+	require.Equal(t, expected, allValidators())
+	require.Equal(t, 3, nr.Policy().Validators())
+	require.Equal(t, "https://localhost:12345", nr.localNode.Validator(kpNew.Address()).Endpoint().String())
+
+	// already applied
+	nr.localNode.RemoveValidators(kpNew.Address())
+	nr.updateValidators(height)
+	require.False(t, nr.localNode.HasValidators(kpNew.Address()))
+}
+
+func TestUpdateValidatorsRemoveLocalNode(t *testing.T) {
+	conf := common.NewTestConfig()
+	nr, _, _ := createNodeRunnerForTesting(3, conf, nil)
+
+	latest := nr.Consensus().LatestBlock()
+	height := latest.Height + 2
+
+	u := operation.NewValidatorUpdate(height, operation.ValidatorUpdateRemove, nr.localNode.Address(), "")
+	require.NoError(t, block.NewValidatorUpdate(u).Save(nr.Storage()))
+
+	nr.updateValidators(height)
+	require.False(t, nr.localNode.HasValidators(nr.localNode.Address()))
+	require.Equal(t, 2, nr.Policy().Validators())
+
+	// the removed node does not vote
+	basis := voting.Basis{Height: latest.Height + 1, BlockHash: latest.Hash, TotalTxs: latest.TotalTxs, TotalOps: latest.TotalOps}
+	b := ballot.NewBallot(nr.localNode.Address(), nr.localNode.Address(), basis, []string{})
+	b.SetVote(ballot.StateSIGN, voting.YES)
+	nr.BroadcastBallot(*b)
+
+	state := consensus.ISAACState{Height: basis.Height, Round: basis.Round, BallotState: ballot.StateSIGN}
+	require.False(t, nr.BallotSendRecord().Sent(state))
+}
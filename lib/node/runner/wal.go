@@ -0,0 +1,75 @@
+package runner
+
+import (
+	"boscoin.io/sebak/lib/ballot"
+	"boscoin.io/sebak/lib/consensus"
+)
+
+// replayWAL opens the consensus WAL and restores the ballots, which the node
+// already signed, and the last `ISAACState` of the current height; after the
+// restart, the node does not sign the conflicting ballot for the same
+// `ISAACState`.
+func (nr *NodeRunner) replayWAL() (err error) {
+	if nr.wal, err = consensus.OpenWAL(nr.Conf.ConsensusWALPath); err != nil {
+		return
+	}
+
+	// the records of the stored blocks are not needed
+	latest := nr.consensus.LatestBlock()
+	if err = nr.wal.Truncate(latest.Height - 1); err != nil {
+		return
+	}
+
+	var records []consensus.WALRecord
+	if records, err = nr.wal.Records(); err != nil {
+		return
+	}
+
+	for _, r := range records {
+		switch r.Type {
+		case consensus.WALRecordBallot:
+			nr.ballotSendRecord.SetSent(consensus.ISAACState{
+				Height:      r.Ballot.VotingBasis().Height,
+				Round:       r.Ballot.VotingBasis().Round,
+				BallotState: r.Ballot.State(),
+			})
+		case consensus.WALRecordState:
+			if nr.walState == nil || nr.walState.IsLater(*r.State) {
+				state := *r.State
+				nr.walState = &state
+			}
+		}
+	}
+
+	nr.log.Debug("consensus WAL replayed", "path", nr.wal.Path(), "records", len(records), "state", nr.walState)
+
+	return
+}
+
+func (nr *NodeRunner) writeWALBallot(b ballot.Ballot) error {
+	if nr.wal == nil {
+		return nil
+	}
+
+	return nr.wal.WriteBallot(b)
+}
+
+func (nr *NodeRunner) writeWALState(state consensus.ISAACState) {
+	if nr.wal == nil {
+		return
+	}
+
+	if err := nr.wal.WriteState(state); err != nil {
+		nr.log.Error("failed to write state to WAL", "state", state, "error", err)
+	}
+}
+
+func (nr *NodeRunner) truncateWAL(height uint64) {
+	if nr.wal == nil {
+		return
+	}
+
+	if err := nr.wal.Truncate(height); err != nil {
+		nr.log.Error("failed to truncate WAL", "height", height, "error", err)
+	}
+}
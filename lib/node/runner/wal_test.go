@@ -0,0 +1,62 @@
+package runner
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/ballot"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/consensus"
+)
+
+// TestConsensusWALReplay checks the restarted node knows the ballots, which
+// it signed before, and does not broadcast the new ballot for them.
+func TestConsensusWALReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sebak-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	conf := common.NewTestConfig()
+	conf.ConsensusWALPath = filepath.Join(dir, "consensus.wal")
+
+	nr, _, cm := createNodeRunnerForTesting(3, conf, nil)
+	go nr.Network().Start()
+	_, err = nr.proposeNewBallot(0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(cm.Messages()))
+
+	height := nr.Consensus().LatestBlock().Height
+	lastState := consensus.ISAACState{Height: height, Round: 2, BallotState: ballot.StateSIGN}
+	nr.isaacStateManager.setState(consensus.ISAACState{Height: height, Round: 1, BallotState: ballot.StateINIT})
+	nr.isaacStateManager.setState(lastState)
+
+	// stopped; the WAL file is closed
+	nr.Stop()
+
+	// restarted
+	restarted, _, restartedCM := createNodeRunnerForTesting(3, conf, nil)
+	go restarted.Network().Start()
+	defer restarted.Stop()
+
+	require.True(t, restarted.BallotSendRecord().Sent(consensus.ISAACState{Height: height, Round: 0, BallotState: ballot.StateINIT}))
+	require.Equal(t, lastState, *restarted.walState)
+
+	// the new INIT ballot for the same round is not broadcasted
+	_, err = restarted.proposeNewBallot(0)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(restartedCM.Messages()))
+
+	_, err = restarted.proposeNewBallot(1)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(restartedCM.Messages()))
+
+	// the records of stored block are removed
+	restarted.RemoveSendRecordsLowerThanOrEqualHeight(height)
+	records, err := restarted.wal.Records()
+	require.NoError(t, err)
+	require.Equal(t, 0, len(records))
+}
@@ -0,0 +1,69 @@
+package simulation
+
+import (
+	"sync"
+	"time"
+
+	"boscoin.io/sebak/lib/common"
+)
+
+// hook is the `runner.Hook` of the simulated node; the asynchronous works of
+// node are counted by the `common.Activity` of `Simulation`.
+//
+// The posted state transitions, which are not received yet, are the works of
+// node, but while the state manager sleeps, they can not be received, so they
+// are not counted until it wakes up.
+type hook struct {
+	sync.Mutex
+
+	activity *common.Activity
+	pending  int
+	sleeping bool
+}
+
+func newHook(activity *common.Activity) *hook {
+	return &hook{activity: activity}
+}
+
+func (h *hook) Begin() {
+	h.activity.Begin()
+}
+
+func (h *hook) End() {
+	h.activity.End()
+}
+
+func (h *hook) TransitPosted() {
+	h.Lock()
+	defer h.Unlock()
+
+	h.pending++
+	if !h.sleeping {
+		h.activity.Begin()
+	}
+}
+
+func (h *hook) TransitReceived() {
+	h.Lock()
+	defer h.Unlock()
+
+	h.pending--
+}
+
+func (h *hook) Sleep(clock common.Clock, d time.Duration) {
+	h.Lock()
+	h.sleeping = true
+	for i := 0; i < h.pending; i++ {
+		h.activity.End()
+	}
+	h.Unlock()
+
+	clock.Sleep(d)
+
+	h.Lock()
+	h.sleeping = false
+	for i := 0; i < h.pending; i++ {
+		h.activity.Begin()
+	}
+	h.Unlock()
+}
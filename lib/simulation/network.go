@@ -0,0 +1,177 @@
+package simulation
+
+import (
+	"io"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/voting"
+)
+
+// DefaultDelay is the default delay of messages. The delayed messages are
+// delivered after the nodes handled the previous messages, so the nodes see
+// the messages in the order of the simulated time.
+const DefaultDelay = 10 * time.Millisecond
+
+// faults decides how the message between two nodes is delivered. All the
+// decisions are made by the seeded random source, so the same seed gives the
+// same decisions.
+type faults struct {
+	sync.Mutex
+
+	rand      *rand.Rand
+	delay     time.Duration
+	linkDelay map[[2]int]time.Duration
+	jitter    time.Duration
+	dropRate  float64
+	blocked   map[[2]int]bool
+	groups    map[int]int // node index -> partition group
+}
+
+func newFaults(seed int64) *faults {
+	return &faults{
+		rand:      rand.New(rand.NewSource(seed)),
+		delay:     DefaultDelay,
+		linkDelay: map[[2]int]time.Duration{},
+		blocked:   map[[2]int]bool{},
+	}
+}
+
+// decide returns the delay of message from `from` to `to`; if `ok` is false,
+// the message is dropped.
+func (f *faults) decide(from, to int) (delay time.Duration, ok bool) {
+	f.Lock()
+	defer f.Unlock()
+
+	if f.blocked[[2]int{from, to}] {
+		return
+	}
+	if f.groups != nil && f.group(from) != f.group(to) {
+		return
+	}
+	if f.dropRate > 0 && f.rand.Float64() < f.dropRate {
+		return
+	}
+
+	delay = f.delay
+	if d, found := f.linkDelay[[2]int{from, to}]; found {
+		delay = d
+	}
+	if f.jitter > 0 {
+		delay += time.Duration(f.rand.Int63n(int64(f.jitter)))
+	}
+
+	return delay, true
+}
+
+// group returns the partition group of node; the node, which is not in any
+// group, is isolated from the others.
+func (f *faults) group(i int) int {
+	if g, found := f.groups[i]; found {
+		return g
+	}
+	return -1 - i
+}
+
+type envelope struct {
+	seq       uint64
+	from, to  int
+	deliverAt time.Time
+	message   common.NetworkMessage
+}
+
+// memoryNetwork is the `network.MemoryNetwork`, which signals when it is
+// started; the node is started after its network is started.
+type memoryNetwork struct {
+	*network.MemoryNetwork
+
+	started chan struct{}
+}
+
+func newMemoryNetwork(n *network.MemoryNetwork) *memoryNetwork {
+	return &memoryNetwork{MemoryNetwork: n, started: make(chan struct{})}
+}
+
+func (n *memoryNetwork) Start() error {
+	close(n.started)
+	return n.MemoryNetwork.Start()
+}
+
+// messageBroker queues the messages, which the node sends to itself, in
+// `Simulation`, so they are delivered in order with the other messages.
+type messageBroker struct {
+	sim   *Simulation
+	index int
+}
+
+func (m messageBroker) Response(w io.Writer, b []byte) error {
+	_, err := w.Write(b)
+	return err
+}
+
+func (m messageBroker) Receive(message common.NetworkMessage) {
+	m.sim.enqueue(m.index, m.index, 0, message)
+}
+
+// connectionManager is the `network.ConnectionManager` of the simulated node.
+// It assumes every validator is connected and sends the broadcasted messages
+// through `Simulation`, which applies the faults.
+type connectionManager struct {
+	network.ConnectionManager
+
+	sim        *Simulation
+	index      int
+	localNode  *node.LocalNode
+	validators []string
+}
+
+func newConnectionManager(
+	sim *Simulation,
+	index int,
+	localNode *node.LocalNode,
+	n network.Network,
+	policy voting.ThresholdPolicy,
+	conf common.Config,
+) *connectionManager {
+	var validators []string
+	for address := range localNode.GetValidators() {
+		if address == localNode.Address() {
+			continue
+		}
+		validators = append(validators, address)
+	}
+	sort.Strings(validators)
+	policy.SetConnected(len(validators))
+
+	return &connectionManager{
+		ConnectionManager: network.NewValidatorConnectionManager(localNode, n, policy, conf),
+		sim:               sim,
+		index:             index,
+		localNode:         localNode,
+		validators:        validators,
+	}
+}
+
+func (c *connectionManager) Start() {
+}
+
+func (c *connectionManager) AllConnected() []string {
+	return c.validators
+}
+
+func (c *connectionManager) CountConnected() int {
+	return len(c.validators)
+}
+
+func (c *connectionManager) IsReady() bool {
+	return true
+}
+
+func (c *connectionManager) Broadcast(message common.Message) {
+	c.sim.broadcast(c.index, message)
+}
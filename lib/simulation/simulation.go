@@ -0,0 +1,416 @@
+// Package simulation runs the multiple validators in one process over
+// `network.MemoryNetwork`. The validators share one `common.FakeClock`, so the
+// consensus timeouts happen only when the simulation advances the clock; the
+// messages between validators can be delayed, dropped, reordered and
+// partitioned. The messages are delivered one by one in order of the simulated
+// delivery time, and the simulation waits until the nodes finish handling
+// them, so the same seed gives the same run.
+package simulation
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/consensus"
+	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/node/runner"
+	"boscoin.io/sebak/lib/transaction"
+)
+
+type Config struct {
+	Validators int
+	Threshold  int   // voting threshold in percent; default is 66
+	Seed       int64 // seed of the faults
+	Conf       common.Config
+}
+
+// Simulation boots the `runner.NodeRunner`s and drives them by the fake clock.
+type Simulation struct {
+	sync.Mutex
+
+	config   Config
+	clock    *common.FakeClock
+	activity *common.Activity // the works of nodes, which are not yet done
+	faults   *faults
+	nodes    []*runner.NodeRunner
+	networks []*memoryNetwork
+
+	queue []envelope
+	seq   uint64
+}
+
+func New(config Config) (*Simulation, error) {
+	if config.Validators < 1 {
+		return nil, fmt.Errorf("`Validators` must be greater than 0")
+	}
+	if config.Threshold == 0 {
+		config.Threshold = 66
+	}
+	if config.Conf.NetworkID == nil {
+		config.Conf = common.NewTestConfig()
+	}
+
+	s := &Simulation{
+		config:   config,
+		clock:    common.NewFakeClock(time.Now()),
+		activity: common.NewActivity(),
+		faults:   newFaults(config.Seed),
+	}
+	s.clock.SetActivity(s.activity)
+
+	var localNodes []*node.LocalNode
+	var prev *network.MemoryNetwork
+	for i := 0; i < config.Validators; i++ {
+		_, n, localNode := network.CreateMemoryNetwork(prev)
+		prev = n
+		n.SetMessageBroker(messageBroker{sim: s, index: i})
+		s.networks = append(s.networks, newMemoryNetwork(n))
+		localNodes = append(localNodes, localNode)
+	}
+
+	for _, localNode := range localNodes {
+		for _, v := range localNodes {
+			localNode.AddValidators(v.ConvertToValidator())
+		}
+	}
+
+	for i, localNode := range localNodes {
+		policy, err := consensus.NewDefaultVotingThresholdPolicy(config.Threshold)
+		if err != nil {
+			return nil, err
+		}
+		policy.SetValidators(len(localNodes))
+
+		cm := newConnectionManager(s, i, localNode, s.networks[i], policy, config.Conf)
+
+		st := block.InitTestBlockchain()
+		is, err := consensus.NewISAAC(localNode, policy, cm, st, config.Conf, nil)
+		if err != nil {
+			return nil, err
+		}
+		tp := transaction.NewPool(config.Conf)
+
+		nr, err := runner.NewNodeRunner(localNode, policy, s.networks[i], is, st, tp, config.Conf)
+		if err != nil {
+			return nil, err
+		}
+		nr.SetClock(s.clock)
+		nr.SetHook(newHook(s.activity))
+		s.nodes = append(s.nodes, nr)
+	}
+
+	return s, nil
+}
+
+func (s *Simulation) Clock() *common.FakeClock {
+	return s.clock
+}
+
+func (s *Simulation) Nodes() []*runner.NodeRunner {
+	return s.nodes
+}
+
+func (s *Simulation) Node(i int) *runner.NodeRunner {
+	return s.nodes[i]
+}
+
+// Index returns the index of node by address; if not found, -1.
+func (s *Simulation) Index(address string) int {
+	for i, nr := range s.nodes {
+		if nr.Node().Address() == address {
+			return i
+		}
+	}
+	return -1
+}
+
+// Proposer returns the index of the proposer of the next block at `round`,
+// which is selected by the first node.
+func (s *Simulation) Proposer(round uint64) int {
+	nr := s.nodes[0]
+	return s.Index(nr.Consensus().SelectProposer(nr.Consensus().LatestBlock().Height, round))
+}
+
+// Start starts all the nodes and waits until they begin the consensus.
+func (s *Simulation) Start() {
+	for _, nr := range s.nodes {
+		go nr.Start()
+	}
+
+	for _, n := range s.networks {
+		<-n.started
+	}
+	s.settle()
+}
+
+func (s *Simulation) Stop() {
+	for _, nr := range s.nodes {
+		nr.Stop()
+	}
+}
+
+// SetDelay sets the delay of every message.
+func (s *Simulation) SetDelay(d time.Duration) {
+	s.faults.Lock()
+	defer s.faults.Unlock()
+	s.faults.delay = d
+}
+
+// SetLinkDelay sets the delay of the messages from `from` to `to`; it
+// overrides `SetDelay`.
+func (s *Simulation) SetLinkDelay(from, to int, d time.Duration) {
+	s.faults.Lock()
+	defer s.faults.Unlock()
+	s.faults.linkDelay[[2]int{from, to}] = d
+}
+
+// SetJitter adds the random delay up to `d` to every message, so the messages
+// can be reordered.
+func (s *Simulation) SetJitter(d time.Duration) {
+	s.faults.Lock()
+	defer s.faults.Unlock()
+	s.faults.jitter = d
+}
+
+// SetDropRate sets the probability, 0 to 1, that a message is dropped.
+func (s *Simulation) SetDropRate(rate float64) {
+	s.faults.Lock()
+	defer s.faults.Unlock()
+	s.faults.dropRate = rate
+}
+
+// Block drops the messages from `from` to `to`.
+func (s *Simulation) Block(from, to int) {
+	s.faults.Lock()
+	defer s.faults.Unlock()
+	s.faults.blocked[[2]int{from, to}] = true
+}
+
+func (s *Simulation) Unblock(from, to int) {
+	s.faults.Lock()
+	defer s.faults.Unlock()
+	delete(s.faults.blocked, [2]int{from, to})
+}
+
+// Isolate drops all the messages from and to the node.
+func (s *Simulation) Isolate(i int) {
+	for j := range s.nodes {
+		if i == j {
+			continue
+		}
+		s.Block(i, j)
+		s.Block(j, i)
+	}
+}
+
+// Partition splits the nodes into the groups; the messages between the
+// different groups are dropped. The node, which is not in the groups, is
+// isolated.
+func (s *Simulation) Partition(groups ...[]int) {
+	s.faults.Lock()
+	defer s.faults.Unlock()
+
+	s.faults.groups = map[int]int{}
+	for g, group := range groups {
+		for _, i := range group {
+			s.faults.groups[i] = g
+		}
+	}
+}
+
+// Heal removes the partitions and the blocked links; the delays and the drop
+// rate are kept.
+func (s *Simulation) Heal() {
+	s.faults.Lock()
+	defer s.faults.Unlock()
+
+	s.faults.groups = nil
+	s.faults.blocked = map[[2]int]bool{}
+}
+
+func (s *Simulation) broadcast(from int, message common.Message) {
+	b, err := message.Serialize()
+	if err != nil {
+		s.nodes[from].Log().Error("failed to serialize message", "error", err)
+		return
+	}
+	m := common.NewNetworkMessage(message.GetType(), b)
+
+	for to := range s.nodes {
+		if to == from {
+			continue
+		}
+
+		delay, ok := s.faults.decide(from, to)
+		if !ok {
+			continue
+		}
+		s.enqueue(from, to, delay, m)
+	}
+}
+
+// enqueue queues the message to be delivered after `delay`.
+func (s *Simulation) enqueue(from, to int, delay time.Duration, message common.NetworkMessage) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.seq++
+	s.queue = append(s.queue, envelope{
+		seq:       s.seq,
+		from:      from,
+		to:        to,
+		deliverAt: s.clock.Now().Add(delay),
+		message:   message,
+	})
+}
+
+// popDue removes and returns the queued message, which is due to the current
+// time; the earliest delivery time comes first and the earlier queued one
+// comes first at the same time.
+func (s *Simulation) popDue() (e envelope, found bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	now := s.clock.Now()
+	index := -1
+	for i, q := range s.queue {
+		if q.deliverAt.After(now) {
+			continue
+		}
+		if index < 0 || q.deliverAt.Before(e.deliverAt) ||
+			(q.deliverAt.Equal(e.deliverAt) && q.seq < e.seq) {
+			index, e = i, q
+		}
+	}
+	if index < 0 {
+		return
+	}
+	s.queue = append(s.queue[:index], s.queue[index+1:]...)
+
+	return e, true
+}
+
+// nextDelivery returns the earliest delivery time of the queued messages.
+func (s *Simulation) nextDelivery() (t time.Time, found bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	for _, e := range s.queue {
+		if !found || e.deliverAt.Before(t) {
+			t = e.deliverAt
+			found = true
+		}
+	}
+	return
+}
+
+// settle delivers the due messages one by one; before every delivery and
+// before returning, it waits until the nodes finish their works, like
+// handling the fired timers and the state transitions.
+func (s *Simulation) settle() {
+	for {
+		s.activity.Wait()
+
+		e, found := s.popDue()
+		if !found {
+			return
+		}
+		s.nodes[e.to].HandleMessage(e.message)
+	}
+}
+
+// Advance moves the clock by `d`. The clock stops at the delivery time of
+// every queued message, so the messages and the timers are handled in order
+// of the simulated time.
+func (s *Simulation) Advance(d time.Duration) {
+	target := s.clock.Now().Add(d)
+
+	s.settle()
+	for {
+		next, found := s.nextDelivery()
+		if !found || next.After(target) {
+			break
+		}
+		if now := s.clock.Now(); next.After(now) {
+			s.clock.Advance(next.Sub(now))
+		}
+		s.settle()
+	}
+
+	if now := s.clock.Now(); target.After(now) {
+		s.clock.Advance(target.Sub(now))
+	}
+	s.settle()
+}
+
+// RunUntil advances the clock by `step` until `cond` returns true; it returns
+// false if `cond` is not satisfied in `limit`.
+func (s *Simulation) RunUntil(cond func() bool, step, limit time.Duration) bool {
+	for elapsed := time.Duration(0); elapsed <= limit; elapsed += step {
+		if cond() {
+			return true
+		}
+		s.Advance(step)
+	}
+	return cond()
+}
+
+// Heights returns the latest block height of each node.
+func (s *Simulation) Heights() []uint64 {
+	var heights []uint64
+	for _, nr := range s.nodes {
+		heights = append(heights, nr.Consensus().LatestBlock().Height)
+	}
+	return heights
+}
+
+// WaitForHeight runs until at least `count` nodes reach `height`.
+func (s *Simulation) WaitForHeight(height uint64, count int, step, limit time.Duration) bool {
+	return s.RunUntil(func() bool {
+		var reached int
+		for _, h := range s.Heights() {
+			if h >= height {
+				reached++
+			}
+		}
+		return reached >= count
+	}, step, limit)
+}
+
+// CheckSafety checks that no two nodes stored the different blocks at the
+// same height.
+func (s *Simulation) CheckSafety() error {
+	var max uint64
+	for _, h := range s.Heights() {
+		if h > max {
+			max = h
+		}
+	}
+
+	for height := common.GenesisBlockHeight; height <= max; height++ {
+		var hash string
+		var first int
+		for i, nr := range s.nodes {
+			blk, err := block.GetBlockByHeight(nr.Storage(), height)
+			if err != nil {
+				continue
+			}
+			if hash == "" {
+				hash, first = blk.Hash, i
+				continue
+			}
+			if blk.Hash != hash {
+				return fmt.Errorf(
+					"different blocks at height %d: node %d has %s, node %d has %s",
+					height, first, hash, i, blk.Hash,
+				)
+			}
+		}
+	}
+
+	return nil
+}
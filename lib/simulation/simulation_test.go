@@ -0,0 +1,112 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+)
+
+const (
+	testStep  = 500 * time.Millisecond
+	testLimit = 10 * time.Minute
+)
+
+func newTestSimulation(t *testing.T, validators int) *Simulation {
+	conf := common.NewTestConfig()
+	conf.BlockTimeDelta = 0
+
+	s, err := New(Config{Validators: validators, Seed: 1, Conf: conf})
+	require.NoError(t, err)
+	s.Start()
+
+	return s
+}
+
+func TestSimulationProgress(t *testing.T) {
+	s := newTestSimulation(t, 4)
+	defer s.Stop()
+
+	require.True(t, s.WaitForHeight(5, 4, testStep, testLimit), "heights: %v", s.Heights())
+	require.NoError(t, s.CheckSafety())
+}
+
+func TestSimulationDelayAndReorder(t *testing.T) {
+	s := newTestSimulation(t, 4)
+	defer s.Stop()
+
+	s.SetDelay(100 * time.Millisecond)
+	s.SetJitter(300 * time.Millisecond)
+
+	require.True(t, s.WaitForHeight(4, 4, testStep, testLimit), "heights: %v", s.Heights())
+	require.NoError(t, s.CheckSafety())
+}
+
+// TestSimulationRoundChange isolates the proposer of the next block, so the
+// other nodes time out and agree on the block at the next round.
+func TestSimulationRoundChange(t *testing.T) {
+	s := newTestSimulation(t, 4)
+	defer s.Stop()
+
+	proposer := s.Proposer(0)
+	s.Isolate(proposer)
+
+	var others []int
+	for i := range s.Nodes() {
+		if i != proposer {
+			others = append(others, i)
+		}
+	}
+
+	// without the proposer, nothing happens until the timeout
+	s.Advance(time.Second)
+	for _, i := range others {
+		require.Equal(t, uint64(0), s.Node(i).ISAACState().Round)
+	}
+
+	require.True(t, s.RunUntil(func() bool {
+		for _, i := range others {
+			if s.Node(i).Consensus().LatestBlock().Height < 2 {
+				return false
+			}
+		}
+		return true
+	}, testStep, testLimit), "heights: %v", s.Heights())
+
+	blk := s.Node(others[0]).Consensus().LatestBlock()
+	require.True(t, blk.Round > 0)
+	require.NotEqual(t, s.Node(proposer).Node().Address(), blk.Proposer)
+	require.Equal(t, uint64(1), s.Node(proposer).Consensus().LatestBlock().Height)
+	require.NoError(t, s.CheckSafety())
+}
+
+// TestSimulationMinorityPartition checks the majority keeps making blocks
+// while the minority is partitioned.
+func TestSimulationMinorityPartition(t *testing.T) {
+	s := newTestSimulation(t, 4)
+	defer s.Stop()
+
+	s.Partition([]int{0, 1, 2}, []int{3})
+
+	require.True(t, s.WaitForHeight(4, 3, testStep, testLimit), "heights: %v", s.Heights())
+	require.Equal(t, uint64(1), s.Heights()[3])
+	require.NoError(t, s.CheckSafety())
+}
+
+// TestSimulationNoQuorum checks no block is made without the quorum, and the
+// nodes make blocks again after the partition is healed.
+func TestSimulationNoQuorum(t *testing.T) {
+	s := newTestSimulation(t, 4)
+	defer s.Stop()
+
+	s.Partition([]int{0, 1}, []int{2, 3})
+
+	s.Advance(time.Minute)
+	require.Equal(t, []uint64{1, 1, 1, 1}, s.Heights())
+
+	s.Heal()
+	require.True(t, s.WaitForHeight(3, 4, testStep, testLimit), "heights: %v", s.Heights())
+	require.NoError(t, s.CheckSafety())
+}
@@ -6,6 +6,7 @@ import (
 
 	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/storage/statedb/trie"
 )
 
@@ -139,6 +140,39 @@ func (stateDB *StateDB) SetState(addr string, key, value common.Hash) {
 	}
 }
 
+// SetAccount replaces the state of account with `ba`.
+func (stateDB *StateDB) SetAccount(ba block.BlockAccount) {
+	obj := newObject(ba.Address, ba, stateDB.db, stateDB.MarkStateObjectDirty)
+	stateDB.setStateObject(obj)
+	stateDB.MarkStateObjectDirty(ba.Address)
+}
+
+// RemoveAccount removes the account from the state trie.
+func (stateDB *StateDB) RemoveAccount(addr string) error {
+	delete(stateDB.stateObjects, addr)
+	delete(stateDB.stateObjectsDirty, addr)
+	delete(stateDB.stateObjectsCommitDirty, addr)
+
+	return stateDB.trie.TryDelete([]byte(addr))
+}
+
+// GetAccountProof returns the account and the proof nodes of the account in
+// the state trie.
+func (stateDB *StateDB) GetAccountProof(addr string) (ba *block.BlockAccount, proof [][]byte, err error) {
+	obj := stateDB.getStateObject(addr)
+	if obj == nil {
+		err = errors.BlockAccountDoesNotExists
+		return
+	}
+	if proof, err = stateDB.trie.Prove([]byte(addr)); err != nil {
+		return
+	}
+
+	data := obj.data
+	ba = &data
+	return
+}
+
 func (stateDB *StateDB) getStateObject(addr string) (stateObject *stateObject) {
 	if obj := stateDB.stateObjects[addr]; obj != nil {
 		return obj
@@ -3,6 +3,7 @@ package statedb
 import (
 	"testing"
 
+	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/storage"
 	"boscoin.io/sebak/lib/storage/statedb/trie"
@@ -36,3 +37,34 @@ func TestStateDB(t *testing.T) {
 		require.Equal(t, gotValueHash, valueHash)
 	}
 }
+
+func TestStateDBSetAccount(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	emptyRoot, err := New(common.Hash{}, trie.NewEthDatabase(st)).CommitTrie()
+	require.NoError(t, err)
+
+	ba := block.NewBlockAccount("dummy", common.Amount(100))
+
+	var root common.Hash
+	{
+		stateDB := New(emptyRoot, trie.NewEthDatabase(st))
+		stateDB.SetAccount(*ba)
+		root, err = stateDB.CommitTrie()
+		require.NoError(t, err)
+		require.NotEqual(t, emptyRoot, root)
+		require.NoError(t, stateDB.CommitDB(root))
+	}
+
+	{
+		stateDB := New(root, trie.NewEthDatabase(st))
+		require.True(t, stateDB.ExistAccount(ba.Address))
+		require.Equal(t, ba.Balance, stateDB.GetBalance(ba.Address))
+
+		require.NoError(t, stateDB.RemoveAccount(ba.Address))
+		removedRoot, err := stateDB.CommitTrie()
+		require.NoError(t, err)
+		require.Equal(t, emptyRoot, removedRoot)
+	}
+}
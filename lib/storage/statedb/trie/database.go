@@ -1,6 +1,7 @@
 package trie
 
 import (
+	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/storage"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/syndtr/goleveldb/leveldb"
@@ -18,16 +19,22 @@ func NewEthDatabase(ldb *storage.LevelDBBackend) *EthDatabase {
 	}
 }
 
+// nodeKey prefixes the key of trie node, so the nodes are not mixed with the
+// other records in storage.
+func nodeKey(key []byte) []byte {
+	return append([]byte(common.StateTriePrefix), key...)
+}
+
 func (db *EthDatabase) Put(key []byte, value []byte) error {
-	return db.ldbBackend.Core.Put(key, value, nil)
+	return db.ldbBackend.Core.Put(nodeKey(key), value, nil)
 }
 
 func (db *EthDatabase) Has(key []byte) (bool, error) {
-	return db.ldbBackend.Core.Has(key, nil)
+	return db.ldbBackend.Core.Has(nodeKey(key), nil)
 }
 
 func (db *EthDatabase) Get(key []byte) ([]byte, error) {
-	dat, err := db.ldbBackend.Core.Get(key, nil)
+	dat, err := db.ldbBackend.Core.Get(nodeKey(key), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -35,7 +42,7 @@ func (db *EthDatabase) Get(key []byte) ([]byte, error) {
 }
 
 func (db *EthDatabase) Delete(key []byte) error {
-	return db.ldbBackend.Core.Delete(key, nil)
+	return db.ldbBackend.Core.Delete(nodeKey(key), nil)
 }
 
 func (db *EthDatabase) Close() {
@@ -59,19 +66,25 @@ type ldbBatch struct {
 }
 
 func (b *ldbBatch) Put(key, value []byte) error {
-	b.b.Put(key, value)
+	b.b.Put(nodeKey(key), value)
 	b.size += len(value)
 	return nil
 }
 
 func (b *ldbBatch) Delete(key []byte) error {
-	b.b.Delete(key)
+	b.b.Delete(nodeKey(key))
 	b.size += 1
 	return nil
 }
 
+// Write puts the batch items into the `Core` one by one, so the items are
+// also kept in the uncommitted `storage.BatchCore`.
 func (b *ldbBatch) Write() error {
-	return b.db.Core.Write(b.b, nil)
+	r := &coreReplayer{core: b.db.Core}
+	if err := b.b.Replay(r); err != nil {
+		return err
+	}
+	return r.err
 }
 
 func (b *ldbBatch) ValueSize() int {
@@ -82,3 +95,20 @@ func (b *ldbBatch) Reset() {
 	b.b.Reset()
 	b.size = 0
 }
+
+type coreReplayer struct {
+	core storage.LevelDBCore
+	err  error
+}
+
+func (r *coreReplayer) Put(key, value []byte) {
+	if r.err == nil {
+		r.err = r.core.Put(key, value, nil)
+	}
+}
+
+func (r *coreReplayer) Delete(key []byte) {
+	if r.err == nil {
+		r.err = r.core.Delete(key, nil)
+	}
+}
@@ -2,6 +2,8 @@ package trie
 
 import (
 	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/trie"
 
 	"boscoin.io/sebak/lib/common"
@@ -27,3 +29,34 @@ func NewTrie(root common.Hash, db *EthDatabase) *Trie {
 func (t *Trie) CommitDB(root common.Hash) (err error) {
 	return t.DB.Commit(root, false)
 }
+
+// proofList collects the proof nodes in order from the root.
+type proofList [][]byte
+
+func (l *proofList) Put(key []byte, value []byte) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// Prove returns the nodes of the path from the root to the value of `key`.
+func (t *Trie) Prove(key []byte) (proof [][]byte, err error) {
+	var l proofList
+	if err = t.Trie.Prove(key, 0, &l); err != nil {
+		return
+	}
+	proof = l
+	return
+}
+
+// VerifyProof checks the proof nodes by `Prove` and returns the value of
+// `key` in the trie of `root`. If the trie does not contain `key`, the value
+// will be nil.
+func VerifyProof(root common.Hash, key []byte, proof [][]byte) (value []byte, err error) {
+	db := ethdb.NewMemDatabase()
+	for _, node := range proof {
+		db.Put(crypto.Keccak256(node), node)
+	}
+
+	value, _, err = trie.VerifyProof(ethcommon.Hash(root), key, db)
+	return
+}
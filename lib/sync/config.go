@@ -1,6 +1,7 @@
 package sync
 
 import (
+	"sort"
 	"time"
 
 	"boscoin.io/sebak/lib/common"
@@ -9,6 +10,7 @@ import (
 	"boscoin.io/sebak/lib/node/runner"
 	"boscoin.io/sebak/lib/storage"
 	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/voting"
 	"github.com/inconshreveable/log15"
 )
 
@@ -27,10 +29,15 @@ type Config struct {
 	connectionManager network.ConnectionManager
 	tp                *transaction.Pool
 	localNode         *node.LocalNode
+	validators        []string // the validators of genesis
 	nodelist          *NodeList
 	logger            log15.Logger
 	commonCfg         common.Config
 
+	// ThresholdPolicy is used to verify the commit certificate of the fetched
+	// blocks.
+	ThresholdPolicy voting.ThresholdPolicy
+
 	SyncPoolSize             uint64
 	FetchTimeout             time.Duration
 	RetryInterval            time.Duration
@@ -53,6 +60,7 @@ func NewConfig(localNode *node.LocalNode,
 		logger:            log.New(log15.Ctx{"node": localNode.Alias()}),
 		commonCfg:         cfg,
 		localNode:         localNode,
+		validators:        genesisValidators(localNode),
 		nodelist:          &NodeList{},
 
 		SyncPoolSize:             SyncPoolSize,
@@ -107,8 +115,14 @@ func (c *Config) NewValidator() Validator {
 		c.commonCfg,
 		func(v *BlockValidator) {
 			v.prevBlockWaitTimeout = c.CheckPrevBlockInterval
+			v.policy = c.ThresholdPolicy
+			v.validators = c.validators
 			v.logger = c.logger.New("submodule", "validator")
 		})
+
+	if c.commonCfg.LightMode {
+		return NewLightBlockValidator(v)
+	}
 	return v
 }
 
@@ -145,6 +159,7 @@ func (c *Config) LoggingConfig() {
 		"retryInterval", c.RetryInterval,
 		"checkInterval", c.CheckBlockHeightInterval,
 		"checkPrevBlockInterval", c.CheckPrevBlockInterval,
+		"light", c.commonCfg.LightMode,
 	)
 }
 
@@ -156,3 +171,16 @@ func (c *Config) commonAccountAddress() (string, error) {
 
 	return commonAccount.Address, nil
 }
+
+// genesisValidators returns the validators of local node; `NewConfig` must be
+// called before `runner.NodeRunner` applies the validator updates to the
+// local node.
+func genesisValidators(localNode *node.LocalNode) []string {
+	var validators []string
+	for address := range localNode.GetValidators() {
+		validators = append(validators, address)
+	}
+	sort.Strings(validators)
+
+	return validators
+}
@@ -152,15 +152,21 @@ func (f *BlockFetcher) fetch(ctx context.Context, si *SyncInfo) error {
 		return err
 	}
 
+	blk := blocks[0].(block.Block)
+	si.Block = &blk
+
+	if certificates, ok := items[runner.NodeItemCommitCertificate]; ok && len(certificates) > 0 {
+		if c, ok := certificates[0].(block.CommitCertificate); ok && c.Height == blk.Height {
+			si.Certificate = &c
+		}
+	}
+
 	bts, ok := items[runner.NodeItemBlockTransaction]
 	if !ok {
 		err := errors.New("fetch: block transactions not found in response")
 		return err
 	}
 
-	blk := blocks[0].(block.Block)
-	si.Block = &blk
-
 	{
 		btmap := make(map[string]*block.BlockTransaction) // For ordering txs by block.Transactions
 
@@ -274,13 +280,17 @@ func (f *BlockFetcher) unmarshalResp(body io.Reader) (map[runner.NodeItemDataTyp
 	return items, nil
 }
 
+// apiClientURL returns the url to fetch the block with it's transactions and
+// commit certificate; the light mode also needs the transactions to find the
+// validator updates.
 func apiClientURL(n node.Node, height uint64) *url.URL {
 	ep := n.Endpoint()
 	u := url.URL(*ep)
 	u.Path = network.UrlPathPrefixNode + runner.GetBlocksPattern
 	q := u.Query()
 	q.Set("height-range", fmt.Sprintf("%d-%d", height, height+1))
-	q.Set("mode", "full")
+	q.Set("mode", string(runner.GetBlocksOptionsModeFull))
+	q.Set("certificate", "1")
 	u.RawQuery = q.Encode()
 
 	return &u
@@ -54,7 +54,10 @@ func TestBlockFetcher(t *testing.T) {
 	require.NoError(t, err)
 	bt.Message = tp.Message
 
+	var mode string
 	apiHandlerFunc := func(req *http.Request) (*http.Response, error) {
+		mode = req.URL.Query().Get("mode")
+
 		w := httptest.NewRecorder()
 		renderNodeItem(w, runner.NodeItemBlock, bk)
 
@@ -62,6 +65,7 @@ func TestBlockFetcher(t *testing.T) {
 		bt.Message = tp.Message
 
 		renderNodeItem(w, runner.NodeItemBlockTransaction, bt)
+		renderNodeItem(w, runner.NodeItemCommitCertificate, block.NewCommitCertificate(bk, nil))
 		resp := w.Result()
 		return resp, nil
 	}
@@ -78,8 +82,11 @@ func TestBlockFetcher(t *testing.T) {
 	nodelist.SetLatestNodeAddrs([]string{kp.Address()})
 	si, err := f.Fetch(ctx, &SyncInfo{Height: 1, NodeList: nodelist})
 	require.NoError(t, err)
+	require.Equal(t, string(runner.GetBlocksOptionsModeFull), mode)
 	require.Equal(t, bk.Hash, si.Block.Hash)
 	require.Equal(t, bk.TransactionsRoot, si.Block.TransactionsRoot)
+	require.NotNil(t, si.Certificate)
+	require.Equal(t, bk.Hash, si.Certificate.BlockHash)
 }
 
 func TestLargeFetch(t *testing.T) {
@@ -0,0 +1,149 @@
+package sync
+
+import (
+	"context"
+	"strconv"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common/observer"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/transaction/operation"
+	"boscoin.io/sebak/lib/voting"
+)
+
+// LightBlockValidator validates and stores only the block and it's commit
+// certificate; the transactions and the account states are not stored, except
+// the validator updates of transactions, which are needed to verify the commit
+// certificates of the next blocks.
+type LightBlockValidator struct {
+	*BlockValidator
+}
+
+func NewLightBlockValidator(v *BlockValidator) *LightBlockValidator {
+	return &LightBlockValidator{BlockValidator: v}
+}
+
+func (v *LightBlockValidator) Validate(ctx context.Context, syncInfo *SyncInfo) error {
+	exists, err := v.existsBlock(ctx, v.storage, syncInfo.Height)
+	if err != nil {
+		return err
+	}
+	if exists == true {
+		v.logger.Info("this block exists", "height", syncInfo.Height)
+		return nil
+	}
+	v.logger.Debug("start validate light block", "height", syncInfo.Height)
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		if err := v.validate(ctx, syncInfo); err != nil {
+			return err
+		}
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		return v.finishBlock(ctx, syncInfo)
+	}
+}
+
+func (v *LightBlockValidator) validate(ctx context.Context, si *SyncInfo) error {
+	prevBlk, err := v.getPrevBlock(ctx, si.Height)
+	if err != nil {
+		return err
+	}
+
+	r := voting.Basis{
+		Round:     si.Block.Round,
+		Height:    si.Height,
+		BlockHash: prevBlk.Hash,
+		TotalTxs:  si.Block.TotalTxs,
+		TotalOps:  si.Block.TotalOps,
+	}
+
+	blk := block.NewBlock(
+		si.Block.Proposer,
+		r,
+		si.Block.ProposerTransaction,
+		si.Block.Transactions,
+		si.Block.StateRoot,
+		si.Block.ProposedTime,
+	)
+	if blk.Hash != si.Block.Hash {
+		return errors.HashDoesNotMatch
+	}
+
+	// the transactions are linked to the block by their hashes
+	if len(si.Bts) != len(si.Block.Transactions) {
+		return errors.TransactionNotFound
+	}
+	for i, bt := range si.Bts {
+		tx := bt.Transaction()
+		if tx.B.MakeHashString() != si.Block.Transactions[i] {
+			return errors.HashDoesNotMatch
+		}
+	}
+
+	// without commit certificate, the light block can not be trusted
+	if si.Certificate == nil {
+		return errors.CommitCertificateNotFound
+	}
+	if v.policy == nil || len(v.validators) < 1 {
+		return errors.InvalidCommitCertificate
+	}
+
+	return v.validateCertificate(ctx, si)
+}
+
+func (v *LightBlockValidator) finishBlock(ctx context.Context, si *SyncInfo) error {
+	bs, err := v.storage.OpenBatch()
+	if err != nil {
+		return err
+	}
+
+	blk := *si.Block
+	if err := blk.Save(bs); err != nil {
+		bs.Discard()
+		if err == errors.BlockAlreadyExists {
+			return nil
+		}
+		return err
+	}
+
+	if err := si.Certificate.Save(bs); err != nil {
+		bs.Discard()
+		return err
+	}
+
+	for _, bt := range si.Bts {
+		for _, op := range bt.Transaction().B.Operations {
+			opb, ok := op.B.(operation.ValidatorUpdate)
+			if !ok {
+				continue
+			}
+			if err := block.NewValidatorUpdate(opb).Save(bs); err != nil {
+				bs.Discard()
+				return err
+			}
+		}
+	}
+
+	if err := bs.Commit(); err != nil {
+		bs.Discard()
+		return err
+	}
+
+	v.logger.Debug("finish to sync light block", "height", si.Height, "hash", blk.Hash)
+
+	select {
+	case <-ctx.Done():
+		return nil
+	default:
+		observer.SyncBlockWaitObserver.Trigger(strconv.FormatUint(si.Height, 10))
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/consensus"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+func TestLightBlockValidator(t *testing.T) {
+	conf := common.NewTestConfig()
+	conf.LightMode = true
+
+	st := block.InitTestBlockchain()
+	defer st.Close()
+	_, nw, localNode := network.CreateMemoryNetwork(nil)
+	localNode.ClearValidators()
+
+	var kps []*keypair.Full
+	for i := 0; i < 3; i++ {
+		kp := keypair.Random()
+		ep, _ := common.NewEndpointFromString(fmt.Sprintf("https://node%d?NodeName=n%d", i, i))
+		validator, _ := node.NewValidator(kp.Address(), ep, "")
+		localNode.AddValidators(validator)
+		kps = append(kps, kp)
+	}
+
+	policy, _ := consensus.NewDefaultVotingThresholdPolicy(66)
+	policy.SetValidators(len(kps)) // threshold is 2
+
+	v := NewLightBlockValidator(NewBlockValidator(nw, st, transaction.NewPool(conf), conf, func(v *BlockValidator) {
+		v.policy = policy
+		v.validators = genesisValidators(localNode)
+	}))
+
+	genesis := block.GetLatestBlock(st)
+
+	// the validator update of transaction is stored
+	kpNew := keypair.Random()
+	kpSource := keypair.Random()
+	op, _ := operation.NewOperation(operation.NewValidatorUpdate(genesis.Height+2, operation.ValidatorUpdateAdd, kpNew.Address(), ""))
+	tx, _ := transaction.NewTransaction(kpSource.Address(), 0, op)
+	tx.Sign(kpSource, conf.NetworkID)
+
+	bk := block.TestMakeNewBlockWithPrevBlock(genesis, []string{tx.GetHash()})
+	bts := []*block.BlockTransaction{}
+	{
+		bt := block.NewBlockTransactionFromTransaction(bk.Hash, bk.Height, bk.ProposedTime, tx)
+		bts = append(bts, &bt)
+	}
+	certificate := func(signers ...*keypair.Full) *block.CommitCertificate {
+		var signatures []block.CommitSignature
+		for _, kp := range signers {
+			signature, _ := keypair.MakeSignature(kp, conf.NetworkID, bk.Hash)
+			signatures = append(signatures, block.CommitSignature{Validator: kp.Address(), Signature: base58.Encode(signature)})
+		}
+		c := block.NewCommitCertificate(bk, signatures)
+		return &c
+	}
+
+	ctx := context.Background()
+
+	{ // without certificate
+		err := v.Validate(ctx, &SyncInfo{Height: bk.Height, Block: &bk, Bts: bts})
+		require.Equal(t, errors.CommitCertificateNotFound, err)
+	}
+
+	{ // not enough signatures
+		err := v.Validate(ctx, &SyncInfo{Height: bk.Height, Block: &bk, Bts: bts, Certificate: certificate(kps[0])})
+		require.Equal(t, errors.InvalidCommitCertificate.Code, err.(*errors.Error).Code)
+	}
+
+	{ // modified block
+		modified := bk
+		modified.Proposer = keypair.Random().Address()
+		err := v.Validate(ctx, &SyncInfo{Height: bk.Height, Block: &modified, Bts: bts, Certificate: certificate(kps...)})
+		require.Equal(t, errors.HashDoesNotMatch, err)
+	}
+
+	{ // without transactions
+		err := v.Validate(ctx, &SyncInfo{Height: bk.Height, Block: &bk, Certificate: certificate(kps...)})
+		require.Equal(t, errors.TransactionNotFound, err)
+	}
+
+	{ // modified transaction
+		modified := tx
+		modified.B.Operations = nil
+		bt := block.NewBlockTransactionFromTransaction(bk.Hash, bk.Height, bk.ProposedTime, modified)
+		err := v.Validate(ctx, &SyncInfo{Height: bk.Height, Block: &bk, Bts: []*block.BlockTransaction{&bt}, Certificate: certificate(kps...)})
+		require.Equal(t, errors.HashDoesNotMatch, err)
+	}
+
+	require.NoError(t, v.Validate(ctx, &SyncInfo{Height: bk.Height, Block: &bk, Bts: bts, Certificate: certificate(kps[0], kps[2])}))
+
+	saved, err := block.GetBlockByHeight(st, bk.Height)
+	require.NoError(t, err)
+	require.Equal(t, bk.Hash, saved.Hash)
+
+	c, err := block.GetCommitCertificate(st, bk.Height)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(c.Signatures))
+
+	// the transactions of block are not stored
+	exists, err := block.ExistsBlockTransaction(st, tx.GetHash())
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// but the validator updates are stored
+	updates := block.GetValidatorUpdates(st, genesis.Height+2, genesis.Height+2)
+	require.Equal(t, 1, len(updates))
+	require.Equal(t, kpNew.Address(), updates[0].Address)
+}
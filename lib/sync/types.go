@@ -25,6 +25,10 @@ type SyncInfo struct {
 	Bts    []*block.BlockTransaction
 	Ptx    *ballot.ProposerTransaction
 
+	// Certificate is the commit certificate of block; the validators reject
+	// the block without it.
+	Certificate *block.CommitCertificate
+
 	// Fetching target node addresses, NodeList is  the validators which
 	// participated and confirmed the consensus of latest ballot.
 	NodeList *NodeList
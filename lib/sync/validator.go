@@ -26,6 +26,13 @@ type BlockValidator struct {
 	txpool    *transaction.Pool
 	commonCfg common.Config
 
+	// policy and validators are used to verify the commit certificate; if
+	// policy is nil, the certificate is not verified. validators are the
+	// validators of genesis; the certificate is verified by the validators
+	// in force at the height of block, see `block.GetValidatorsAtHeight`.
+	policy     voting.ThresholdPolicy
+	validators []string
+
 	prevBlockWaitTimeout time.Duration // Waiting prev block if is doesn't exist
 	logger               log15.Logger
 }
@@ -92,6 +99,10 @@ func (v *BlockValidator) validate(ctx context.Context, syncInfo *SyncInfo) error
 		return err
 	}
 
+	if err := v.validateCertificate(ctx, syncInfo); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -166,6 +177,30 @@ func (v *BlockValidator) finishBlock(ctx context.Context, syncInfo *SyncInfo) er
 		}
 	}
 
+	prevBlk, err := block.GetBlockByHeight(bs, blk.Height-1)
+	if err != nil {
+		bs.Discard()
+		return err
+	}
+
+	stateAccounts := runner.StateAccounts(txs, *syncInfo.Ptx)
+	stateRoot, err := runner.UpdateStateRoot(bs, prevBlk.StateRoot, stateAccounts, true)
+	if err != nil {
+		bs.Discard()
+		return err
+	}
+	if stateRoot != blk.StateRoot {
+		bs.Discard()
+		return errors.StateRootDoesNotMatch
+	}
+
+	if syncInfo.Certificate != nil {
+		if err := syncInfo.Certificate.Save(bs); err != nil {
+			bs.Discard()
+			return err
+		}
+	}
+
 	v.logger.Debug("finish to sync block height", "height", syncInfo.Height, "hash", blk.Hash)
 
 	if err := bs.Commit(); err != nil {
@@ -192,8 +227,10 @@ func (v *BlockValidator) finishBlock(ctx context.Context, syncInfo *SyncInfo) er
 func (v *BlockValidator) validateBlock(ctx context.Context, si *SyncInfo, prevBlk *block.Block) error {
 	v.logger.Debug("start validate block", "height", si.Height)
 	var txs []string
+	var ops uint64
 	for _, bt := range si.Bts {
 		txs = append(txs, bt.Hash)
+		ops += uint64(len(bt.Operations))
 	}
 
 	r := voting.Basis{
@@ -204,7 +241,11 @@ func (v *BlockValidator) validateBlock(ctx context.Context, si *SyncInfo, prevBl
 		TotalOps:  si.Block.TotalOps,
 	}
 
-	blk := block.NewBlock(si.Block.Proposer, r, si.Block.ProposerTransaction, txs, si.Block.ProposedTime)
+	// the minimum fee of block is decided by the previous block
+	blk := block.NewBlockWithFee(
+		si.Block.Proposer, r, si.Block.ProposerTransaction, txs, si.Block.StateRoot, si.Block.ProposedTime,
+		block.NextMinFee(prevBlk.Header, v.commonCfg.OpsInBallotLimit), ops,
+	)
 
 	if blk.Hash != si.Block.Hash {
 		err := errors.HashDoesNotMatch
@@ -216,6 +257,34 @@ func (v *BlockValidator) validateBlock(ctx context.Context, si *SyncInfo, prevBl
 	return nil
 }
 
+// validateCertificate checks the commit certificate of block has the valid
+// signatures of validators as many as the threshold; the block without
+// certificate is rejected. The validators and the threshold are the ones in
+// force at the height of block, not the current ones; the validator updates
+// until the height are already stored by the previous blocks. Whether the
+// local node is one of them does not matter, so the node, which is removed
+// from the validators, still syncs the blocks.
+func (v *BlockValidator) validateCertificate(ctx context.Context, si *SyncInfo) error {
+	if v.policy == nil || len(v.validators) < 1 {
+		return nil
+	}
+
+	if si.Certificate == nil {
+		v.logger.Error("commit certificate not found", "height", si.Height)
+		return errors.CommitCertificateNotFound
+	}
+
+	validators := block.GetValidatorsAtHeight(v.storage, v.validators, si.Height)
+	threshold := v.policy.ThresholdOf(len(validators))
+
+	if err := si.Certificate.Verify(v.commonCfg.NetworkID, *si.Block, validators, threshold); err != nil {
+		v.logger.Error("invalid commit certificate", "height", si.Height, "error", err)
+		return err
+	}
+
+	return nil
+}
+
 func (v *BlockValidator) validateTxs(ctx context.Context, si *SyncInfo) error {
 	v.logger.Debug("start validate txs", "height", si.Height)
 	// proposer transaction
@@ -224,7 +293,8 @@ func (v *BlockValidator) validateTxs(ctx context.Context, si *SyncInfo) error {
 			return err
 		}
 	}
-	// transactions
+	// transactions; the transactions of same source are validated in order
+	pending := map[string][]transaction.Transaction{}
 	for _, bt := range si.Bts {
 		tx := bt.Transaction()
 		hash := tx.B.MakeHashString()
@@ -233,13 +303,14 @@ func (v *BlockValidator) validateTxs(ctx context.Context, si *SyncInfo) error {
 			return err
 		}
 
-		if err := tx.IsWellFormed(v.commonCfg); err != nil {
+		if err := tx.IsWellFormedWithMinFee(v.commonCfg, si.Block.MinFee); err != nil {
 			return err
 		}
 
-		if err := runner.ValidateTx(v.storage, v.commonCfg, tx); err != nil {
+		if err := runner.ValidateTxWithPending(v.storage, v.commonCfg, si.Block.MinFee, pending[tx.B.Source], tx); err != nil {
 			return err
 		}
+		pending[tx.B.Source] = append(pending[tx.B.Source], tx)
 	}
 
 	v.logger.Debug("end validate txs", "height", si.Height)
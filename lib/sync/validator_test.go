@@ -6,8 +6,13 @@ import (
 
 	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/consensus"
+	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/network"
 	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/stretchr/testify/require"
 )
 
@@ -35,3 +40,71 @@ func TestValidator(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+func TestValidatorCertificate(t *testing.T) {
+	conf := common.NewTestConfig()
+	st := block.InitTestBlockchain()
+	defer st.Close()
+	_, nw, _ := network.CreateMemoryNetwork(nil)
+	tp := transaction.NewPool(conf)
+
+	var kps []*keypair.Full
+	var validators []string
+	for i := 0; i < 3; i++ {
+		kp := keypair.Random()
+		kps = append(kps, kp)
+		validators = append(validators, kp.Address())
+	}
+
+	policy, _ := consensus.NewDefaultVotingThresholdPolicy(66)
+	policy.SetValidators(len(kps)) // threshold is 2
+
+	v := NewBlockValidator(nw, st, tp, conf, func(v *BlockValidator) {
+		v.policy = policy
+		v.validators = validators
+	})
+
+	bk := block.TestMakeNewBlockWithPrevBlock(block.GetLatestBlock(st), nil)
+	sign := func(kp *keypair.Full) block.CommitSignature {
+		signature, _ := keypair.MakeSignature(kp, conf.NetworkID, bk.Hash)
+		return block.CommitSignature{Validator: kp.Address(), Signature: base58.Encode(signature)}
+	}
+
+	ctx := context.Background()
+
+	{ // without certificate
+		err := v.validateCertificate(ctx, &SyncInfo{Height: bk.Height, Block: &bk})
+		require.Equal(t, errors.CommitCertificateNotFound, err)
+	}
+
+	{ // enough signatures
+		c := block.NewCommitCertificate(bk, []block.CommitSignature{sign(kps[0]), sign(kps[1])})
+		require.NoError(t, v.validateCertificate(ctx, &SyncInfo{Height: bk.Height, Block: &bk, Certificate: &c}))
+	}
+
+	{ // not enough signatures of validators
+		c := block.NewCommitCertificate(bk, []block.CommitSignature{sign(kps[0]), sign(keypair.Random())})
+		err := v.validateCertificate(ctx, &SyncInfo{Height: bk.Height, Block: &bk, Certificate: &c})
+		require.Equal(t, errors.InvalidCommitCertificate.Code, err.(*errors.Error).Code)
+	}
+
+	// from the height of block, kps[0] is removed and kpNew is added
+	kpNew := keypair.Random()
+	for _, u := range []operation.ValidatorUpdate{
+		operation.NewValidatorUpdate(bk.Height, operation.ValidatorUpdateRemove, kps[0].Address(), ""),
+		operation.NewValidatorUpdate(bk.Height, operation.ValidatorUpdateAdd, kpNew.Address(), ""),
+	} {
+		require.NoError(t, block.NewValidatorUpdate(u).Save(st))
+	}
+
+	{ // signed by the removed validator
+		c := block.NewCommitCertificate(bk, []block.CommitSignature{sign(kps[0]), sign(kps[1])})
+		err := v.validateCertificate(ctx, &SyncInfo{Height: bk.Height, Block: &bk, Certificate: &c})
+		require.Equal(t, errors.InvalidCommitCertificate.Code, err.(*errors.Error).Code)
+	}
+
+	{ // signed by the validators of the height
+		c := block.NewCommitCertificate(bk, []block.CommitSignature{sign(kpNew), sign(kps[1])})
+		require.NoError(t, v.validateCertificate(ctx, &SyncInfo{Height: bk.Height, Block: &bk, Certificate: &c}))
+	}
+}
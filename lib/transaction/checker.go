@@ -17,6 +17,7 @@ type Checker struct {
 	NetworkID   []byte
 	Transaction Transaction
 	Conf        common.Config
+	MinFee      common.Amount
 }
 
 func CheckSource(c common.Checker, args ...interface{}) (err error) {
@@ -42,7 +43,11 @@ func CheckOverOperationsLimit(c common.Checker, args ...interface{}) (err error)
 
 func CheckBaseFee(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*Checker)
-	if checker.Transaction.B.Fee < checker.Transaction.TotalBaseFee() {
+	minFee := checker.MinFee
+	if minFee < common.BaseFee {
+		minFee = common.BaseFee
+	}
+	if checker.Transaction.B.Fee < checker.Transaction.TotalMinFee(minFee) {
 		err = errors.InvalidFee
 		return
 	}
@@ -71,7 +76,37 @@ func CheckOperations(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*Checker)
 
 	var hashes []string
-	for _, op := range checker.Transaction.B.Operations {
+	var hasSetSigners bool
+	ops := checker.Transaction.B.Operations
+	for i, op := range ops {
+		if mop, ok := op.B.(operation.AccountMerge); ok {
+			// the source account is removed by `AccountMerge`, so nothing
+			// can follow it
+			if i != len(ops)-1 {
+				err = errors.AccountMergeNotLastOperation
+				return
+			}
+			if checker.Transaction.B.Source == mop.TargetAddress() {
+				err = errors.InvalidOperation
+				return
+			}
+			if err = op.IsWellFormed(checker.Conf); err != nil {
+				return
+			}
+			continue
+		}
+		if _, ok := op.B.(operation.SetSigners); ok {
+			// only one `SetSigners` is allowed in one transaction
+			if hasSetSigners {
+				err = errors.DuplicatedOperation
+				return
+			}
+			if err = op.IsWellFormed(checker.Conf); err != nil {
+				return
+			}
+			hasSetSigners = true
+			continue
+		}
 		if pop, ok := op.B.(operation.Payable); ok {
 			if checker.Transaction.B.Source == pop.TargetAddress() {
 				err = errors.InvalidOperation
@@ -95,16 +130,62 @@ func CheckOperations(c common.Checker, args ...interface{}) (err error) {
 	return
 }
 
+func CheckMemo(c common.Checker, args ...interface{}) (err error) {
+	checker := c.(*Checker)
+	if checker.Transaction.B.Memo == nil {
+		return
+	}
+
+	return checker.Transaction.B.Memo.IsWellFormed()
+}
+
+func CheckTimeBounds(c common.Checker, args ...interface{}) (err error) {
+	checker := c.(*Checker)
+	if checker.Transaction.B.TimeBounds == nil {
+		return
+	}
+
+	return checker.Transaction.B.TimeBounds.IsWellFormed()
+}
+
 func CheckVerifySignature(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*Checker)
 
+	tx := checker.Transaction
+	if len(tx.H.Signature) < 1 && len(tx.H.Signatures) < 1 {
+		err = errors.SignatureVerificationFailed
+		return
+	}
+
+	if len(tx.H.Signature) > 0 {
+		if err = verifySignature(checker.NetworkID, tx.H.Hash, tx.B.Source, tx.H.Signature); err != nil {
+			return
+		}
+	}
+
+	signers := []string{tx.B.Source}
+	for _, s := range tx.H.Signatures {
+		if _, found := common.InStringArray(signers, s.Signer); found {
+			err = errors.DuplicatedSigner
+			return
+		}
+		if err = verifySignature(checker.NetworkID, tx.H.Hash, s.Signer, s.Signature); err != nil {
+			return
+		}
+		signers = append(signers, s.Signer)
+	}
+
+	return
+}
+
+func verifySignature(networkID []byte, hash, address, signature string) (err error) {
 	var kp keypair.KP
-	if kp, err = keypair.Parse(checker.Transaction.B.Source); err != nil {
+	if kp, err = keypair.Parse(address); err != nil {
 		return
 	}
 	err = kp.Verify(
-		append(checker.NetworkID, []byte(checker.Transaction.H.Hash)...),
-		base58.Decode(checker.Transaction.H.Signature),
+		append(networkID, []byte(hash)...),
+		base58.Decode(signature),
 	)
 	if err != nil {
 		return
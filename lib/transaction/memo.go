@@ -0,0 +1,78 @@
+package transaction
+
+import (
+	"strconv"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+)
+
+type MemoType string
+
+const (
+	// MemoText is the utf-8 text, which is up to `common.MaxMemoTextSize`
+	// bytes.
+	MemoText MemoType = "text"
+	// MemoID is the unsigned 64 bit integer, like the customer id.
+	MemoID MemoType = "id"
+	// MemoHash is the base58 encoded 32 bytes hash.
+	MemoHash MemoType = "hash"
+)
+
+// Memo is the optional, typed note of transaction. It is part of `Body`, so
+// it can not be changed after the transaction is signed.
+type Memo struct {
+	Type  MemoType `json:"type"`
+	Value string   `json:"value"`
+}
+
+func NewMemo(memoType MemoType, value string) (memo Memo, err error) {
+	memo = Memo{Type: memoType, Value: value}
+	err = memo.IsWellFormed()
+
+	return
+}
+
+func (m Memo) IsWellFormed() (err error) {
+	switch m.Type {
+	case MemoText:
+		if len(m.Value) > common.MaxMemoTextSize {
+			err = errors.InvalidMemo.Clone().SetData("error", "too long text")
+			return
+		}
+	case MemoID:
+		if _, err = m.ID(); err != nil {
+			err = errors.InvalidMemo.Clone().SetData("error", "id must be unsigned integer")
+			return
+		}
+	case MemoHash:
+		if len(base58.Decode(m.Value)) != 32 {
+			err = errors.InvalidMemo.Clone().SetData("error", "hash must be 32 bytes")
+			return
+		}
+	default:
+		err = errors.InvalidMemo.Clone().SetData("error", "unknown memo type")
+		return
+	}
+
+	return
+}
+
+// ID returns the unsigned integer of `MemoID`.
+func (m Memo) ID() (uint64, error) {
+	return strconv.ParseUint(m.Value, 10, 64)
+}
+
+// Key returns the unique string of memo for indexing. `MemoID` is indexed by
+// it's canonical number, so "01" and "1" are the same key.
+func (m Memo) Key() string {
+	if m.Type == MemoID {
+		if id, err := m.ID(); err == nil {
+			m.Value = strconv.FormatUint(id, 10)
+		}
+	}
+
+	return common.MustMakeObjectHashString(m)
+}
@@ -0,0 +1,99 @@
+package transaction
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestMemo(t *testing.T) {
+	{ // text
+		_, err := NewMemo(MemoText, "customer-1")
+		require.NoError(t, err)
+
+		_, err = NewMemo(MemoText, strings.Repeat("a", common.MaxMemoTextSize))
+		require.NoError(t, err)
+
+		_, err = NewMemo(MemoText, strings.Repeat("a", common.MaxMemoTextSize+1))
+		require.Equal(t, errors.InvalidMemo.Code, err.(*errors.Error).Code)
+	}
+
+	{ // id
+		_, err := NewMemo(MemoID, "18446744073709551615")
+		require.NoError(t, err)
+
+		_, err = NewMemo(MemoID, "-1")
+		require.Equal(t, errors.InvalidMemo.Code, err.(*errors.Error).Code)
+
+		_, err = NewMemo(MemoID, "18446744073709551616")
+		require.Equal(t, errors.InvalidMemo.Code, err.(*errors.Error).Code)
+	}
+
+	{ // hash
+		_, err := NewMemo(MemoHash, base58.Encode(common.MakeHash([]byte("showme"))))
+		require.NoError(t, err)
+
+		_, err = NewMemo(MemoHash, base58.Encode([]byte("showme")))
+		require.Equal(t, errors.InvalidMemo.Code, err.(*errors.Error).Code)
+	}
+
+	{ // unknown type
+		_, err := NewMemo(MemoType("findme"), "showme")
+		require.Equal(t, errors.InvalidMemo.Code, err.(*errors.Error).Code)
+	}
+}
+
+func TestMemoKey(t *testing.T) {
+	id, _ := NewMemo(MemoID, "1")
+	leadingZeros, _ := NewMemo(MemoID, "0001")
+	other, _ := NewMemo(MemoID, "10")
+	text, _ := NewMemo(MemoText, "1")
+
+	n, err := leadingZeros.ID()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), n)
+
+	// id is indexed by it's canonical number
+	require.Equal(t, id.Key(), leadingZeros.Key())
+	require.NotEqual(t, id.Key(), other.Key())
+	require.NotEqual(t, id.Key(), text.Key())
+}
+
+func TestTransactionWithMemo(t *testing.T) {
+	conf := common.NewTestConfig()
+
+	kp, tx := TestMakeTransaction(conf.NetworkID, 1)
+	hashWithoutMemo := tx.GetHash()
+
+	memo, _ := NewMemo(MemoID, "1")
+	tx.B.Memo = &memo
+	tx.Sign(kp, conf.NetworkID)
+	require.NoError(t, tx.IsWellFormed(conf))
+
+	// memo is part of hash
+	require.NotEqual(t, hashWithoutMemo, tx.GetHash())
+	memo.Value = "2"
+	require.NotEqual(t, tx.GetHash(), tx.B.MakeHashString())
+
+	{ // memo is kept in JSON
+		b, err := tx.Serialize()
+		require.NoError(t, err)
+
+		var tx2 Transaction
+		common.MustUnmarshalJSON(b, &tx2)
+		require.Equal(t, *tx.B.Memo, *tx2.B.Memo)
+		require.Equal(t, tx.B.MakeHashString(), tx2.GetHash())
+	}
+
+	{ // invalid memo
+		tx.B.Memo = &Memo{Type: MemoID, Value: "showme"}
+		tx.Sign(kp, conf.NetworkID)
+		err := tx.IsWellFormed(conf)
+		require.Equal(t, errors.InvalidMemo.Code, err.(*errors.Error).Code)
+	}
+}
@@ -0,0 +1,35 @@
+package operation
+
+import (
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+)
+
+// AccountMerge moves the whole remaining balance of the source account to
+// `Target` and removes the source account. It must be the last operation of
+// transaction.
+type AccountMerge struct {
+	Target string `json:"target"`
+}
+
+func NewAccountMerge(target string) AccountMerge {
+	return AccountMerge{
+		Target: target,
+	}
+}
+
+func (o AccountMerge) IsWellFormed(common.Config) (err error) {
+	if _, err = keypair.Parse(o.Target); err != nil {
+		return
+	}
+
+	return
+}
+
+func (o AccountMerge) TargetAddress() string {
+	return o.Target
+}
+
+func (o AccountMerge) HasFee() bool {
+	return true
+}
@@ -0,0 +1,39 @@
+package operation
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+)
+
+func TestAccountMergeOperation(t *testing.T) {
+	conf := common.NewTestConfig()
+
+	kp := keypair.Random()
+	{
+		o := NewAccountMerge(kp.Address())
+		require.NoError(t, o.IsWellFormed(conf))
+		require.Equal(t, kp.Address(), o.TargetAddress())
+	}
+
+	{ // invalid target
+		o := NewAccountMerge("invalid-address")
+		require.Error(t, o.IsWellFormed(conf))
+	}
+
+	{ // RLP and JSON
+		op, err := NewOperation(NewAccountMerge(kp.Address()))
+		require.NoError(t, err)
+		require.Equal(t, TypeAccountMerge, op.H.Type)
+		require.Equal(t, ThresholdHigh, GetThresholdLevel(op.H.Type))
+		common.CheckRoundTripRLP(t, op)
+
+		var unmarshaled Operation
+		require.NoError(t, json.Unmarshal(common.MustMarshalJSON(op), &unmarshaled))
+		require.Equal(t, op, unmarshaled)
+	}
+}
@@ -0,0 +1,34 @@
+package operation
+
+import (
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+// Delegate points the stake of frozen account, the source of transaction to
+// the validator. The stake is the balance of frozen account and it is
+// undelegated by `UnfreezeRequest`.
+type Delegate struct {
+	Validator string `json:"validator"`
+}
+
+func NewDelegate(validator string) Delegate {
+	return Delegate{
+		Validator: validator,
+	}
+}
+
+func (o Delegate) IsWellFormed(common.Config) (err error) {
+	if _, err = keypair.Parse(o.Validator); err != nil {
+		return errors.BadPublicAddress
+	}
+
+	return
+}
+
+// HasFee returns false; like `UnfreezeRequest`, the balance of frozen
+// account is kept.
+func (o Delegate) HasFee() bool {
+	return false
+}
@@ -0,0 +1,92 @@
+package operation
+
+import (
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+// DelegatorReward is the share of inflation for the delegator; the frozen
+// account can not receive, so it is sent to the linked account, `Target`.
+type DelegatorReward struct {
+	Delegator string        `json:"delegator"`
+	Target    string        `json:"target"`
+	Amount    common.Amount `json:"amount"`
+}
+
+// DelegationReward is the operation to route the inflation of block from the
+// common account to the delegators pro rata to the delegated stake; see
+// `common.Config.InflationToDelegators`. Like `Inflation`, it has the block
+// related data.
+type DelegationReward struct {
+	Rewards   []DelegatorReward `json:"rewards"`
+	Height    uint64            `json:"block-height"`
+	BlockHash string            `json:"block-hash"`
+	TotalTxs  uint64            `json:"total-txs"`
+}
+
+func NewDelegationReward(
+	rewards []DelegatorReward,
+	blockHeight uint64,
+	blockHash string,
+	totalTxs uint64,
+) DelegationReward {
+	return DelegationReward{
+		Rewards:   rewards,
+		Height:    blockHeight,
+		BlockHash: blockHash,
+		TotalTxs:  totalTxs,
+	}
+}
+
+// IsWellFormed checks the rewards are sorted by delegator without
+// duplication and every amount is over 0 without overflowing the total.
+func (o DelegationReward) IsWellFormed(common.Config) (err error) {
+	if len(o.BlockHash) < 1 {
+		err = errors.InvalidOperation
+		return
+	}
+
+	if len(o.Rewards) < 1 {
+		err = errors.InvalidOperation
+		return
+	}
+
+	var total common.Amount
+	for i, r := range o.Rewards {
+		if _, err = keypair.Parse(r.Delegator); err != nil {
+			err = errors.BadPublicAddress
+			return
+		}
+		if _, err = keypair.Parse(r.Target); err != nil {
+			err = errors.BadPublicAddress
+			return
+		}
+		if r.Amount < 1 {
+			err = errors.InvalidOperation
+			return
+		}
+		if i > 0 && o.Rewards[i-1].Delegator >= r.Delegator {
+			err = errors.InvalidOperation
+			return
+		}
+		if total, err = total.Add(r.Amount); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// GetAmount returns the total amount of rewards.
+func (o DelegationReward) GetAmount() (amount common.Amount) {
+	for _, r := range o.Rewards {
+		amount = amount.MustAdd(r.Amount)
+	}
+
+	return
+}
+
+func (o DelegationReward) HasFee() bool {
+	return false
+}
@@ -0,0 +1,89 @@
+package operation
+
+import (
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+// TxFeeRecipient is the validator, which receives the share of collected
+// transaction fee. With `common.TxFeeDistributionSigners`, `Signature` is
+// the commit signature of validator for the previous block.
+type TxFeeRecipient struct {
+	Address   string        `json:"address"`
+	Amount    common.Amount `json:"amount"`
+	Signature string        `json:"signature,omitempty"`
+}
+
+// DistributeTxFee is the operation to send the validator share of collected
+// transaction fee, `common.Config.TxFeeValidatorShare`, to the validators;
+// the rest is sent by `CollectTxFee`. Like `CollectTxFee`, it has the block
+// related data.
+type DistributeTxFee struct {
+	Recipients []TxFeeRecipient `json:"recipients"`
+	Height     uint64           `json:"block-height"`
+	BlockHash  string           `json:"block-hash"`
+	TotalTxs   uint64           `json:"total-txs"`
+}
+
+func NewDistributeTxFee(
+	recipients []TxFeeRecipient,
+	blockHeight uint64,
+	blockHash string,
+	totalTxs uint64,
+) DistributeTxFee {
+	return DistributeTxFee{
+		Recipients: recipients,
+		Height:     blockHeight,
+		BlockHash:  blockHash,
+		TotalTxs:   totalTxs,
+	}
+}
+
+// IsWellFormed checks the recipients are sorted by address without
+// duplication and every amount is over 0 without overflowing the total.
+func (o DistributeTxFee) IsWellFormed(common.Config) (err error) {
+	if len(o.BlockHash) < 1 {
+		err = errors.InvalidOperation
+		return
+	}
+
+	if len(o.Recipients) < 1 {
+		err = errors.InvalidOperation
+		return
+	}
+
+	var total common.Amount
+	for i, r := range o.Recipients {
+		if _, err = keypair.Parse(r.Address); err != nil {
+			err = errors.BadPublicAddress
+			return
+		}
+		if r.Amount < 1 {
+			err = errors.InvalidOperation
+			return
+		}
+		if i > 0 && o.Recipients[i-1].Address >= r.Address {
+			err = errors.InvalidOperation
+			return
+		}
+		if total, err = total.Add(r.Amount); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// GetAmount returns the total amount of recipients.
+func (o DistributeTxFee) GetAmount() (amount common.Amount) {
+	for _, r := range o.Recipients {
+		amount = amount.MustAdd(r.Amount)
+	}
+
+	return
+}
+
+func (o DistributeTxFee) HasFee() bool {
+	return false
+}
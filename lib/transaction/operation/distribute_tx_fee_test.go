@@ -0,0 +1,71 @@
+package operation
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestDistributeTxFeeOperation(t *testing.T) {
+	conf := common.NewTestConfig()
+
+	addresses := []string{keypair.Random().Address(), keypair.Random().Address()}
+	sort.Strings(addresses)
+
+	recipients := []TxFeeRecipient{
+		{Address: addresses[0], Amount: common.Amount(10)},
+		{Address: addresses[1], Amount: common.Amount(10)},
+	}
+
+	o := NewDistributeTxFee(recipients, 10, "block-hash", 3)
+	require.NoError(t, o.IsWellFormed(conf))
+	require.Equal(t, common.Amount(20), o.GetAmount())
+	require.False(t, o.HasFee())
+
+	{ // empty recipients
+		o := NewDistributeTxFee(nil, 10, "block-hash", 3)
+		require.Equal(t, errors.InvalidOperation, o.IsWellFormed(conf))
+	}
+
+	{ // empty block hash
+		o := NewDistributeTxFee(recipients, 10, "", 3)
+		require.Equal(t, errors.InvalidOperation, o.IsWellFormed(conf))
+	}
+
+	{ // bad address
+		o := NewDistributeTxFee([]TxFeeRecipient{{Address: "showme", Amount: common.Amount(10)}}, 10, "block-hash", 3)
+		require.Equal(t, errors.BadPublicAddress, o.IsWellFormed(conf))
+	}
+
+	{ // zero amount
+		o := NewDistributeTxFee([]TxFeeRecipient{{Address: addresses[0]}}, 10, "block-hash", 3)
+		require.Equal(t, errors.InvalidOperation, o.IsWellFormed(conf))
+	}
+
+	{ // not sorted
+		o := NewDistributeTxFee([]TxFeeRecipient{recipients[1], recipients[0]}, 10, "block-hash", 3)
+		require.Equal(t, errors.InvalidOperation, o.IsWellFormed(conf))
+	}
+
+	{ // duplicated
+		o := NewDistributeTxFee([]TxFeeRecipient{recipients[0], recipients[0]}, 10, "block-hash", 3)
+		require.Equal(t, errors.InvalidOperation, o.IsWellFormed(conf))
+	}
+
+	{ // serialization
+		op, err := NewOperation(o)
+		require.NoError(t, err)
+		require.Equal(t, TypeDistributeTxFee, op.H.Type)
+
+		var decoded Operation
+		require.NoError(t, decoded.UnmarshalJSON(common.MustMarshalJSON(op)))
+		require.Equal(t, op, decoded)
+
+		common.CheckRoundTripRLP(t, op)
+	}
+}
@@ -20,6 +20,12 @@ const (
 	TypeInflation
 	TypeUnfreezingRequest
 	TypeInflationPF
+	TypeSetSigners
+	TypeAccountMerge
+	TypeValidatorUpdate
+	TypeDistributeTxFee
+	TypeDelegate
+	TypeDelegationReward
 )
 
 var (
@@ -32,6 +38,12 @@ var (
 		"inflation",
 		"unfreezing-request",
 		"inflation-pf",
+		"set-signers",
+		"account-merge",
+		"validator-update",
+		"distribute-tx-fee",
+		"delegate",
+		"delegation-reward",
 	}
 )
 
@@ -60,13 +72,38 @@ func IsNormalOperation(t OperationType) bool {
 	switch t {
 	case TypeCreateAccount, TypePayment,
 		TypeCongressVoting, TypeCongressVotingResult,
-		TypeUnfreezingRequest, TypeInflationPF:
+		TypeUnfreezingRequest, TypeInflationPF,
+		TypeSetSigners, TypeAccountMerge,
+		TypeValidatorUpdate, TypeDelegate:
 		return true
 	default:
 		return false
 	}
 }
 
+// ThresholdLevel decides which one of `Thresholds` is required to authorize
+// the operation.
+type ThresholdLevel byte
+
+const (
+	ThresholdLow ThresholdLevel = iota
+	ThresholdMedium
+	ThresholdHigh
+)
+
+// GetThresholdLevel returns the `ThresholdLevel` of operation type; changing
+// the signers of account and removing account need the highest one.
+func GetThresholdLevel(t OperationType) ThresholdLevel {
+	switch t {
+	case TypeSetSigners, TypeAccountMerge, TypeValidatorUpdate:
+		return ThresholdHigh
+	case TypeUnfreezingRequest:
+		return ThresholdLow
+	default:
+		return ThresholdMedium
+	}
+}
+
 type Operation struct {
 	H Header
 	B Body
@@ -91,6 +128,18 @@ func NewOperation(opb Body) (op Operation, err error) {
 		t = TypeCongressVotingResult
 	case InflationPF:
 		t = TypeInflationPF
+	case SetSigners:
+		t = TypeSetSigners
+	case AccountMerge:
+		t = TypeAccountMerge
+	case ValidatorUpdate:
+		t = TypeValidatorUpdate
+	case DistributeTxFee:
+		t = TypeDistributeTxFee
+	case Delegate:
+		t = TypeDelegate
+	case DelegationReward:
+		t = TypeDelegationReward
 	default:
 		err = errors.UnknownOperationType
 		return
@@ -262,6 +311,18 @@ func newBodyFromType(ty OperationType) (interface{}, error) {
 		return &UnfreezeRequest{}, nil
 	case TypeInflationPF:
 		return &InflationPF{}, nil
+	case TypeSetSigners:
+		return &SetSigners{}, nil
+	case TypeAccountMerge:
+		return &AccountMerge{}, nil
+	case TypeValidatorUpdate:
+		return &ValidatorUpdate{}, nil
+	case TypeDistributeTxFee:
+		return &DistributeTxFee{}, nil
+	case TypeDelegate:
+		return &Delegate{}, nil
+	case TypeDelegationReward:
+		return &DelegationReward{}, nil
 	default:
 		return nil, errors.InvalidOperation
 	}
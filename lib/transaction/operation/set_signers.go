@@ -0,0 +1,92 @@
+package operation
+
+import (
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+// Signer is the additional key, which can sign the transaction on behalf of
+// the account with it's `Weight`.
+type Signer struct {
+	Address string `json:"address"`
+	Weight  uint8  `json:"weight"`
+}
+
+// Thresholds is the minimum sum of signer weights, which is required to
+// authorize the operations by it's `ThresholdLevel`.
+type Thresholds struct {
+	Low    uint8 `json:"low"`
+	Medium uint8 `json:"medium"`
+	High   uint8 `json:"high"`
+}
+
+func (t Thresholds) Get(level ThresholdLevel) uint8 {
+	switch level {
+	case ThresholdLow:
+		return t.Low
+	case ThresholdHigh:
+		return t.High
+	default:
+		return t.Medium
+	}
+}
+
+// SetSigners replaces the signers and thresholds of the source account.
+// `MasterWeight` is the weight of the key of source account itself.
+type SetSigners struct {
+	MasterWeight uint8      `json:"master_weight"`
+	Signers      []Signer   `json:"signers"`
+	Thresholds   Thresholds `json:"thresholds"`
+}
+
+func NewSetSigners(masterWeight uint8, thresholds Thresholds, signers ...Signer) SetSigners {
+	return SetSigners{
+		MasterWeight: masterWeight,
+		Signers:      signers,
+		Thresholds:   thresholds,
+	}
+}
+
+func (o SetSigners) IsWellFormed(common.Config) (err error) {
+	if len(o.Signers) > common.MaxSignersInAccount {
+		err = errors.TooManySigners
+		return
+	}
+
+	if o.Thresholds.Low > o.Thresholds.Medium || o.Thresholds.Medium > o.Thresholds.High {
+		err = errors.InvalidThresholds
+		return
+	}
+
+	var addresses []string
+	total := uint64(o.MasterWeight)
+	for _, s := range o.Signers {
+		if _, err = keypair.Parse(s.Address); err != nil {
+			err = errors.BadPublicAddress
+			return
+		}
+		if s.Weight < 1 {
+			err = errors.InvalidOperation
+			return
+		}
+		if _, found := common.InStringArray(addresses, s.Address); found {
+			err = errors.DuplicatedSigner
+			return
+		}
+		addresses = append(addresses, s.Address)
+		total += uint64(s.Weight)
+	}
+
+	// the account must not be locked out by it's own signers
+	if total < 1 || total < uint64(o.Thresholds.High) {
+		err = errors.InvalidThresholds
+		return
+	}
+
+	return
+}
+
+func (o SetSigners) HasFee() bool {
+	return true
+}
@@ -0,0 +1,96 @@
+package operation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestSetSignersOperation(t *testing.T) {
+	kp0 := keypair.Random()
+	kp1 := keypair.Random()
+
+	conf := common.NewTestConfig()
+	{ // 2 of 3
+		o := NewSetSigners(
+			1,
+			Thresholds{Low: 1, Medium: 2, High: 2},
+			Signer{Address: kp0.Address(), Weight: 1},
+			Signer{Address: kp1.Address(), Weight: 1},
+		)
+		require.NoError(t, o.IsWellFormed(conf))
+	}
+
+	{ // thresholds are not in order
+		o := NewSetSigners(
+			1,
+			Thresholds{Low: 2, Medium: 1, High: 2},
+			Signer{Address: kp0.Address(), Weight: 1},
+		)
+		require.Equal(t, errors.InvalidThresholds, o.IsWellFormed(conf))
+	}
+
+	{ // signers can not reach the high threshold
+		o := NewSetSigners(
+			0,
+			Thresholds{Low: 1, Medium: 1, High: 3},
+			Signer{Address: kp0.Address(), Weight: 1},
+			Signer{Address: kp1.Address(), Weight: 1},
+		)
+		require.Equal(t, errors.InvalidThresholds, o.IsWellFormed(conf))
+	}
+
+	{ // duplicated signer
+		o := NewSetSigners(
+			1,
+			Thresholds{Low: 1, Medium: 1, High: 1},
+			Signer{Address: kp0.Address(), Weight: 1},
+			Signer{Address: kp0.Address(), Weight: 1},
+		)
+		require.Equal(t, errors.DuplicatedSigner, o.IsWellFormed(conf))
+	}
+
+	{ // bad signer address
+		o := NewSetSigners(
+			1,
+			Thresholds{Low: 1, Medium: 1, High: 1},
+			Signer{Address: "showme", Weight: 1},
+		)
+		require.Equal(t, errors.BadPublicAddress, o.IsWellFormed(conf))
+	}
+
+	{ // zero weight
+		o := NewSetSigners(
+			1,
+			Thresholds{Low: 1, Medium: 1, High: 1},
+			Signer{Address: kp0.Address(), Weight: 0},
+		)
+		require.Equal(t, errors.InvalidOperation, o.IsWellFormed(conf))
+	}
+
+	{ // too many signers
+		var signers []Signer
+		for i := 0; i < common.MaxSignersInAccount+1; i++ {
+			signers = append(signers, Signer{Address: keypair.Random().Address(), Weight: 1})
+		}
+		o := NewSetSigners(1, Thresholds{Low: 1, Medium: 1, High: 1}, signers...)
+		require.Equal(t, errors.TooManySigners, o.IsWellFormed(conf))
+	}
+}
+
+func TestSetSignersOperationRLP(t *testing.T) {
+	opb := NewSetSigners(
+		1,
+		Thresholds{Low: 1, Medium: 2, High: 2},
+		Signer{Address: keypair.Random().Address(), Weight: 1},
+	)
+	op, err := NewOperation(opb)
+	require.NoError(t, err)
+	require.Equal(t, TypeSetSigners, op.H.Type)
+
+	common.CheckRoundTripRLP(t, op)
+}
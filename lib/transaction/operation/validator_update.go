@@ -0,0 +1,64 @@
+package operation
+
+import (
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+type ValidatorUpdateAction string
+
+const (
+	ValidatorUpdateAdd    ValidatorUpdateAction = "add"
+	ValidatorUpdateRemove ValidatorUpdateAction = "remove"
+)
+
+// ValidatorUpdate schedules to add or remove the validator at `Height`; the
+// validators switch to the new validator set from the block of `Height`.
+// Like `CongressVoting`, only the congress account can submit it.
+type ValidatorUpdate struct {
+	Height   uint64                `json:"height"`
+	Action   ValidatorUpdateAction `json:"action"`
+	Address  string                `json:"address"`
+	Endpoint string                `json:"endpoint"` // optional; if empty, it will be discovered
+}
+
+func NewValidatorUpdate(height uint64, action ValidatorUpdateAction, address, endpoint string) ValidatorUpdate {
+	return ValidatorUpdate{
+		Height:   height,
+		Action:   action,
+		Address:  address,
+		Endpoint: endpoint,
+	}
+}
+
+func (o ValidatorUpdate) IsWellFormed(common.Config) (err error) {
+	if o.Height <= common.GenesisBlockHeight {
+		return errors.InvalidOperation
+	}
+
+	if _, err = keypair.Parse(o.Address); err != nil {
+		return errors.BadPublicAddress
+	}
+
+	switch o.Action {
+	case ValidatorUpdateAdd:
+		if len(o.Endpoint) > 0 {
+			if _, err = common.NewEndpointFromString(o.Endpoint); err != nil {
+				return errors.InvalidOperation.Clone().SetData("error", err)
+			}
+		}
+	case ValidatorUpdateRemove:
+		if len(o.Endpoint) > 0 {
+			return errors.InvalidOperation
+		}
+	default:
+		return errors.InvalidOperation
+	}
+
+	return
+}
+
+func (o ValidatorUpdate) HasFee() bool {
+	return true
+}
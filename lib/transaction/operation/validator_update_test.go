@@ -0,0 +1,52 @@
+package operation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestValidatorUpdateOperation(t *testing.T) {
+	conf := common.NewTestConfig()
+	address := keypair.Random().Address()
+
+	require.NoError(t, NewValidatorUpdate(10, ValidatorUpdateAdd, address, "https://localhost:12345").IsWellFormed(conf))
+	require.NoError(t, NewValidatorUpdate(10, ValidatorUpdateAdd, address, "").IsWellFormed(conf))
+	require.NoError(t, NewValidatorUpdate(10, ValidatorUpdateRemove, address, "").IsWellFormed(conf))
+
+	{ // genesis height
+		o := NewValidatorUpdate(common.GenesisBlockHeight, ValidatorUpdateAdd, address, "")
+		require.Equal(t, errors.InvalidOperation, o.IsWellFormed(conf))
+	}
+
+	{ // bad address
+		o := NewValidatorUpdate(10, ValidatorUpdateAdd, "showme", "")
+		require.Equal(t, errors.BadPublicAddress, o.IsWellFormed(conf))
+	}
+
+	{ // unknown action
+		o := NewValidatorUpdate(10, ValidatorUpdateAction("findme"), address, "")
+		require.Equal(t, errors.InvalidOperation, o.IsWellFormed(conf))
+	}
+
+	{ // remove with endpoint
+		o := NewValidatorUpdate(10, ValidatorUpdateRemove, address, "https://localhost:12345")
+		require.Equal(t, errors.InvalidOperation, o.IsWellFormed(conf))
+	}
+
+	{ // serialization
+		op, err := NewOperation(NewValidatorUpdate(10, ValidatorUpdateAdd, address, "https://localhost:12345"))
+		require.NoError(t, err)
+		require.Equal(t, TypeValidatorUpdate, op.H.Type)
+
+		var decoded Operation
+		require.NoError(t, decoded.UnmarshalJSON(common.MustMarshalJSON(op)))
+		require.Equal(t, op, decoded)
+
+		common.CheckRoundTripRLP(t, op)
+	}
+}
@@ -1,8 +1,11 @@
 package transaction
 
 import (
+	"container/heap"
 	"container/list"
+	"math/bits"
 	"sync"
+	"time"
 
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/errors"
@@ -12,25 +15,57 @@ import (
 type Pool struct {
 	sync.RWMutex
 
-	Pool    map[ /* Transaction.GetHash() */ string]Transaction
-	sources map[ /* Transaction.Source() */ string] /* Transaction.GetHash() */ string
+	Pool map[ /* Transaction.GetHash() */ string]Transaction
+	// the pending transactions of same source are kept in order of
+	// `SequenceID`; the sequence IDs are consecutive.
+	sources map[ /* Transaction.Source() */ string][] /* Transaction.GetHash() */ string
 
 	hashList *list.List // Transaction.GetHash()
 	hashMap  map[ /* Transaction.GetHash() */ string]*list.Element
 
+	added     map[ /* Transaction.GetHash() */ string]PoolEntry
+	lastOrder uint64
+
+	journal *PoolJournal
+
 	cfg common.Config
 }
 
 func NewPool(cfg common.Config) *Pool {
 	return &Pool{
 		Pool:     map[string]Transaction{},
-		sources:  map[string]string{},
+		sources:  map[string][]string{},
 		hashList: list.New(),
 		hashMap:  make(map[string]*list.Element),
+		added:    map[string]PoolEntry{},
 		cfg:      cfg,
 	}
 }
 
+// PoolEntry is the pending transaction with the time added to the pool.
+// `Order` increases by the time added, so it can be used as the cursor of the
+// pending transactions.
+type PoolEntry struct {
+	Transaction Transaction
+	Added       time.Time
+	Order       uint64
+}
+
+// SetJournal sets the `PoolJournal`; the transactions added to or removed from
+// the pool are written to the journal. The journal does not block the pool,
+// the failed writes are corrected when the journal is restored.
+func (tp *Pool) SetJournal(journal *PoolJournal) {
+	tp.Lock()
+	defer tp.Unlock()
+
+	tp.journal = journal
+}
+
+// ClientLimit is the maximum number of transactions from clients.
+func (tp *Pool) ClientLimit() int {
+	return tp.cfg.TxPoolClientLimit
+}
+
 func (tp *Pool) Len() int {
 	tp.RLock()
 	defer tp.RUnlock()
@@ -54,40 +89,245 @@ func (tp *Pool) Get(hash string) (Transaction, bool) {
 	return tx, found
 }
 
+// Entry returns the pending transaction with the time added.
+func (tp *Pool) Entry(hash string) (PoolEntry, bool) {
+	tp.RLock()
+	defer tp.RUnlock()
+
+	entry, found := tp.added[hash]
+	return entry, found
+}
+
+// Entries returns the pending transactions in order of the time added.
+func (tp *Pool) Entries() []PoolEntry {
+	tp.RLock()
+	defer tp.RUnlock()
+
+	entries := make([]PoolEntry, 0, len(tp.Pool))
+	for e := tp.hashList.Front(); e != nil; e = e.Next() {
+		if hash, ok := e.Value.(string); ok {
+			entries = append(entries, tp.added[hash])
+		}
+	}
+
+	return entries
+}
+
+// GetFromSource returns the first pending transaction of source, which has the
+// lowest sequence ID.
 func (tp *Pool) GetFromSource(source string) (Transaction, bool) {
 	tp.RLock()
 	defer tp.RUnlock()
-	hash, found := tp.sources[source]
-	if !found {
+
+	hashes := tp.sources[source]
+	if len(hashes) < 1 {
 		return Transaction{}, false
 	}
-	return tp.Get(hash)
+	tx, found := tp.Pool[hashes[0]]
+	return tx, found
+}
+
+// PendingFromSource returns the pending transactions of source in order of
+// sequence ID.
+func (tp *Pool) PendingFromSource(source string) (txs []Transaction) {
+	tp.RLock()
+	defer tp.RUnlock()
+
+	for _, hash := range tp.sources[source] {
+		txs = append(txs, tp.Pool[hash])
+	}
+
+	return
+}
+
+// comparePriority compares the fee per operation of transactions; it returns
+// positive number if `a` has the higher priority than `b`.
+func comparePriority(a, b Transaction) int {
+	// a.Fee / len(a.Operations) vs b.Fee / len(b.Operations)
+	ah, al := bits.Mul64(uint64(a.B.Fee), uint64(len(b.B.Operations)))
+	bh, bl := bits.Mul64(uint64(b.B.Fee), uint64(len(a.B.Operations)))
+
+	switch {
+	case ah > bh || (ah == bh && al > bl):
+		return 1
+	case ah < bh || (ah == bh && al < bl):
+		return -1
+	default:
+		return 0
+	}
+}
+
+// CanReplace checks `tx` can replace `existing` in the pool; the transaction,
+// which has same source and sequence ID, can replace the existing one with
+// the strictly higher fee.
+func CanReplace(existing, tx Transaction) error {
+	if existing.Source() != tx.Source() || existing.B.SequenceID != tx.B.SequenceID {
+		return errors.TransactionSameSourceInPool
+	}
+	if tx.B.Fee <= existing.B.Fee {
+		return errors.TransactionReplacementUnderpriced
+	}
+
+	return nil
+}
+
+// CheckSource checks `tx` can be added with the pending transactions of same
+// source; `tx` must replace the pending one by fee or follow the last one.
+func (tp *Pool) CheckSource(tx Transaction) error {
+	tp.RLock()
+	defer tp.RUnlock()
+
+	_, err := tp.checkSourceUnlocked(tx)
+	return err
+}
+
+// checkSourceUnlocked returns the index of pending transaction, which is
+// replaced by `tx`; if `tx` follows the pending transactions, -1.
+func (tp *Pool) checkSourceUnlocked(tx Transaction) (int, error) {
+	hashes := tp.sources[tx.Source()]
+	if len(hashes) < 1 {
+		return -1, nil
+	}
+
+	first := tp.Pool[hashes[0]]
+	last := tp.Pool[hashes[len(hashes)-1]]
+	switch {
+	case tx.B.SequenceID == last.B.SequenceID+1:
+		return -1, nil
+	case tx.B.SequenceID < first.B.SequenceID || tx.B.SequenceID > last.B.SequenceID:
+		return -1, errors.TransactionInvalidSequenceID
+	}
+
+	// replace-by-fee
+	index := int(tx.B.SequenceID - first.B.SequenceID)
+	if err := CanReplace(tp.Pool[hashes[index]], tx); err != nil {
+		return -1, err
+	}
+
+	return index, nil
 }
 
 func (tp *Pool) add(tx Transaction, limit int) error {
 	txHash := tx.GetHash()
-	if tp.Has(txHash) {
+
+	tp.Lock()
+	defer tp.Unlock()
+
+	if _, found := tp.Pool[txHash]; found {
 		return errors.TransactionAlreadyExistsInPool
 	}
 
-	if limit > 0 && tp.Len() >= limit {
-		return errors.TransactionPoolFull
+	index, err := tp.checkSourceUnlocked(tx)
+	if err != nil {
+		return err
 	}
 
-	metrics.TxPool.AddSize(1)
+	if index >= 0 {
+		// the replaced transaction keeps it's place in the queue of source
+		hashes := tp.sources[tx.Source()]
+		tp.deleteUnlocked(hashes[index])
+		hashes[index] = txHash
+		metrics.TxPool.AddEvicted(metrics.TxPoolEvictedReplaced, 1)
+	} else {
+		if limit > 0 && len(tp.Pool) >= limit {
+			// the transaction, which has the lowest fee per operation, is
+			// evicted for the higher one
+			lowest := tp.lowestPriorityUnlocked(tx.Source())
+			if len(lowest) < 1 || comparePriority(tx, tp.Pool[lowest]) <= 0 {
+				return errors.TransactionPoolFull
+			}
+			tp.removeUnlocked(lowest)
+			metrics.TxPool.AddEvicted(metrics.TxPoolEvictedUnderpriced, 1)
+		}
 
-	tp.Lock()
-	defer tp.Unlock()
+		tp.sources[tx.Source()] = append(tp.sources[tx.Source()], txHash)
+		metrics.TxPool.AddSize(1)
+	}
 
 	tp.Pool[txHash] = tx
-	tp.sources[tx.Source()] = txHash
 
 	e := tp.hashList.PushBack(txHash)
 	tp.hashMap[txHash] = e
 
+	tp.lastOrder++
+	tp.added[txHash] = PoolEntry{Transaction: tx, Added: time.Now(), Order: tp.lastOrder}
+
+	if tp.journal != nil {
+		tp.journal.Add(tx)
+	}
+
 	return nil
 }
 
+// lowestPriorityUnlocked returns the hash of transaction, which has the lowest
+// fee per operation; among the same fee, the latest one is returned. Only the
+// last pending transaction of each source can be evicted and the transactions
+// of `exclude` source are not evicted.
+func (tp *Pool) lowestPriorityUnlocked(exclude string) (lowest string) {
+	for e := tp.hashList.Back(); e != nil; e = e.Prev() {
+		hash := e.Value.(string)
+		tx := tp.Pool[hash]
+		if tx.Source() == exclude {
+			continue
+		}
+		if hashes := tp.sources[tx.Source()]; hashes[len(hashes)-1] != hash {
+			continue
+		}
+		if len(lowest) < 1 || comparePriority(tx, tp.Pool[lowest]) < 0 {
+			lowest = hash
+		}
+	}
+
+	return
+}
+
+// deleteUnlocked deletes the transaction, but it does not touch the queue of
+// source.
+func (tp *Pool) deleteUnlocked(hash string) {
+	delete(tp.Pool, hash)
+	delete(tp.added, hash)
+	if e, ok := tp.hashMap[hash]; ok {
+		tp.hashList.Remove(e)
+		delete(tp.hashMap, hash)
+	}
+
+	if tp.journal != nil {
+		tp.journal.Remove(hash)
+	}
+}
+
+// removeUnlocked removes the transaction with the following transactions of
+// same source; without it, they can not be included in block. It returns the
+// removed transactions.
+func (tp *Pool) removeUnlocked(hash string) (removed []string) {
+	tx, found := tp.Pool[hash]
+	if !found {
+		return
+	}
+
+	hashes := tp.sources[tx.Source()]
+	for i, h := range hashes {
+		if h != hash {
+			continue
+		}
+		for _, following := range hashes[i:] {
+			tp.deleteUnlocked(following)
+			metrics.TxPool.AddSize(-1)
+			removed = append(removed, following)
+		}
+		hashes = hashes[:i]
+		break
+	}
+
+	if len(hashes) < 1 {
+		delete(tp.sources, tx.Source())
+	} else {
+		tp.sources[tx.Source()] = hashes
+	}
+
+	return
+}
+
 func (tp *Pool) AddFromClient(tx Transaction) error {
 	return tp.add(tx, tp.cfg.TxPoolClientLimit)
 }
@@ -108,21 +348,9 @@ func (tp *Pool) Remove(hashes ...string) {
 	tp.Lock()
 	defer tp.Unlock()
 
-	var num int
 	for _, hash := range hashes {
-		if tx, found := tp.Pool[hash]; found {
-			delete(tp.sources, tx.Source())
-			delete(tp.Pool, hash)
-			if e, ok := tp.hashMap[hash]; ok {
-				tp.hashList.Remove(e)
-				delete(tp.hashMap, hash)
-			}
-			num++
-		}
+		tp.removeUnlocked(hash)
 	}
-
-	metrics.TxPool.AddSize(-num)
-
 }
 
 func (tp *Pool) RemoveFromSources(sources ...string) {
@@ -133,24 +361,63 @@ func (tp *Pool) RemoveFromSources(sources ...string) {
 	tp.Lock()
 	defer tp.Unlock()
 
-	var num int
 	for _, source := range sources {
-		if hash, found := tp.sources[source]; found {
-			if _, found := tp.Pool[hash]; found {
-				delete(tp.sources, source)
-				delete(tp.Pool, hash)
-				if e, ok := tp.hashMap[hash]; ok {
-					tp.hashList.Remove(e)
-					delete(tp.hashMap, hash)
-				}
-				num++
-			}
+		if hashes, found := tp.sources[source]; found {
+			tp.removeUnlocked(hashes[0])
 		}
 	}
+}
 
-	metrics.TxPool.AddSize(-num)
+// RemoveStale removes the pending transactions of source, which have the lower
+// sequence ID than `sequenceID`, the current sequence ID of account; the
+// following transactions are kept.
+func (tp *Pool) RemoveStale(source string, sequenceID uint64) {
+	tp.Lock()
+	defer tp.Unlock()
+
+	hashes := tp.sources[source]
+	for len(hashes) > 0 && tp.Pool[hashes[0]].B.SequenceID < sequenceID {
+		tp.deleteUnlocked(hashes[0])
+		metrics.TxPool.AddSize(-1)
+		hashes = hashes[1:]
+	}
+
+	if len(hashes) < 1 {
+		delete(tp.sources, source)
+	} else {
+		tp.sources[source] = hashes
+	}
 }
 
+// RemoveExpired removes the transactions, which can not be included in the
+// block of `height` and the later blocks by it's `TimeBounds`, with the
+// following transactions of same source. It returns the removed transactions.
+func (tp *Pool) RemoveExpired(height uint64, proposedTime time.Time) (removed []string) {
+	tp.Lock()
+	defer tp.Unlock()
+
+	var expired []string
+	for hash, tx := range tp.Pool {
+		if tx.B.TimeBounds == nil {
+			continue
+		}
+		if tx.B.TimeBounds.IsExpired(height, proposedTime) {
+			expired = append(expired, hash)
+		}
+	}
+
+	for _, hash := range expired {
+		removed = append(removed, tp.removeUnlocked(hash)...)
+	}
+	metrics.TxPool.AddEvicted(metrics.TxPoolEvictedExpired, len(removed))
+
+	return
+}
+
+// AvailableTransactions returns the transactions in order of fee per
+// operation; the transactions of same fee are ordered by the time added. The
+// transactions of same source are returned in order of sequence ID, so the
+// following transaction comes after the previous one.
 func (tp *Pool) AvailableTransactions(transactionLimit int) []string {
 	if transactionLimit < 1 {
 		return nil
@@ -159,21 +426,63 @@ func (tp *Pool) AvailableTransactions(transactionLimit int) []string {
 	tp.RLock()
 	defer tp.RUnlock()
 
-	var ret []string
-	var cnt int
-	// first ouput by order older hash
+	pq := &poolQueue{pool: tp.Pool, order: map[string]int{}}
 	for e := tp.hashList.Front(); e != nil; e = e.Next() {
-		if cnt >= transactionLimit {
-			return ret
+		if hash, ok := e.Value.(string); ok {
+			pq.order[hash] = len(pq.order)
 		}
-		hash, ok := e.Value.(string)
-		if ok {
-			ret = append(ret, hash)
-			cnt++
+	}
+
+	// next index in the queue of source
+	next := map[string]int{}
+	for source, hashes := range tp.sources {
+		pq.hashes = append(pq.hashes, hashes[0])
+		next[source] = 1
+	}
+	heap.Init(pq)
+
+	var hashes []string
+	for pq.Len() > 0 && len(hashes) < transactionLimit {
+		hash := heap.Pop(pq).(string)
+		hashes = append(hashes, hash)
+
+		source := tp.Pool[hash].Source()
+		if i := next[source]; i < len(tp.sources[source]) {
+			heap.Push(pq, tp.sources[source][i])
+			next[source] = i + 1
 		}
 	}
 
-	return ret
+	return hashes
+}
+
+// poolQueue is the `heap.Interface` of transaction hashes by fee per operation
+// and the time added.
+type poolQueue struct {
+	hashes []string
+	pool   map[string]Transaction
+	order  map[string]int
+}
+
+func (pq poolQueue) Len() int { return len(pq.hashes) }
+
+func (pq poolQueue) Less(i, j int) bool {
+	a, b := pq.hashes[i], pq.hashes[j]
+	if c := comparePriority(pq.pool[a], pq.pool[b]); c != 0 {
+		return c > 0
+	}
+	return pq.order[a] < pq.order[b]
+}
+
+func (pq poolQueue) Swap(i, j int) { pq.hashes[i], pq.hashes[j] = pq.hashes[j], pq.hashes[i] }
+
+func (pq *poolQueue) Push(x interface{}) { pq.hashes = append(pq.hashes, x.(string)) }
+
+func (pq *poolQueue) Pop() interface{} {
+	n := len(pq.hashes)
+	hash := pq.hashes[n-1]
+	pq.hashes = pq.hashes[:n-1]
+	return hash
 }
 
 func (tp *Pool) IsSameSource(source string) (found bool) {
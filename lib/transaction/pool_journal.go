@@ -0,0 +1,66 @@
+package transaction
+
+import (
+	"fmt"
+	"sort"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+)
+
+// PoolJournal keeps the pending transactions of `Pool` in storage, so the pool
+// can be restored after the node restarts.
+type PoolJournal struct {
+	st *storage.LevelDBBackend
+}
+
+func NewPoolJournal(st *storage.LevelDBBackend) *PoolJournal {
+	return &PoolJournal{st: st}
+}
+
+func GetPoolJournalKey(hash string) string {
+	return fmt.Sprintf("%s%s", common.TransactionPoolJournalPrefix, hash)
+}
+
+func (j *PoolJournal) Add(tx Transaction) error {
+	key := GetPoolJournalKey(tx.GetHash())
+
+	if exists, err := j.st.Has(key); err != nil || exists {
+		return err
+	}
+
+	return j.st.New(key, tx)
+}
+
+func (j *PoolJournal) Remove(hash string) error {
+	if err := j.st.Remove(GetPoolJournalKey(hash)); err != nil && err != errors.StorageRecordDoesNotExist {
+		return err
+	}
+
+	return nil
+}
+
+// Transactions returns the journaled transactions in order of sequence ID, so
+// the transactions of same source can be added to `Pool` in order.
+func (j *PoolJournal) Transactions() (txs []Transaction) {
+	iterFunc, closeFunc := j.st.GetIterator(common.TransactionPoolJournalPrefix, nil)
+	defer closeFunc()
+
+	for {
+		item, hasNext := iterFunc()
+		if !hasNext {
+			break
+		}
+
+		var tx Transaction
+		common.MustUnmarshalJSON(item.Value, &tx)
+		txs = append(txs, tx)
+	}
+
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].B.SequenceID < txs[j].B.SequenceID
+	})
+
+	return
+}
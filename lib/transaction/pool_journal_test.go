@@ -0,0 +1,44 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/storage"
+)
+
+func TestPoolJournal(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	conf := common.NewTestConfig()
+	journal := NewPoolJournal(st)
+	pool := NewPool(conf)
+	pool.SetJournal(journal)
+
+	kp := keypair.Random()
+	tx0 := makePoolTestTransaction(conf, kp, 1, common.BaseFee, 0)
+	tx1 := makePoolTestTransaction(conf, kp, 1, common.BaseFee, 1)
+	other := makePoolTestTransaction(conf, keypair.Random(), 1, common.BaseFee, 3)
+
+	// added in reverse order of sequence id
+	require.NoError(t, pool.Add(other))
+	require.NoError(t, pool.Add(tx0))
+	require.NoError(t, pool.Add(tx1))
+	require.Equal(t, []Transaction{tx0, tx1, other}, journal.Transactions())
+
+	// replaced
+	higher := makePoolTestTransaction(conf, kp, 1, common.BaseFee*2, 1)
+	require.NoError(t, pool.Add(higher))
+	require.Equal(t, []Transaction{tx0, higher, other}, journal.Transactions())
+
+	// removed with the following transaction
+	pool.Remove(tx0.GetHash())
+	require.Equal(t, []Transaction{other}, journal.Transactions())
+
+	// removing unknown transaction is not error
+	require.NoError(t, journal.Remove(tx0.GetHash()))
+}
@@ -0,0 +1,152 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func makePoolTestTransaction(conf common.Config, kp *keypair.Full, ops int, fee common.Amount, sequenceID uint64) Transaction {
+	tx := TestMakeTransactionWithKeypair(conf.NetworkID, ops, kp)
+	tx.B.Fee = fee
+	tx.B.SequenceID = sequenceID
+	tx.Sign(kp, conf.NetworkID)
+
+	return tx
+}
+
+func TestPoolFeePriority(t *testing.T) {
+	conf := common.NewTestConfig()
+	pool := NewPool(conf)
+
+	tx0 := makePoolTestTransaction(conf, keypair.Random(), 1, common.BaseFee, 0)
+	tx1 := makePoolTestTransaction(conf, keypair.Random(), 2, common.BaseFee*6, 0) // 3x per operation
+	tx2 := makePoolTestTransaction(conf, keypair.Random(), 1, common.BaseFee*2, 0)
+	tx3 := makePoolTestTransaction(conf, keypair.Random(), 1, common.BaseFee, 0)
+	tx4 := makePoolTestTransaction(conf, keypair.Random(), 3, common.BaseFee*6, 0) // 2x per operation
+
+	for _, tx := range []Transaction{tx0, tx1, tx2, tx3, tx4} {
+		require.NoError(t, pool.Add(tx))
+	}
+
+	// same fee per operation is ordered by the time added
+	require.Equal(
+		t,
+		[]string{tx1.GetHash(), tx2.GetHash(), tx4.GetHash(), tx0.GetHash(), tx3.GetHash()},
+		pool.AvailableTransactions(10),
+	)
+	require.Equal(t, []string{tx1.GetHash(), tx2.GetHash()}, pool.AvailableTransactions(2))
+}
+
+func TestPoolReplaceByFee(t *testing.T) {
+	conf := common.NewTestConfig()
+	pool := NewPool(conf)
+
+	kp := keypair.Random()
+	tx := makePoolTestTransaction(conf, kp, 1, common.BaseFee, 3)
+	require.NoError(t, pool.Add(tx))
+
+	{ // same fee can not replace
+		same := makePoolTestTransaction(conf, kp, 2, common.BaseFee, 3)
+		require.Equal(t, errors.TransactionReplacementUnderpriced, pool.Add(same))
+		require.Equal(t, errors.TransactionReplacementUnderpriced, CanReplace(tx, same))
+	}
+
+	{ // different sequence id can not replace
+		other := makePoolTestTransaction(conf, kp, 1, common.BaseFee*2, 4)
+		require.Equal(t, errors.TransactionSameSourceInPool, CanReplace(tx, other))
+	}
+
+	higher := makePoolTestTransaction(conf, kp, 1, common.BaseFee+1, 3)
+	require.NoError(t, CanReplace(tx, higher))
+	require.NoError(t, pool.Add(higher))
+
+	require.Equal(t, 1, pool.Len())
+	require.False(t, pool.Has(tx.GetHash()))
+	found, ok := pool.GetFromSource(kp.Address())
+	require.True(t, ok)
+	require.Equal(t, higher.GetHash(), found.GetHash())
+	require.Equal(t, []string{higher.GetHash()}, pool.AvailableTransactions(10))
+}
+
+func TestPoolEvictUnderpriced(t *testing.T) {
+	conf := common.NewTestConfig()
+	conf.TxPoolClientLimit = 2
+	pool := NewPool(conf)
+
+	tx0 := makePoolTestTransaction(conf, keypair.Random(), 1, common.BaseFee, 0)
+	tx1 := makePoolTestTransaction(conf, keypair.Random(), 1, common.BaseFee*2, 0)
+	require.NoError(t, pool.AddFromClient(tx0))
+	require.NoError(t, pool.AddFromClient(tx1))
+
+	// lower or same fee is rejected
+	tx2 := makePoolTestTransaction(conf, keypair.Random(), 1, common.BaseFee, 0)
+	require.Equal(t, errors.TransactionPoolFull, pool.AddFromClient(tx2))
+
+	// higher fee evicts the lowest
+	tx3 := makePoolTestTransaction(conf, keypair.Random(), 1, common.BaseFee*3, 0)
+	require.NoError(t, pool.AddFromClient(tx3))
+	require.Equal(t, 2, pool.Len())
+	require.False(t, pool.Has(tx0.GetHash()))
+	require.False(t, pool.IsSameSource(tx0.Source()))
+	require.Equal(t, []string{tx3.GetHash(), tx1.GetHash()}, pool.AvailableTransactions(10))
+}
+
+func TestPoolSourceQueue(t *testing.T) {
+	conf := common.NewTestConfig()
+	pool := NewPool(conf)
+
+	kp := keypair.Random()
+	tx0 := makePoolTestTransaction(conf, kp, 1, common.BaseFee, 3)
+	tx1 := makePoolTestTransaction(conf, kp, 1, common.BaseFee*3, 4)
+	tx2 := makePoolTestTransaction(conf, kp, 1, common.BaseFee, 5)
+	other := makePoolTestTransaction(conf, keypair.Random(), 1, common.BaseFee*2, 0)
+
+	for _, tx := range []Transaction{tx0, tx1, tx2, other} {
+		require.NoError(t, pool.Add(tx))
+	}
+
+	{ // sequence id gap
+		gap := makePoolTestTransaction(conf, kp, 1, common.BaseFee, 7)
+		require.Equal(t, errors.TransactionInvalidSequenceID, pool.Add(gap))
+		lower := makePoolTestTransaction(conf, kp, 1, common.BaseFee*2, 2)
+		require.Equal(t, errors.TransactionInvalidSequenceID, pool.Add(lower))
+	}
+
+	found, ok := pool.GetFromSource(kp.Address())
+	require.True(t, ok)
+	require.Equal(t, tx0.GetHash(), found.GetHash())
+	require.Equal(t, []Transaction{tx0, tx1, tx2}, pool.PendingFromSource(kp.Address()))
+
+	// the following transaction comes after the previous one, even if it has
+	// the higher fee
+	require.Equal(
+		t,
+		[]string{other.GetHash(), tx0.GetHash(), tx1.GetHash(), tx2.GetHash()},
+		pool.AvailableTransactions(10),
+	)
+
+	// replace-by-fee keeps the place in queue
+	higher := makePoolTestTransaction(conf, kp, 1, common.BaseFee*2, 4)
+	require.Equal(t, errors.TransactionReplacementUnderpriced, pool.Add(higher))
+	higher = makePoolTestTransaction(conf, kp, 1, common.BaseFee*4, 4)
+	require.NoError(t, pool.Add(higher))
+	require.Equal(t, []Transaction{tx0, higher, tx2}, pool.PendingFromSource(kp.Address()))
+	require.Equal(t, 4, pool.Len())
+
+	// the included transactions are removed; the following one is kept
+	pool.RemoveStale(kp.Address(), 5)
+	require.Equal(t, []Transaction{tx2}, pool.PendingFromSource(kp.Address()))
+	require.Equal(t, 2, pool.Len())
+
+	// removing transaction removes the following ones
+	tx3 := makePoolTestTransaction(conf, kp, 1, common.BaseFee, 6)
+	require.NoError(t, pool.Add(tx3))
+	pool.Remove(tx2.GetHash())
+	require.False(t, pool.IsSameSource(kp.Address()))
+	require.Equal(t, []string{other.GetHash()}, pool.AvailableTransactions(10))
+}
@@ -0,0 +1,93 @@
+package transaction
+
+import (
+	"time"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+)
+
+// TimeBounds limits the block height and the proposed time of block, which
+// the transaction can be included. The zero value of each bound means
+// unbounded and the bounds are inclusive.
+type TimeBounds struct {
+	MinHeight uint64 `json:"min_height"`
+	MaxHeight uint64 `json:"max_height"`
+	MinTime   string `json:"min_time"` // ISO8601
+	MaxTime   string `json:"max_time"` // ISO8601
+}
+
+func (tb TimeBounds) IsWellFormed() (err error) {
+	if tb.MaxHeight > 0 && tb.MinHeight > tb.MaxHeight {
+		err = errors.InvalidTimeBounds.Clone().SetData("error", "min_height is higher than max_height")
+		return
+	}
+
+	var minTime, maxTime time.Time
+	if minTime, err = tb.minTime(); err != nil {
+		return
+	}
+	if maxTime, err = tb.maxTime(); err != nil {
+		return
+	}
+	if !minTime.IsZero() && !maxTime.IsZero() && minTime.After(maxTime) {
+		err = errors.InvalidTimeBounds.Clone().SetData("error", "min_time is after max_time")
+		return
+	}
+
+	return
+}
+
+// Check checks the transaction can be included in the block of `height`,
+// which is proposed at `proposedTime`.
+func (tb TimeBounds) Check(height uint64, proposedTime time.Time) (err error) {
+	if tb.IsExpired(height, proposedTime) {
+		err = errors.TransactionExpired
+		return
+	}
+
+	if height < tb.MinHeight {
+		err = errors.TransactionNotValidYet
+		return
+	}
+	if minTime, _ := tb.minTime(); !minTime.IsZero() && proposedTime.Before(minTime) {
+		err = errors.TransactionNotValidYet
+		return
+	}
+
+	return
+}
+
+// IsExpired returns true when the upper bounds are passed; the transaction
+// can not be included in the block of `height` and the later blocks.
+func (tb TimeBounds) IsExpired(height uint64, proposedTime time.Time) bool {
+	if tb.MaxHeight > 0 && height > tb.MaxHeight {
+		return true
+	}
+	if maxTime, _ := tb.maxTime(); !maxTime.IsZero() && proposedTime.After(maxTime) {
+		return true
+	}
+
+	return false
+}
+
+func (tb TimeBounds) minTime() (t time.Time, err error) {
+	return parseTimeBound(tb.MinTime)
+}
+
+func (tb TimeBounds) maxTime() (t time.Time, err error) {
+	return parseTimeBound(tb.MaxTime)
+}
+
+func parseTimeBound(s string) (t time.Time, err error) {
+	if len(s) < 1 {
+		return
+	}
+
+	if t, err = common.ParseISO8601(s); err != nil {
+		err = errors.InvalidTimeBounds.Clone().SetData("error", err.Error())
+		return
+	}
+
+	return
+}
@@ -0,0 +1,124 @@
+package transaction
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestTimeBoundsIsWellFormed(t *testing.T) {
+	now := time.Now()
+
+	{ // unbounded
+		require.NoError(t, TimeBounds{}.IsWellFormed())
+	}
+
+	{ // height
+		require.NoError(t, TimeBounds{MinHeight: 10, MaxHeight: 10}.IsWellFormed())
+		require.NoError(t, TimeBounds{MinHeight: 10}.IsWellFormed())
+
+		err := TimeBounds{MinHeight: 11, MaxHeight: 10}.IsWellFormed()
+		require.Equal(t, errors.InvalidTimeBounds.Code, err.(*errors.Error).Code)
+	}
+
+	{ // time
+		tb := TimeBounds{
+			MinTime: common.FormatISO8601(now),
+			MaxTime: common.FormatISO8601(now.Add(time.Minute)),
+		}
+		require.NoError(t, tb.IsWellFormed())
+
+		tb.MinTime, tb.MaxTime = tb.MaxTime, tb.MinTime
+		err := tb.IsWellFormed()
+		require.Equal(t, errors.InvalidTimeBounds.Code, err.(*errors.Error).Code)
+
+		err = TimeBounds{MaxTime: "showme"}.IsWellFormed()
+		require.Equal(t, errors.InvalidTimeBounds.Code, err.(*errors.Error).Code)
+	}
+}
+
+func TestTimeBoundsCheck(t *testing.T) {
+	now := time.Now()
+
+	{ // height
+		tb := TimeBounds{MinHeight: 10, MaxHeight: 20}
+		require.Equal(t, errors.TransactionNotValidYet, tb.Check(9, now))
+		require.NoError(t, tb.Check(10, now))
+		require.NoError(t, tb.Check(20, now))
+		require.Equal(t, errors.TransactionExpired, tb.Check(21, now))
+		require.False(t, tb.IsExpired(20, now))
+		require.True(t, tb.IsExpired(21, now))
+	}
+
+	{ // time
+		tb := TimeBounds{
+			MinTime: common.FormatISO8601(now),
+			MaxTime: common.FormatISO8601(now.Add(time.Minute)),
+		}
+		require.Equal(t, errors.TransactionNotValidYet, tb.Check(1, now.Add(-time.Second)))
+		require.NoError(t, tb.Check(1, now))
+		require.NoError(t, tb.Check(1, now.Add(time.Minute)))
+		require.Equal(t, errors.TransactionExpired, tb.Check(1, now.Add(time.Minute+time.Second)))
+	}
+}
+
+func TestTransactionWithTimeBounds(t *testing.T) {
+	conf := common.NewTestConfig()
+
+	kp, tx := TestMakeTransaction(conf.NetworkID, 1)
+	hashWithoutTimeBounds := tx.GetHash()
+
+	tx.B.TimeBounds = &TimeBounds{MaxHeight: 10}
+	tx.Sign(kp, conf.NetworkID)
+	require.NoError(t, tx.IsWellFormed(conf))
+	require.NotEqual(t, hashWithoutTimeBounds, tx.GetHash())
+
+	{ // with memo
+		hashWithoutMemo := tx.GetHash()
+		memo, _ := NewMemo(MemoText, "showme")
+		tx.B.Memo = &memo
+		tx.Sign(kp, conf.NetworkID)
+		require.NoError(t, tx.IsWellFormed(conf))
+		require.NotEqual(t, hashWithoutMemo, tx.GetHash())
+	}
+
+	{ // invalid time bounds
+		tx.B.TimeBounds = &TimeBounds{MinHeight: 11, MaxHeight: 10}
+		tx.Sign(kp, conf.NetworkID)
+		err := tx.IsWellFormed(conf)
+		require.Equal(t, errors.InvalidTimeBounds.Code, err.(*errors.Error).Code)
+	}
+}
+
+func TestPoolRemoveExpired(t *testing.T) {
+	conf := common.NewTestConfig()
+	pool := NewPool(conf)
+
+	_, tx0 := TestMakeTransaction(conf.NetworkID, 1)
+	kp1, tx1 := TestMakeTransaction(conf.NetworkID, 1)
+	tx1.B.TimeBounds = &TimeBounds{MaxHeight: 10}
+	tx1.Sign(kp1, conf.NetworkID)
+	tx1Next := makePoolTestTransaction(conf, kp1, 1, common.BaseFee, tx1.B.SequenceID+1)
+	kp2, tx2 := TestMakeTransaction(conf.NetworkID, 1)
+	tx2.B.TimeBounds = &TimeBounds{MaxTime: common.FormatISO8601(time.Now().Add(time.Minute))}
+	tx2.Sign(kp2, conf.NetworkID)
+
+	for _, tx := range []Transaction{tx0, tx1, tx1Next, tx2} {
+		require.NoError(t, pool.Add(tx))
+	}
+
+	require.Empty(t, pool.RemoveExpired(10, time.Now()))
+	require.Equal(t, 4, pool.Len())
+
+	// the following transaction of same source is also removed
+	require.Equal(t, []string{tx1.GetHash(), tx1Next.GetHash()}, pool.RemoveExpired(11, time.Now()))
+	require.Equal(t, 2, pool.Len())
+
+	require.Equal(t, []string{tx2.GetHash()}, pool.RemoveExpired(11, time.Now().Add(2*time.Minute)))
+	require.Equal(t, 1, pool.Len())
+	require.True(t, pool.Has(tx0.GetHash()))
+}
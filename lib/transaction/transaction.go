@@ -2,6 +2,7 @@ package transaction
 
 import (
 	"encoding/json"
+	"io"
 
 	"github.com/btcsuite/btcutil/base58"
 
@@ -32,6 +33,16 @@ type Header struct {
 	// has to validate it anyway.
 	Hash      string `json:"-"`
 	Signature string `json:"signature"`
+	// Signatures of the additional signers of `Body.Source`; see
+	// `operation.SetSigners`.
+	Signatures []Signature `json:"signatures,omitempty"`
+}
+
+// Signature is the signature of one of the additional signers of the
+// source account.
+type Signature struct {
+	Signer    string `json:"signer"`
+	Signature string `json:"signature"`
 }
 
 type Body struct {
@@ -39,6 +50,39 @@ type Body struct {
 	Fee        common.Amount         `json:"fee"`
 	SequenceID uint64                `json:"sequence_id"`
 	Operations []operation.Operation `json:"operations"`
+	Memo       *Memo                 `json:"memo,omitempty"`
+	TimeBounds *TimeBounds           `json:"time_bounds,omitempty"`
+}
+
+// Implement `common.Encoder`
+// The optional fields are appended only when they are set, so the hash of
+// transaction without them is kept same. The unset optional field followed by
+// the set one is encoded with it's zero value.
+func (tb Body) EncodeRLP(w io.Writer) error {
+	fields := []interface{}{
+		tb.Source,
+		tb.Fee,
+		tb.SequenceID,
+		tb.Operations,
+	}
+
+	var memo Memo
+	if tb.Memo != nil {
+		memo = *tb.Memo
+	}
+	var timeBounds TimeBounds
+	if tb.TimeBounds != nil {
+		timeBounds = *tb.TimeBounds
+	}
+
+	switch {
+	case tb.TimeBounds != nil:
+		fields = append(fields, memo, timeBounds)
+	case tb.Memo != nil:
+		fields = append(fields, memo)
+	}
+
+	return common.Encode(w, fields)
 }
 
 func (tb Body) MakeHash() []byte {
@@ -103,10 +147,21 @@ var TransactionWellFormedCheckerFuncs = []common.CheckerFunc{
 	CheckBaseFee,
 	CheckOperationTypes,
 	CheckOperations,
+	CheckMemo,
+	CheckTimeBounds,
 	CheckVerifySignature,
 }
 
+// IsWellFormed checks the transaction with `common.BaseFee`, the lowest
+// minimum fee.
 func (tx Transaction) IsWellFormed(conf common.Config) (err error) {
+	return tx.IsWellFormedWithMinFee(conf, common.BaseFee)
+}
+
+// IsWellFormedWithMinFee checks the transaction; the fee must not be under
+// `minFee` per operation, which is the minimum fee of the next block, see
+// `block.NextMinFee`.
+func (tx Transaction) IsWellFormedWithMinFee(conf common.Config, minFee common.Amount) (err error) {
 	// TODO check `Version` format with SemVer
 
 	checker := &Checker{
@@ -114,6 +169,7 @@ func (tx Transaction) IsWellFormed(conf common.Config) (err error) {
 		NetworkID:      conf.NetworkID,
 		Transaction:    tx,
 		Conf:           conf,
+		MinFee:         minFee,
 	}
 	if err = common.RunChecker(checker, common.DefaultDeferFunc); err != nil {
 		if _, ok := err.(*errors.Error); !ok {
@@ -176,8 +232,14 @@ func (tx Transaction) TotalAmount(withFee bool) common.Amount {
 	return amount
 }
 
-// TotalBaseFee returns the minimum fee of transaction.
+// TotalBaseFee returns the minimum fee of transaction by `common.BaseFee`.
 func (tx Transaction) TotalBaseFee() common.Amount {
+	return tx.TotalMinFee(common.BaseFee)
+}
+
+// TotalMinFee returns the minimum fee of transaction by `minFee` per
+// operation.
+func (tx Transaction) TotalMinFee(minFee common.Amount) common.Amount {
 	var opsHaveFee int
 	for _, op := range tx.B.Operations {
 		if op.HasFee() {
@@ -188,7 +250,11 @@ func (tx Transaction) TotalBaseFee() common.Amount {
 		return common.Amount(0)
 	}
 
-	return common.BaseFee.MustMult(opsHaveFee)
+	fee, err := minFee.MultInt(opsHaveFee)
+	if err != nil {
+		return common.MaximumBalance
+	}
+	return fee
 }
 
 func (tx Transaction) Serialize() (encoded []byte, err error) {
@@ -211,6 +277,44 @@ func (tx *Transaction) Sign(kp keypair.KP, networkID []byte) {
 	return
 }
 
+// AddSignature appends the signature of the additional signer of the source
+// account. Unlike `Sign`, `Body.Source` is kept as it is.
+func (tx *Transaction) AddSignature(kp keypair.KP, networkID []byte) {
+	tx.H.Hash = tx.B.MakeHashString()
+	signature, _ := keypair.MakeSignature(kp, networkID, tx.H.Hash)
+
+	tx.H.Signatures = append(tx.H.Signatures, Signature{
+		Signer:    kp.Address(),
+		Signature: base58.Encode(signature),
+	})
+
+	return
+}
+
+// AccountMerge returns the `operation.AccountMerge` of transaction if exists.
+func (tx Transaction) AccountMerge() (opb operation.AccountMerge, found bool) {
+	for _, op := range tx.B.Operations {
+		if opb, found = op.B.(operation.AccountMerge); found {
+			return
+		}
+	}
+
+	return
+}
+
+// Signers returns the addresses which signed this transaction, including
+// `Body.Source`.
+func (tx Transaction) Signers() (signers []string) {
+	if len(tx.H.Signature) > 0 {
+		signers = append(signers, tx.B.Source)
+	}
+	for _, s := range tx.H.Signatures {
+		signers = append(signers, s.Signer)
+	}
+
+	return
+}
+
 func (tx Transaction) IsEmpty() bool {
 	return len(tx.GetHash()) < 1
 }
@@ -76,6 +76,22 @@ func (suite *TestSuite) TestIsWellFormedTransactionWithLowerFeeSuite() {
 		require.Equal(suite.T(), errors.InvalidFee, err, "Transaction shouidn't pass Fee checks")
 	}
 
+	{ // fee is lower than len(Operations) * minimum fee
+		kp, tx := TestMakeTransaction(suite.conf.NetworkID, 3)
+		tx.Sign(kp, suite.conf.NetworkID)
+		require.Nil(suite.T(), tx.IsWellFormedWithMinFee(suite.conf, common.BaseFee))
+		require.Equal(suite.T(), errors.InvalidFee, tx.IsWellFormedWithMinFee(suite.conf, common.BaseFee+1))
+
+		tx.B.Fee = (common.BaseFee + 1).MustMult(3)
+		tx.Sign(kp, suite.conf.NetworkID)
+		require.Nil(suite.T(), tx.IsWellFormedWithMinFee(suite.conf, common.BaseFee+1))
+
+		// minimum fee is not under BaseFee
+		tx.B.Fee = common.BaseFee.MustMult(3).MustSub(1)
+		tx.Sign(kp, suite.conf.NetworkID)
+		require.Equal(suite.T(), errors.InvalidFee, tx.IsWellFormedWithMinFee(suite.conf, 1))
+	}
+
 	{ // with CongressVoting, it has zero fee
 		kp, tx := TestMakeTransaction(suite.conf.NetworkID, 3)
 
@@ -194,6 +210,90 @@ func (suite *TestSuite) TestIsWellFormedTransactionMaxOperationsInTransactionSui
 	}
 }
 
+func (suite *TestSuite) TestIsWellFormedTransactionWithSignersSuite() {
+	var err error
+
+	kpSigner := keypair.Random()
+
+	{ // source and additional signer
+		_, tx := TestMakeTransaction(suite.conf.NetworkID, 1)
+		tx.AddSignature(kpSigner, suite.conf.NetworkID)
+		err = tx.IsWellFormed(suite.conf)
+		require.Nil(suite.T(), err)
+		require.Equal(suite.T(), []string{tx.B.Source, kpSigner.Address()}, tx.Signers())
+	}
+
+	{ // only additional signer
+		_, tx := TestMakeTransaction(suite.conf.NetworkID, 1)
+		tx.H.Signature = ""
+		tx.AddSignature(kpSigner, suite.conf.NetworkID)
+		err = tx.IsWellFormed(suite.conf)
+		require.Nil(suite.T(), err)
+		require.Equal(suite.T(), []string{kpSigner.Address()}, tx.Signers())
+	}
+
+	{ // no signature
+		_, tx := TestMakeTransaction(suite.conf.NetworkID, 1)
+		tx.H.Signature = ""
+		err = tx.IsWellFormed(suite.conf)
+		require.Equal(suite.T(), errors.SignatureVerificationFailed, err)
+	}
+
+	{ // duplicated signer
+		_, tx := TestMakeTransaction(suite.conf.NetworkID, 1)
+		tx.AddSignature(kpSigner, suite.conf.NetworkID)
+		tx.AddSignature(kpSigner, suite.conf.NetworkID)
+		err = tx.IsWellFormed(suite.conf)
+		require.Equal(suite.T(), errors.DuplicatedSigner, err)
+	}
+
+	{ // invalid signature of additional signer
+		_, tx := TestMakeTransaction(suite.conf.NetworkID, 1)
+		tx.AddSignature(kpSigner, suite.conf.NetworkID)
+		newSignature, _ := keypair.Random().Sign(append(suite.conf.NetworkID, []byte(tx.B.MakeHashString())...))
+		tx.H.Signatures[0].Signature = base58.Encode(newSignature)
+		err = tx.IsWellFormed(suite.conf)
+		require.NotNil(suite.T(), err)
+	}
+}
+
+func (suite *TestSuite) TestIsWellFormedTransactionWithAccountMergeSuite() {
+	var err error
+
+	mergeOp, _ := operation.NewOperation(operation.NewAccountMerge(keypair.Random().Address()))
+
+	{ // last operation
+		kp, tx := TestMakeTransaction(suite.conf.NetworkID, 1)
+		tx.B.Operations = append(tx.B.Operations, mergeOp)
+		tx.B.Fee = common.BaseFee * 2
+		tx.Sign(kp, suite.conf.NetworkID)
+		err = tx.IsWellFormed(suite.conf)
+		require.NoError(suite.T(), err)
+
+		_, found := tx.AccountMerge()
+		require.True(suite.T(), found)
+	}
+
+	{ // not last operation
+		kp, tx := TestMakeTransaction(suite.conf.NetworkID, 1)
+		tx.B.Operations = append([]operation.Operation{mergeOp}, tx.B.Operations...)
+		tx.B.Fee = common.BaseFee * 2
+		tx.Sign(kp, suite.conf.NetworkID)
+		err = tx.IsWellFormed(suite.conf)
+		require.Equal(suite.T(), errors.AccountMergeNotLastOperation, err)
+	}
+
+	{ // merge to source itself
+		kp, tx := TestMakeTransaction(suite.conf.NetworkID, 1)
+		selfOp, _ := operation.NewOperation(operation.NewAccountMerge(kp.Address()))
+		tx.B.Operations = append(tx.B.Operations, selfOp)
+		tx.B.Fee = common.BaseFee * 2
+		tx.Sign(kp, suite.conf.NetworkID)
+		err = tx.IsWellFormed(suite.conf)
+		require.Equal(suite.T(), errors.InvalidOperation, err)
+	}
+}
+
 func TestTransaction(t *testing.T) {
 	suite.Run(t, new(TestSuite))
 }
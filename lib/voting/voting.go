@@ -11,6 +11,9 @@ const (
 
 type ThresholdPolicy interface {
 	Threshold() int
+	// The threshold for the given number of validators, like the
+	// validators of the past height
+	ThresholdOf(int) int
 	Validators() int
 	// Set the number of validators required for consensus
 	// The parameter must be a strictly positive integer